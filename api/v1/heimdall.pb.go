@@ -31,12 +31,111 @@ var _ = time.Kitchen
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+type Policy_Action int32
+
+const (
+	Policy_ALLOW Policy_Action = 0
+	Policy_DENY  Policy_Action = 1
+)
+
+var Policy_Action_name = map[int32]string{
+	0: "ALLOW",
+	1: "DENY",
+}
+
+var Policy_Action_value = map[string]int32{
+	"ALLOW": 0,
+	"DENY":  1,
+}
+
+func (x Policy_Action) String() string {
+	return proto.EnumName(Policy_Action_name, int32(x))
+}
+
+func (Policy_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{30, 0}
+}
+
+type WatchEvent_Type int32
+
+const (
+	WatchEvent_UNKNOWN WatchEvent_Type = 0
+	// SYNCED is sent once the stream has caught up to the current revision
+	WatchEvent_SYNCED                 WatchEvent_Type = 1
+	WatchEvent_NODE_JOINED            WatchEvent_Type = 2
+	WatchEvent_NODE_LEFT              WatchEvent_Type = 3
+	WatchEvent_PEER_ADDED             WatchEvent_Type = 4
+	WatchEvent_PEER_UPDATED           WatchEvent_Type = 5
+	WatchEvent_PEER_REMOVED           WatchEvent_Type = 6
+	WatchEvent_ROUTE_CREATED          WatchEvent_Type = 7
+	WatchEvent_ROUTE_UPDATED          WatchEvent_Type = 8
+	WatchEvent_ROUTE_DELETED          WatchEvent_Type = 9
+	WatchEvent_PEER_AUTHORIZED        WatchEvent_Type = 10
+	WatchEvent_PEER_DEAUTHORIZED      WatchEvent_Type = 11
+	WatchEvent_POLICY_CREATED         WatchEvent_Type = 12
+	WatchEvent_POLICY_UPDATED         WatchEvent_Type = 13
+	WatchEvent_POLICY_DELETED         WatchEvent_Type = 14
+	WatchEvent_PEER_TAGGED            WatchEvent_Type = 15
+	WatchEvent_PEER_ROUTES_ADVERTISED WatchEvent_Type = 16
+)
+
+var WatchEvent_Type_name = map[int32]string{
+	0:  "UNKNOWN",
+	1:  "SYNCED",
+	2:  "NODE_JOINED",
+	3:  "NODE_LEFT",
+	4:  "PEER_ADDED",
+	5:  "PEER_UPDATED",
+	6:  "PEER_REMOVED",
+	7:  "ROUTE_CREATED",
+	8:  "ROUTE_UPDATED",
+	9:  "ROUTE_DELETED",
+	10: "PEER_AUTHORIZED",
+	11: "PEER_DEAUTHORIZED",
+	12: "POLICY_CREATED",
+	13: "POLICY_UPDATED",
+	14: "POLICY_DELETED",
+	15: "PEER_TAGGED",
+	16: "PEER_ROUTES_ADVERTISED",
+}
+
+var WatchEvent_Type_value = map[string]int32{
+	"UNKNOWN":                0,
+	"SYNCED":                 1,
+	"NODE_JOINED":            2,
+	"NODE_LEFT":              3,
+	"PEER_ADDED":             4,
+	"PEER_UPDATED":           5,
+	"PEER_REMOVED":           6,
+	"ROUTE_CREATED":          7,
+	"ROUTE_UPDATED":          8,
+	"ROUTE_DELETED":          9,
+	"PEER_AUTHORIZED":        10,
+	"PEER_DEAUTHORIZED":      11,
+	"POLICY_CREATED":         12,
+	"POLICY_UPDATED":         13,
+	"POLICY_DELETED":         14,
+	"PEER_TAGGED":            15,
+	"PEER_ROUTES_ADVERTISED": 16,
+}
+
+func (x WatchEvent_Type) String() string {
+	return proto.EnumName(WatchEvent_Type_name, int32(x))
+}
+
+func (WatchEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{51, 0}
+}
+
 type Master struct {
-	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	GRPCAddress          string   `protobuf:"bytes,2,opt,name=grpc_address,json=grpcAddress,proto3" json:"grpc_address,omitempty"`
-	RedisURL             string   `protobuf:"bytes,3,opt,name=redis_url,json=redisUrl,proto3" json:"redis_url,omitempty"`
-	GatewayIP            string   `protobuf:"bytes,4,opt,name=gateway_ip,json=gatewayIp,proto3" json:"gateway_ip,omitempty"`
-	Peers                []*Peer  `protobuf:"bytes,5,rep,name=peers,proto3" json:"peers,omitempty"`
+	ID          string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GRPCAddress string  `protobuf:"bytes,2,opt,name=grpc_address,json=grpcAddress,proto3" json:"grpc_address,omitempty"`
+	RedisURL    string  `protobuf:"bytes,3,opt,name=redis_url,json=redisUrl,proto3" json:"redis_url,omitempty"`
+	GatewayIP   string  `protobuf:"bytes,4,opt,name=gateway_ip,json=gatewayIp,proto3" json:"gateway_ip,omitempty"`
+	Peers       []*Peer `protobuf:"bytes,5,rep,name=peers,proto3" json:"peers,omitempty"`
+	Term        uint64  `protobuf:"varint,6,opt,name=term,proto3" json:"term,omitempty"`
+	// revision is the store revision when the master was promoted
+	Revision             uint64   `protobuf:"varint,7,opt,name=revision,proto3" json:"revision,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -110,6 +209,20 @@ func (m *Master) GetPeers() []*Peer {
 	return nil
 }
 
+func (m *Master) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *Master) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
 type JoinRequest struct {
 	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	ClusterKey           string   `protobuf:"bytes,2,opt,name=cluster_key,json=clusterKey,proto3" json:"cluster_key,omitempty"`
@@ -118,6 +231,9 @@ type JoinRequest struct {
 	EndpointPort         uint64   `protobuf:"varint,5,opt,name=endpoint_port,json=endpointPort,proto3" json:"endpoint_port,omitempty"`
 	InterfaceName        string   `protobuf:"bytes,6,opt,name=interface_name,json=interfaceName,proto3" json:"interface_name,omitempty"`
 	Name                 string   `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
+	PublicKey            string   `protobuf:"bytes,8,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	NodeSubnetSize       uint32   `protobuf:"varint,9,opt,name=node_subnet_size,json=nodeSubnetSize,proto3" json:"node_subnet_size,omitempty"`
+	NodeSubnetSizeV6     uint32   `protobuf:"varint,10,opt,name=node_subnet_size_v6,json=nodeSubnetSizeV6,proto3" json:"node_subnet_size_v6,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -205,6 +321,27 @@ func (m *JoinRequest) GetName() string {
 	return ""
 }
 
+func (m *JoinRequest) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *JoinRequest) GetNodeSubnetSize() uint32 {
+	if m != nil {
+		return m.NodeSubnetSize
+	}
+	return 0
+}
+
+func (m *JoinRequest) GetNodeSubnetSizeV6() uint32 {
+	if m != nil {
+		return m.NodeSubnetSizeV6
+	}
+	return 0
+}
+
 type JoinResponse struct {
 	Master               *Master  `protobuf:"bytes,1,opt,name=master,proto3" json:"master,omitempty"`
 	Node                 *Node    `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
@@ -269,8 +406,19 @@ func (m *JoinResponse) GetPeers() []*Peer {
 }
 
 type ConnectRequest struct {
-	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ID        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PublicKey string `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// exit_node is the name or id of the node to route internet traffic through
+	ExitNode string `protobuf:"bytes,4,opt,name=exit_node,json=exitNode,proto3" json:"exit_node,omitempty"`
+	// advertised_routes are the networks the peer routes for the cluster
+	AdvertisedRoutes []string `protobuf:"bytes,5,rep,name=advertised_routes,json=advertisedRoutes,proto3" json:"advertised_routes,omitempty"`
+	// mesh requests direct tunnels to the other mesh peers
+	Mesh bool `protobuf:"varint,6,opt,name=mesh,proto3" json:"mesh,omitempty"`
+	// listen_port is the wireguard port of the peer for direct tunnels
+	ListenPort uint64 `protobuf:"varint,7,opt,name=listen_port,json=listenPort,proto3" json:"listen_port,omitempty"`
+	// local_addresses are the addresses of the peer on its local networks
+	LocalAddresses       []string `protobuf:"bytes,8,rep,name=local_addresses,json=localAddresses,proto3" json:"local_addresses,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -323,8 +471,49 @@ func (m *ConnectRequest) GetName() string {
 	return ""
 }
 
+func (m *ConnectRequest) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *ConnectRequest) GetExitNode() string {
+	if m != nil {
+		return m.ExitNode
+	}
+	return ""
+}
+
+func (m *ConnectRequest) GetAdvertisedRoutes() []string {
+	if m != nil {
+		return m.AdvertisedRoutes
+	}
+	return nil
+}
+
+func (m *ConnectRequest) GetMesh() bool {
+	if m != nil {
+		return m.Mesh
+	}
+	return false
+}
+
+func (m *ConnectRequest) GetListenPort() uint64 {
+	if m != nil {
+		return m.ListenPort
+	}
+	return 0
+}
+
+func (m *ConnectRequest) GetLocalAddresses() []string {
+	if m != nil {
+		return m.LocalAddresses
+	}
+	return nil
+}
+
 type ConnectResponse struct {
-	KeyPair              *KeyPair `protobuf:"bytes,1,opt,name=keypair,proto3" json:"keypair,omitempty"`
 	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
 	Peers                []*Peer  `protobuf:"bytes,3,rep,name=peers,proto3" json:"peers,omitempty"`
 	DNS                  []string `protobuf:"bytes,4,rep,name=dns,proto3" json:"dns,omitempty"`
@@ -366,13 +555,6 @@ func (m *ConnectResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ConnectResponse proto.InternalMessageInfo
 
-func (m *ConnectResponse) GetKeyPair() *KeyPair {
-	if m != nil {
-		return m.KeyPair
-	}
-	return nil
-}
-
 func (m *ConnectResponse) GetAddress() string {
 	if m != nil {
 		return m.Address
@@ -395,7 +577,10 @@ func (m *ConnectResponse) GetDNS() []string {
 }
 
 type AuthorizePeerRequest struct {
-	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ID  string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IPs []string `protobuf:"bytes,2,rep,name=ips,proto3" json:"ips,omitempty"`
+	// tags replace the tags of the peer if specified
+	Tags                 []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -441,6 +626,20 @@ func (m *AuthorizePeerRequest) GetID() string {
 	return ""
 }
 
+func (m *AuthorizePeerRequest) GetIPs() []string {
+	if m != nil {
+		return m.IPs
+	}
+	return nil
+}
+
+func (m *AuthorizePeerRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
 type DeauthorizePeerRequest struct {
 	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -574,26 +773,68 @@ func (m *AuthorizedPeersResponse) GetIDs() []string {
 	return nil
 }
 
-type KeyPair struct {
-	PrivateKey           string   `protobuf:"bytes,1,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
-	PublicKey            string   `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+type PeerNetworkUsageRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KeyPair) Reset()         { *m = KeyPair{} }
-func (m *KeyPair) String() string { return proto.CompactTextString(m) }
-func (*KeyPair) ProtoMessage()    {}
-func (*KeyPair) Descriptor() ([]byte, []int) {
+func (m *PeerNetworkUsageRequest) Reset()         { *m = PeerNetworkUsageRequest{} }
+func (m *PeerNetworkUsageRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerNetworkUsageRequest) ProtoMessage()    {}
+func (*PeerNetworkUsageRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_601158708112ddb8, []int{9}
 }
-func (m *KeyPair) XXX_Unmarshal(b []byte) error {
+func (m *PeerNetworkUsageRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerNetworkUsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerNetworkUsageRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PeerNetworkUsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerNetworkUsageRequest.Merge(m, src)
+}
+func (m *PeerNetworkUsageRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerNetworkUsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerNetworkUsageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerNetworkUsageRequest proto.InternalMessageInfo
+
+type NetworkUsage struct {
+	Network              string   `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Total                uint64   `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Allocated            uint64   `protobuf:"varint,3,opt,name=allocated,proto3" json:"allocated,omitempty"`
+	Excluded             uint64   `protobuf:"varint,4,opt,name=excluded,proto3" json:"excluded,omitempty"`
+	Available            uint64   `protobuf:"varint,5,opt,name=available,proto3" json:"available,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NetworkUsage) Reset()         { *m = NetworkUsage{} }
+func (m *NetworkUsage) String() string { return proto.CompactTextString(m) }
+func (*NetworkUsage) ProtoMessage()    {}
+func (*NetworkUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{10}
+}
+func (m *NetworkUsage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KeyPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *NetworkUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KeyPair.Marshal(b, m, deterministic)
+		return xxx_messageInfo_NetworkUsage.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -603,52 +844,127 @@ func (m *KeyPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *KeyPair) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KeyPair.Merge(m, src)
+func (m *NetworkUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkUsage.Merge(m, src)
 }
-func (m *KeyPair) XXX_Size() int {
+func (m *NetworkUsage) XXX_Size() int {
 	return m.Size()
 }
-func (m *KeyPair) XXX_DiscardUnknown() {
-	xxx_messageInfo_KeyPair.DiscardUnknown(m)
+func (m *NetworkUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkUsage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KeyPair proto.InternalMessageInfo
+var xxx_messageInfo_NetworkUsage proto.InternalMessageInfo
 
-func (m *KeyPair) GetPrivateKey() string {
+func (m *NetworkUsage) GetNetwork() string {
 	if m != nil {
-		return m.PrivateKey
+		return m.Network
 	}
 	return ""
 }
 
-func (m *KeyPair) GetPublicKey() string {
+func (m *NetworkUsage) GetTotal() uint64 {
 	if m != nil {
-		return m.PublicKey
+		return m.Total
 	}
-	return ""
+	return 0
+}
+
+func (m *NetworkUsage) GetAllocated() uint64 {
+	if m != nil {
+		return m.Allocated
+	}
+	return 0
+}
+
+func (m *NetworkUsage) GetExcluded() uint64 {
+	if m != nil {
+		return m.Excluded
+	}
+	return 0
+}
+
+func (m *NetworkUsage) GetAvailable() uint64 {
+	if m != nil {
+		return m.Available
+	}
+	return 0
+}
+
+type PeerNetworkUsageResponse struct {
+	Networks             []*NetworkUsage `protobuf:"bytes,1,rep,name=networks,proto3" json:"networks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *PeerNetworkUsageResponse) Reset()         { *m = PeerNetworkUsageResponse{} }
+func (m *PeerNetworkUsageResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerNetworkUsageResponse) ProtoMessage()    {}
+func (*PeerNetworkUsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{11}
+}
+func (m *PeerNetworkUsageResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerNetworkUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerNetworkUsageResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PeerNetworkUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerNetworkUsageResponse.Merge(m, src)
+}
+func (m *PeerNetworkUsageResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerNetworkUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerNetworkUsageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerNetworkUsageResponse proto.InternalMessageInfo
+
+func (m *PeerNetworkUsageResponse) GetNetworks() []*NetworkUsage {
+	if m != nil {
+		return m.Networks
+	}
+	return nil
 }
 
 type Node struct {
-	ID                   string    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Addr                 string    `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	KeyPair              *KeyPair  `protobuf:"bytes,3,opt,name=keypair,proto3" json:"keypair,omitempty"`
-	EndpointIP           string    `protobuf:"bytes,4,opt,name=endpoint_ip,json=endpointIp,proto3" json:"endpoint_ip,omitempty"`
-	EndpointPort         uint64    `protobuf:"varint,5,opt,name=endpoint_port,json=endpointPort,proto3" json:"endpoint_port,omitempty"`
-	GatewayIP            string    `protobuf:"bytes,6,opt,name=gateway_ip,json=gatewayIp,proto3" json:"gateway_ip,omitempty"`
-	Updated              time.Time `protobuf:"bytes,7,opt,name=updated,proto3,stdtime" json:"updated"`
-	InterfaceName        string    `protobuf:"bytes,8,opt,name=interface_name,json=interfaceName,proto3" json:"interface_name,omitempty"`
-	Name                 string    `protobuf:"bytes,9,opt,name=name,proto3" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	ID            string    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Addr          string    `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	EndpointIP    string    `protobuf:"bytes,4,opt,name=endpoint_ip,json=endpointIp,proto3" json:"endpoint_ip,omitempty"`
+	EndpointPort  uint64    `protobuf:"varint,5,opt,name=endpoint_port,json=endpointPort,proto3" json:"endpoint_port,omitempty"`
+	GatewayIP     string    `protobuf:"bytes,6,opt,name=gateway_ip,json=gatewayIp,proto3" json:"gateway_ip,omitempty"`
+	Updated       time.Time `protobuf:"bytes,7,opt,name=updated,proto3,stdtime" json:"updated"`
+	InterfaceName string    `protobuf:"bytes,8,opt,name=interface_name,json=interfaceName,proto3" json:"interface_name,omitempty"`
+	Name          string    `protobuf:"bytes,9,opt,name=name,proto3" json:"name,omitempty"`
+	PublicKey     string    `protobuf:"bytes,10,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Draining      bool      `protobuf:"varint,11,opt,name=draining,proto3" json:"draining,omitempty"`
+	Healthy       bool      `protobuf:"varint,12,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	HealthReason  string    `protobuf:"bytes,13,opt,name=health_reason,json=healthReason,proto3" json:"health_reason,omitempty"`
+	LastHandshake time.Time `protobuf:"bytes,14,opt,name=last_handshake,json=lastHandshake,proto3,stdtime" json:"last_handshake"`
+	GatewayIPV6   string    `protobuf:"bytes,15,opt,name=gateway_ip_v6,json=gatewayIpV6,proto3" json:"gateway_ip_v6,omitempty"`
+	// exit_node is set if peers can route their internet traffic through the node
+	ExitNode             bool     `protobuf:"varint,16,opt,name=exit_node,json=exitNode,proto3" json:"exit_node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Node) Reset()         { *m = Node{} }
 func (m *Node) String() string { return proto.CompactTextString(m) }
 func (*Node) ProtoMessage()    {}
 func (*Node) Descriptor() ([]byte, []int) {
-	return fileDescriptor_601158708112ddb8, []int{10}
+	return fileDescriptor_601158708112ddb8, []int{12}
 }
 func (m *Node) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -691,13 +1007,6 @@ func (m *Node) GetAddr() string {
 	return ""
 }
 
-func (m *Node) GetKeyPair() *KeyPair {
-	if m != nil {
-		return m.KeyPair
-	}
-	return nil
-}
-
 func (m *Node) GetEndpointIP() string {
 	if m != nil {
 		return m.EndpointIP
@@ -740,6 +1049,55 @@ func (m *Node) GetName() string {
 	return ""
 }
 
+func (m *Node) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
+}
+
+func (m *Node) GetDraining() bool {
+	if m != nil {
+		return m.Draining
+	}
+	return false
+}
+
+func (m *Node) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *Node) GetHealthReason() string {
+	if m != nil {
+		return m.HealthReason
+	}
+	return ""
+}
+
+func (m *Node) GetLastHandshake() time.Time {
+	if m != nil {
+		return m.LastHandshake
+	}
+	return time.Time{}
+}
+
+func (m *Node) GetGatewayIPV6() string {
+	if m != nil {
+		return m.GatewayIPV6
+	}
+	return ""
+}
+
+func (m *Node) GetExitNode() bool {
+	if m != nil {
+		return m.ExitNode
+	}
+	return false
+}
+
 type NodesRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -750,7 +1108,7 @@ func (m *NodesRequest) Reset()         { *m = NodesRequest{} }
 func (m *NodesRequest) String() string { return proto.CompactTextString(m) }
 func (*NodesRequest) ProtoMessage()    {}
 func (*NodesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_601158708112ddb8, []int{11}
+	return fileDescriptor_601158708112ddb8, []int{13}
 }
 func (m *NodesRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -781,6 +1139,7 @@ var xxx_messageInfo_NodesRequest proto.InternalMessageInfo
 
 type NodesResponse struct {
 	Nodes                []*Node  `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Master               *Master  `protobuf:"bytes,2,opt,name=master,proto3" json:"master,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -790,7 +1149,7 @@ func (m *NodesResponse) Reset()         { *m = NodesResponse{} }
 func (m *NodesResponse) String() string { return proto.CompactTextString(m) }
 func (*NodesResponse) ProtoMessage()    {}
 func (*NodesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_601158708112ddb8, []int{12}
+	return fileDescriptor_601158708112ddb8, []int{14}
 }
 func (m *NodesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -826,13 +1185,37 @@ func (m *NodesResponse) GetNodes() []*Node {
 	return nil
 }
 
+func (m *NodesResponse) GetMaster() *Master {
+	if m != nil {
+		return m.Master
+	}
+	return nil
+}
+
 type Peer struct {
-	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	KeyPair              *KeyPair `protobuf:"bytes,2,opt,name=keypair,proto3" json:"keypair,omitempty"`
-	AllowedIPs           []string `protobuf:"bytes,3,rep,name=allowed_ips,json=allowedIps,proto3" json:"allowed_ips,omitempty"`
-	Endpoint             string   `protobuf:"bytes,4,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
-	PeerIP               string   `protobuf:"bytes,5,opt,name=peer_ip,json=peerIp,proto3" json:"peer_ip,omitempty"`
-	Name                 string   `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	ID         string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AllowedIPs []string `protobuf:"bytes,3,rep,name=allowed_ips,json=allowedIps,proto3" json:"allowed_ips,omitempty"`
+	Endpoint   string   `protobuf:"bytes,4,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	PeerIP     string   `protobuf:"bytes,5,opt,name=peer_ip,json=peerIp,proto3" json:"peer_ip,omitempty"`
+	Name       string   `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	PublicKey  string   `protobuf:"bytes,7,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	PeerIPV6   string   `protobuf:"bytes,8,opt,name=peer_ip_v6,json=peerIpV6,proto3" json:"peer_ip_v6,omitempty"`
+	Tags       []string `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	// exit_node is the id of the node the peer routes internet traffic through
+	ExitNode string `protobuf:"bytes,10,opt,name=exit_node,json=exitNode,proto3" json:"exit_node,omitempty"`
+	// reflexive_endpoint is the public wireguard endpoint of the peer as
+	// observed by the nodes
+	ReflexiveEndpoint string `protobuf:"bytes,11,opt,name=reflexive_endpoint,json=reflexiveEndpoint,proto3" json:"reflexive_endpoint,omitempty"`
+	// remote_address is the source host of the grpc connection of the peer
+	RemoteAddress string `protobuf:"bytes,12,opt,name=remote_address,json=remoteAddress,proto3" json:"remote_address,omitempty"`
+	// direct is set for peers that are reached over a direct tunnel
+	Direct bool `protobuf:"varint,13,opt,name=direct,proto3" json:"direct,omitempty"`
+	// mesh is set for peers that have direct tunnels to all mesh peers
+	Mesh       bool   `protobuf:"varint,14,opt,name=mesh,proto3" json:"mesh,omitempty"`
+	ListenPort uint64 `protobuf:"varint,15,opt,name=listen_port,json=listenPort,proto3" json:"listen_port,omitempty"`
+	// local_addresses are the addresses with prefix of the peer on its
+	// local networks
+	LocalAddresses       []string `protobuf:"bytes,16,rep,name=local_addresses,json=localAddresses,proto3" json:"local_addresses,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -842,7 +1225,7 @@ func (m *Peer) Reset()         { *m = Peer{} }
 func (m *Peer) String() string { return proto.CompactTextString(m) }
 func (*Peer) ProtoMessage()    {}
 func (*Peer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_601158708112ddb8, []int{13}
+	return fileDescriptor_601158708112ddb8, []int{15}
 }
 func (m *Peer) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -878,13 +1261,6 @@ func (m *Peer) GetID() string {
 	return ""
 }
 
-func (m *Peer) GetKeyPair() *KeyPair {
-	if m != nil {
-		return m.KeyPair
-	}
-	return nil
-}
-
 func (m *Peer) GetAllowedIPs() []string {
 	if m != nil {
 		return m.AllowedIPs
@@ -913,64 +1289,96 @@ func (m *Peer) GetName() string {
 	return ""
 }
 
-type PeersRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Peer) GetPublicKey() string {
+	if m != nil {
+		return m.PublicKey
+	}
+	return ""
 }
 
-func (m *PeersRequest) Reset()         { *m = PeersRequest{} }
-func (m *PeersRequest) String() string { return proto.CompactTextString(m) }
-func (*PeersRequest) ProtoMessage()    {}
-func (*PeersRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_601158708112ddb8, []int{14}
-}
-func (m *PeersRequest) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *PeersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_PeersRequest.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *Peer) GetPeerIPV6() string {
+	if m != nil {
+		return m.PeerIPV6
 	}
+	return ""
 }
-func (m *PeersRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PeersRequest.Merge(m, src)
+
+func (m *Peer) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
 }
-func (m *PeersRequest) XXX_Size() int {
-	return m.Size()
+
+func (m *Peer) GetExitNode() string {
+	if m != nil {
+		return m.ExitNode
+	}
+	return ""
 }
-func (m *PeersRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_PeersRequest.DiscardUnknown(m)
+
+func (m *Peer) GetReflexiveEndpoint() string {
+	if m != nil {
+		return m.ReflexiveEndpoint
+	}
+	return ""
 }
 
-var xxx_messageInfo_PeersRequest proto.InternalMessageInfo
+func (m *Peer) GetRemoteAddress() string {
+	if m != nil {
+		return m.RemoteAddress
+	}
+	return ""
+}
 
-type PeersResponse struct {
-	Peers                []*Peer  `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+func (m *Peer) GetDirect() bool {
+	if m != nil {
+		return m.Direct
+	}
+	return false
+}
+
+func (m *Peer) GetMesh() bool {
+	if m != nil {
+		return m.Mesh
+	}
+	return false
+}
+
+func (m *Peer) GetListenPort() uint64 {
+	if m != nil {
+		return m.ListenPort
+	}
+	return 0
+}
+
+func (m *Peer) GetLocalAddresses() []string {
+	if m != nil {
+		return m.LocalAddresses
+	}
+	return nil
+}
+
+type PeerTags struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tags                 []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PeersResponse) Reset()         { *m = PeersResponse{} }
-func (m *PeersResponse) String() string { return proto.CompactTextString(m) }
-func (*PeersResponse) ProtoMessage()    {}
-func (*PeersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_601158708112ddb8, []int{15}
+func (m *PeerTags) Reset()         { *m = PeerTags{} }
+func (m *PeerTags) String() string { return proto.CompactTextString(m) }
+func (*PeerTags) ProtoMessage()    {}
+func (*PeerTags) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{16}
 }
-func (m *PeersResponse) XXX_Unmarshal(b []byte) error {
+func (m *PeerTags) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PeersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PeerTags) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PeersResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PeerTags.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -980,45 +1388,52 @@ func (m *PeersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *PeersResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PeersResponse.Merge(m, src)
+func (m *PeerTags) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerTags.Merge(m, src)
 }
-func (m *PeersResponse) XXX_Size() int {
+func (m *PeerTags) XXX_Size() int {
 	return m.Size()
 }
-func (m *PeersResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_PeersResponse.DiscardUnknown(m)
+func (m *PeerTags) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerTags.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PeersResponse proto.InternalMessageInfo
+var xxx_messageInfo_PeerTags proto.InternalMessageInfo
 
-func (m *PeersResponse) GetPeers() []*Peer {
+func (m *PeerTags) GetID() string {
 	if m != nil {
-		return m.Peers
+		return m.ID
+	}
+	return ""
+}
+
+func (m *PeerTags) GetTags() []string {
+	if m != nil {
+		return m.Tags
 	}
 	return nil
 }
 
-type Route struct {
-	NodeID               string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	Network              string   `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+type PeersRequest struct {
+	// tag only returns the peers with the tag
+	Tag                  string   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Route) Reset()         { *m = Route{} }
-func (m *Route) String() string { return proto.CompactTextString(m) }
-func (*Route) ProtoMessage()    {}
-func (*Route) Descriptor() ([]byte, []int) {
-	return fileDescriptor_601158708112ddb8, []int{16}
+func (m *PeersRequest) Reset()         { *m = PeersRequest{} }
+func (m *PeersRequest) String() string { return proto.CompactTextString(m) }
+func (*PeersRequest) ProtoMessage()    {}
+func (*PeersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{17}
 }
-func (m *Route) XXX_Unmarshal(b []byte) error {
+func (m *PeersRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Route) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PeersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Route.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PeersRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1028,52 +1443,44 @@ func (m *Route) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Route) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Route.Merge(m, src)
+func (m *PeersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeersRequest.Merge(m, src)
 }
-func (m *Route) XXX_Size() int {
+func (m *PeersRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *Route) XXX_DiscardUnknown() {
-	xxx_messageInfo_Route.DiscardUnknown(m)
+func (m *PeersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeersRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Route proto.InternalMessageInfo
-
-func (m *Route) GetNodeID() string {
-	if m != nil {
-		return m.NodeID
-	}
-	return ""
-}
+var xxx_messageInfo_PeersRequest proto.InternalMessageInfo
 
-func (m *Route) GetNetwork() string {
+func (m *PeersRequest) GetTag() string {
 	if m != nil {
-		return m.Network
+		return m.Tag
 	}
 	return ""
 }
 
-type CreateRouteRequest struct {
-	NodeID               string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	Network              string   `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+type PeersResponse struct {
+	Peers                []*Peer  `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateRouteRequest) Reset()         { *m = CreateRouteRequest{} }
-func (m *CreateRouteRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateRouteRequest) ProtoMessage()    {}
-func (*CreateRouteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_601158708112ddb8, []int{17}
+func (m *PeersResponse) Reset()         { *m = PeersResponse{} }
+func (m *PeersResponse) String() string { return proto.CompactTextString(m) }
+func (*PeersResponse) ProtoMessage()    {}
+func (*PeersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{18}
 }
-func (m *CreateRouteRequest) XXX_Unmarshal(b []byte) error {
+func (m *PeersResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CreateRouteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PeersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CreateRouteRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PeersResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1083,51 +1490,84 @@ func (m *CreateRouteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *CreateRouteRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateRouteRequest.Merge(m, src)
+func (m *PeersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeersResponse.Merge(m, src)
 }
-func (m *CreateRouteRequest) XXX_Size() int {
+func (m *PeersResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *CreateRouteRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateRouteRequest.DiscardUnknown(m)
+func (m *PeersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeersResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateRouteRequest proto.InternalMessageInfo
+var xxx_messageInfo_PeersResponse proto.InternalMessageInfo
 
-func (m *CreateRouteRequest) GetNodeID() string {
+func (m *PeersResponse) GetPeers() []*Peer {
 	if m != nil {
-		return m.NodeID
+		return m.Peers
 	}
-	return ""
+	return nil
 }
 
-func (m *CreateRouteRequest) GetNetwork() string {
-	if m != nil {
-		return m.Network
+type CheckPeerIPsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckPeerIPsRequest) Reset()         { *m = CheckPeerIPsRequest{} }
+func (m *CheckPeerIPsRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckPeerIPsRequest) ProtoMessage()    {}
+func (*CheckPeerIPsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{19}
+}
+func (m *CheckPeerIPsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CheckPeerIPsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CheckPeerIPsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return ""
+}
+func (m *CheckPeerIPsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckPeerIPsRequest.Merge(m, src)
+}
+func (m *CheckPeerIPsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CheckPeerIPsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckPeerIPsRequest.DiscardUnknown(m)
 }
 
-type DeleteRouteRequest struct {
-	Network              string   `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+var xxx_messageInfo_CheckPeerIPsRequest proto.InternalMessageInfo
+
+type PeerIPConflict struct {
+	IP                   string   `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	PeerIDs              []string `protobuf:"bytes,2,rep,name=peer_ids,json=peerIds,proto3" json:"peer_ids,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteRouteRequest) Reset()         { *m = DeleteRouteRequest{} }
-func (m *DeleteRouteRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteRouteRequest) ProtoMessage()    {}
-func (*DeleteRouteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_601158708112ddb8, []int{18}
+func (m *PeerIPConflict) Reset()         { *m = PeerIPConflict{} }
+func (m *PeerIPConflict) String() string { return proto.CompactTextString(m) }
+func (*PeerIPConflict) ProtoMessage()    {}
+func (*PeerIPConflict) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{20}
 }
-func (m *DeleteRouteRequest) XXX_Unmarshal(b []byte) error {
+func (m *PeerIPConflict) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteRouteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PeerIPConflict) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteRouteRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PeerIPConflict.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1137,43 +1577,51 @@ func (m *DeleteRouteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *DeleteRouteRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteRouteRequest.Merge(m, src)
+func (m *PeerIPConflict) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerIPConflict.Merge(m, src)
 }
-func (m *DeleteRouteRequest) XXX_Size() int {
+func (m *PeerIPConflict) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteRouteRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteRouteRequest.DiscardUnknown(m)
+func (m *PeerIPConflict) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerIPConflict.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteRouteRequest proto.InternalMessageInfo
+var xxx_messageInfo_PeerIPConflict proto.InternalMessageInfo
 
-func (m *DeleteRouteRequest) GetNetwork() string {
+func (m *PeerIPConflict) GetIP() string {
 	if m != nil {
-		return m.Network
+		return m.IP
 	}
 	return ""
 }
 
-type RoutesRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *PeerIPConflict) GetPeerIDs() []string {
+	if m != nil {
+		return m.PeerIDs
+	}
+	return nil
 }
 
-func (m *RoutesRequest) Reset()         { *m = RoutesRequest{} }
-func (m *RoutesRequest) String() string { return proto.CompactTextString(m) }
-func (*RoutesRequest) ProtoMessage()    {}
-func (*RoutesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_601158708112ddb8, []int{19}
+type CheckPeerIPsResponse struct {
+	Conflicts            []*PeerIPConflict `protobuf:"bytes,1,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
-func (m *RoutesRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *CheckPeerIPsResponse) Reset()         { *m = CheckPeerIPsResponse{} }
+func (m *CheckPeerIPsResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckPeerIPsResponse) ProtoMessage()    {}
+func (*CheckPeerIPsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{21}
+}
+func (m *CheckPeerIPsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *RoutesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *CheckPeerIPsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_RoutesRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_CheckPeerIPsResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1183,37 +1631,58 @@ func (m *RoutesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *RoutesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RoutesRequest.Merge(m, src)
+func (m *CheckPeerIPsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckPeerIPsResponse.Merge(m, src)
 }
-func (m *RoutesRequest) XXX_Size() int {
+func (m *CheckPeerIPsResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *RoutesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_RoutesRequest.DiscardUnknown(m)
+func (m *CheckPeerIPsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckPeerIPsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RoutesRequest proto.InternalMessageInfo
+var xxx_messageInfo_CheckPeerIPsResponse proto.InternalMessageInfo
 
-type RoutesResponse struct {
-	Routes               []*Route `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+func (m *CheckPeerIPsResponse) GetConflicts() []*PeerIPConflict {
+	if m != nil {
+		return m.Conflicts
+	}
+	return nil
+}
+
+type Route struct {
+	// node_id is the preferred node of the route
+	NodeID  string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Network string `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	// tags limit the route to peers with any of the tags
+	Tags []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// nodes are the candidate nodes that can advertise the route
+	Nodes []*RouteNode `protobuf:"bytes,4,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// active_node_id is the live candidate currently advertising the route
+	ActiveNodeID string `protobuf:"bytes,5,opt,name=active_node_id,json=activeNodeId,proto3" json:"active_node_id,omitempty"`
+	// stale is set when no candidate is live and the route is withdrawn
+	Stale bool `protobuf:"varint,6,opt,name=stale,proto3" json:"stale,omitempty"`
+	// peer_id is the peer advertising the route as a subnet router
+	PeerID string `protobuf:"bytes,7,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	// pending is set for advertised peer routes that are not approved
+	Pending              bool     `protobuf:"varint,8,opt,name=pending,proto3" json:"pending,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RoutesResponse) Reset()         { *m = RoutesResponse{} }
-func (m *RoutesResponse) String() string { return proto.CompactTextString(m) }
-func (*RoutesResponse) ProtoMessage()    {}
-func (*RoutesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_601158708112ddb8, []int{20}
+func (m *Route) Reset()         { *m = Route{} }
+func (m *Route) String() string { return proto.CompactTextString(m) }
+func (*Route) ProtoMessage()    {}
+func (*Route) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{22}
 }
-func (m *RoutesResponse) XXX_Unmarshal(b []byte) error {
+func (m *Route) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *RoutesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Route) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_RoutesResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Route.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1223,1992 +1692,12520 @@ func (m *RoutesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *RoutesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RoutesResponse.Merge(m, src)
+func (m *Route) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Route.Merge(m, src)
 }
-func (m *RoutesResponse) XXX_Size() int {
+func (m *Route) XXX_Size() int {
 	return m.Size()
 }
-func (m *RoutesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_RoutesResponse.DiscardUnknown(m)
+func (m *Route) XXX_DiscardUnknown() {
+	xxx_messageInfo_Route.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RoutesResponse proto.InternalMessageInfo
+var xxx_messageInfo_Route proto.InternalMessageInfo
 
-func (m *RoutesResponse) GetRoutes() []*Route {
+func (m *Route) GetNodeID() string {
 	if m != nil {
-		return m.Routes
+		return m.NodeID
 	}
-	return nil
+	return ""
 }
 
-func init() {
-	proto.RegisterType((*Master)(nil), "dev.ehazlett.heimdall.api.v1.Master")
-	proto.RegisterType((*JoinRequest)(nil), "dev.ehazlett.heimdall.api.v1.JoinRequest")
-	proto.RegisterType((*JoinResponse)(nil), "dev.ehazlett.heimdall.api.v1.JoinResponse")
-	proto.RegisterType((*ConnectRequest)(nil), "dev.ehazlett.heimdall.api.v1.ConnectRequest")
-	proto.RegisterType((*ConnectResponse)(nil), "dev.ehazlett.heimdall.api.v1.ConnectResponse")
-	proto.RegisterType((*AuthorizePeerRequest)(nil), "dev.ehazlett.heimdall.api.v1.AuthorizePeerRequest")
-	proto.RegisterType((*DeauthorizePeerRequest)(nil), "dev.ehazlett.heimdall.api.v1.DeauthorizePeerRequest")
-	proto.RegisterType((*AuthorizedPeersRequest)(nil), "dev.ehazlett.heimdall.api.v1.AuthorizedPeersRequest")
-	proto.RegisterType((*AuthorizedPeersResponse)(nil), "dev.ehazlett.heimdall.api.v1.AuthorizedPeersResponse")
-	proto.RegisterType((*KeyPair)(nil), "dev.ehazlett.heimdall.api.v1.KeyPair")
-	proto.RegisterType((*Node)(nil), "dev.ehazlett.heimdall.api.v1.Node")
-	proto.RegisterType((*NodesRequest)(nil), "dev.ehazlett.heimdall.api.v1.NodesRequest")
-	proto.RegisterType((*NodesResponse)(nil), "dev.ehazlett.heimdall.api.v1.NodesResponse")
-	proto.RegisterType((*Peer)(nil), "dev.ehazlett.heimdall.api.v1.Peer")
-	proto.RegisterType((*PeersRequest)(nil), "dev.ehazlett.heimdall.api.v1.PeersRequest")
-	proto.RegisterType((*PeersResponse)(nil), "dev.ehazlett.heimdall.api.v1.PeersResponse")
-	proto.RegisterType((*Route)(nil), "dev.ehazlett.heimdall.api.v1.Route")
-	proto.RegisterType((*CreateRouteRequest)(nil), "dev.ehazlett.heimdall.api.v1.CreateRouteRequest")
-	proto.RegisterType((*DeleteRouteRequest)(nil), "dev.ehazlett.heimdall.api.v1.DeleteRouteRequest")
-	proto.RegisterType((*RoutesRequest)(nil), "dev.ehazlett.heimdall.api.v1.RoutesRequest")
-	proto.RegisterType((*RoutesResponse)(nil), "dev.ehazlett.heimdall.api.v1.RoutesResponse")
+func (m *Route) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
 }
 
-func init() {
-	proto.RegisterFile("github.com/ehazlett/heimdall/api/v1/heimdall.proto", fileDescriptor_601158708112ddb8)
+func (m *Route) GetTags() []string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
 }
 
-var fileDescriptor_601158708112ddb8 = []byte{
-	// 1133 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x5d, 0x6f, 0xdb, 0x54,
-	0x18, 0xc6, 0xf9, 0x70, 0x92, 0xd7, 0x4d, 0x2b, 0x1d, 0x4d, 0xc5, 0x0b, 0x50, 0x57, 0x2e, 0x43,
-	0x5d, 0x57, 0x9c, 0x35, 0x14, 0x34, 0x69, 0x13, 0x52, 0xdb, 0x8c, 0x61, 0xba, 0x55, 0xd1, 0x19,
-	0xd3, 0x24, 0x10, 0xca, 0xdc, 0xf8, 0x34, 0xb5, 0xea, 0xd8, 0xc6, 0x3e, 0x69, 0x95, 0x5d, 0xf0,
-	0x07, 0xb8, 0xe1, 0x17, 0x71, 0xbd, 0x2b, 0xc4, 0x2f, 0x08, 0xc8, 0x37, 0x48, 0x5c, 0xf2, 0x07,
-	0x40, 0xe7, 0xf8, 0xd8, 0x4d, 0xfa, 0xe1, 0xa4, 0x0c, 0xee, 0x72, 0xde, 0xf3, 0xbc, 0xe7, 0xe3,
-	0x79, 0xdf, 0xe7, 0x39, 0x0e, 0xb4, 0xfa, 0x0e, 0x3d, 0x1e, 0x1e, 0x1a, 0x3d, 0x7f, 0xd0, 0x24,
-	0xc7, 0xd6, 0x6b, 0x97, 0x50, 0xda, 0x3c, 0x26, 0xce, 0xc0, 0xb6, 0x5c, 0xb7, 0x69, 0x05, 0x4e,
-	0xf3, 0x74, 0x2b, 0x1b, 0x1b, 0x41, 0xe8, 0x53, 0x1f, 0xbd, 0x6f, 0x93, 0x53, 0x23, 0x05, 0x1b,
-	0xd9, 0xa4, 0x15, 0x38, 0xc6, 0xe9, 0x56, 0xe3, 0x56, 0xdf, 0xef, 0xfb, 0x1c, 0xd8, 0x64, 0xbf,
-	0x92, 0x9c, 0xc6, 0x7b, 0x7d, 0xdf, 0xef, 0xbb, 0xa4, 0xc9, 0x47, 0x87, 0xc3, 0xa3, 0x26, 0x19,
-	0x04, 0x74, 0x24, 0x26, 0xb5, 0x8b, 0x93, 0xd4, 0x19, 0x90, 0x88, 0x5a, 0x83, 0x20, 0x01, 0xe8,
-	0x7f, 0x48, 0x20, 0x3f, 0xb3, 0x22, 0x4a, 0x42, 0xb4, 0x0c, 0x05, 0xc7, 0x56, 0xa5, 0x55, 0x69,
-	0xbd, 0xb6, 0x2b, 0xc7, 0x63, 0xad, 0x60, 0xb6, 0x71, 0xc1, 0xb1, 0x51, 0x0b, 0x16, 0xfa, 0x61,
-	0xd0, 0xeb, 0x5a, 0xb6, 0x1d, 0x92, 0x28, 0x52, 0x0b, 0x1c, 0xb1, 0x14, 0x8f, 0x35, 0xe5, 0x09,
-	0xee, 0xec, 0xed, 0x24, 0x61, 0xac, 0x30, 0x90, 0x18, 0xa0, 0xbb, 0x50, 0x0b, 0x89, 0xed, 0x44,
-	0xdd, 0x61, 0xe8, 0xaa, 0x45, 0x9e, 0xb0, 0x10, 0x8f, 0xb5, 0x2a, 0x66, 0xc1, 0x17, 0xf8, 0x29,
-	0xae, 0xf2, 0xe9, 0x17, 0xa1, 0x8b, 0x36, 0x01, 0xfa, 0x16, 0x25, 0x67, 0xd6, 0xa8, 0xeb, 0x04,
-	0x6a, 0x89, 0x63, 0xeb, 0xf1, 0x58, 0xab, 0x3d, 0x49, 0xa2, 0x66, 0x07, 0xd7, 0x04, 0xc0, 0x0c,
-	0xd0, 0x03, 0x28, 0x07, 0x84, 0x84, 0x91, 0x5a, 0x5e, 0x2d, 0xae, 0x2b, 0x2d, 0xdd, 0xc8, 0x63,
-	0xcc, 0xe8, 0x10, 0x12, 0xe2, 0x24, 0x41, 0xff, 0xb1, 0x00, 0xca, 0x57, 0xbe, 0xe3, 0x61, 0xf2,
-	0xfd, 0x90, 0x44, 0xf4, 0xda, 0xeb, 0x6a, 0xa0, 0xf4, 0xdc, 0x21, 0x63, 0xa4, 0x7b, 0x42, 0x46,
-	0xc9, 0x6d, 0x31, 0x88, 0xd0, 0x3e, 0x19, 0x5d, 0xe2, 0xa3, 0x38, 0x07, 0x1f, 0x4d, 0x50, 0x88,
-	0x67, 0x07, 0xbe, 0xe3, 0xd1, 0xf3, 0x5b, 0x2e, 0xc6, 0x63, 0x0d, 0x1e, 0x8b, 0xb0, 0xd9, 0xc1,
-	0x90, 0x42, 0xcc, 0x00, 0xad, 0x41, 0x3d, 0x4b, 0x08, 0xfc, 0x90, 0xaa, 0xe5, 0x55, 0x69, 0xbd,
-	0x84, 0x17, 0xd2, 0x60, 0xc7, 0x0f, 0x29, 0xba, 0x03, 0x8b, 0x8e, 0x47, 0x49, 0x78, 0x64, 0xf5,
-	0x48, 0xd7, 0xb3, 0x06, 0x44, 0x95, 0xf9, 0x69, 0xeb, 0x59, 0xf4, 0xc0, 0x1a, 0x10, 0x84, 0xa0,
-	0xc4, 0x27, 0x2b, 0x7c, 0x92, 0xff, 0xd6, 0x7f, 0x96, 0x60, 0x21, 0x61, 0x23, 0x0a, 0x7c, 0x2f,
-	0x22, 0xe8, 0x11, 0xc8, 0x03, 0xde, 0x07, 0x9c, 0x12, 0xa5, 0xf5, 0x61, 0x3e, 0xb3, 0x49, 0xcf,
-	0x60, 0x91, 0x83, 0x3e, 0x83, 0x92, 0xe7, 0xdb, 0x84, 0xb3, 0x35, 0xb3, 0x2a, 0x07, 0xbe, 0x4d,
-	0x30, 0xc7, 0x9f, 0x97, 0xb3, 0x78, 0xd3, 0x72, 0x3e, 0x82, 0xc5, 0x3d, 0xdf, 0xf3, 0x48, 0x8f,
-	0xce, 0x2a, 0x68, 0x7a, 0xfd, 0xc2, 0xc4, 0xf5, 0x7f, 0x91, 0x60, 0x29, 0x4b, 0x17, 0x0c, 0x3c,
-	0x85, 0xca, 0x09, 0x19, 0x05, 0x96, 0x93, 0x52, 0x70, 0x27, 0xff, 0x34, 0xfb, 0x64, 0xd4, 0xb1,
-	0x9c, 0x70, 0x57, 0x89, 0xc7, 0x5a, 0x45, 0x0c, 0x70, 0xba, 0x04, 0x52, 0xa1, 0x32, 0x25, 0x18,
-	0x9c, 0x0e, 0xff, 0xfd, 0x9d, 0xd1, 0x6d, 0x28, 0xda, 0x5e, 0xa4, 0x96, 0x56, 0x8b, 0xeb, 0xb5,
-	0xdd, 0x4a, 0x3c, 0xd6, 0x8a, 0xed, 0x83, 0xe7, 0x98, 0xc5, 0x74, 0x03, 0x6e, 0xed, 0x0c, 0xe9,
-	0xb1, 0x1f, 0x3a, 0xaf, 0x09, 0x4f, 0xc9, 0x27, 0x45, 0xbf, 0x0f, 0xcb, 0x6d, 0x62, 0xdd, 0x24,
-	0x43, 0x85, 0xe5, 0x6c, 0x07, 0x9b, 0x25, 0x44, 0x22, 0x43, 0xdf, 0x86, 0x77, 0x2f, 0xcd, 0x08,
-	0x4e, 0x6f, 0x43, 0xd1, 0xb1, 0x23, 0x55, 0x3a, 0x3f, 0xb1, 0xd9, 0x8e, 0x30, 0x8b, 0xe9, 0x26,
-	0xa4, 0xa4, 0x31, 0xc9, 0x05, 0xa1, 0x73, 0x6a, 0x51, 0xc2, 0x25, 0x27, 0x25, 0x92, 0x13, 0x21,
-	0x26, 0xb9, 0x0f, 0x00, 0x82, 0xe1, 0xa1, 0xeb, 0xf4, 0x26, 0x24, 0x59, 0x4b, 0x22, 0xfb, 0x64,
-	0xa4, 0xff, 0x5d, 0x80, 0x12, 0x6b, 0xaa, 0xbc, 0x16, 0x60, 0xec, 0xa7, 0x2d, 0xc0, 0x7e, 0x4f,
-	0x96, 0xbb, 0xf8, 0xf6, 0xe5, 0xfe, 0x7f, 0x04, 0x3e, 0xed, 0x8d, 0xf2, 0x0c, 0x6f, 0xfc, 0x1c,
-	0x2a, 0xc3, 0xc0, 0xb6, 0x28, 0xb1, 0xb9, 0xd4, 0x95, 0x56, 0xc3, 0x48, 0xec, 0xdf, 0x48, 0xed,
-	0xdf, 0xf8, 0x3a, 0xb5, 0xff, 0xdd, 0xea, 0x9b, 0xb1, 0xf6, 0xce, 0x4f, 0xbf, 0x69, 0x12, 0x4e,
-	0x93, 0xae, 0xb0, 0x93, 0x6a, 0x9e, 0x9d, 0xd4, 0x26, 0xf4, 0xb4, 0x08, 0x0b, 0xac, 0x00, 0x59,
-	0x4b, 0x98, 0x50, 0x17, 0x63, 0xd1, 0x08, 0x0f, 0xa0, 0xcc, 0x04, 0x9f, 0xb4, 0xc2, 0x7c, 0x0e,
-	0x91, 0x24, 0xe8, 0x7f, 0x49, 0x50, 0x62, 0x4d, 0x75, 0x6d, 0x71, 0x27, 0x0a, 0x59, 0xf8, 0x4f,
-	0x0a, 0x69, 0xb9, 0xae, 0x7f, 0x46, 0xec, 0xae, 0x13, 0x24, 0x1a, 0x15, 0x85, 0xdc, 0x49, 0xc2,
-	0x66, 0x27, 0xc2, 0x20, 0x20, 0x66, 0x10, 0xa1, 0x06, 0x54, 0xd3, 0x9a, 0x25, 0x65, 0xc7, 0xd9,
-	0x18, 0xad, 0x41, 0x85, 0x29, 0x97, 0x15, 0xaf, 0xcc, 0xcf, 0x0d, 0xf1, 0x58, 0x93, 0xd9, 0x6d,
-	0xcc, 0x0e, 0x96, 0xd9, 0x94, 0x19, 0x64, 0x7c, 0xca, 0xd3, 0x7c, 0x4e, 0x49, 0xcc, 0x84, 0xfa,
-	0xb4, 0xb0, 0x32, 0x13, 0x91, 0x6e, 0x6a, 0x9c, 0x5f, 0x40, 0x19, 0xfb, 0x43, 0x4a, 0xd8, 0xe1,
-	0x18, 0xc3, 0xdd, 0x8c, 0x54, 0x7e, 0x38, 0x46, 0xbd, 0xd9, 0xc6, 0x32, 0x9b, 0x32, 0x6d, 0x66,
-	0x63, 0x1e, 0xa1, 0x67, 0x7e, 0x78, 0x92, 0xda, 0x98, 0x18, 0xea, 0xcf, 0x01, 0xed, 0x85, 0xc4,
-	0xa2, 0x84, 0xaf, 0x96, 0xba, 0xc7, 0x5b, 0x2e, 0x6a, 0x00, 0x6a, 0x13, 0x97, 0x5c, 0x58, 0x74,
-	0x02, 0x2f, 0x4d, 0xe3, 0x97, 0xa0, 0xce, 0x91, 0x19, 0x51, 0xcf, 0x60, 0x31, 0x0d, 0x08, 0xa6,
-	0x1e, 0x82, 0x1c, 0xf2, 0x88, 0xa0, 0x6a, 0x2d, 0x9f, 0xaa, 0x64, 0x63, 0x91, 0xd2, 0xfa, 0xb3,
-	0x02, 0xd5, 0x2f, 0x05, 0x02, 0x1d, 0x41, 0x45, 0xbc, 0x19, 0x68, 0x33, 0x7f, 0x91, 0xe9, 0x97,
-	0xa9, 0xf1, 0xf1, 0x9c, 0x68, 0x71, 0xe2, 0x6f, 0xa1, 0x3e, 0xe5, 0xe5, 0xa8, 0x95, 0x9f, 0x7f,
-	0x95, 0xf1, 0x37, 0x96, 0x2f, 0x69, 0xff, 0x31, 0xfb, 0x2e, 0x44, 0x5d, 0x58, 0xba, 0x60, 0xfc,
-	0x68, 0x3b, 0x7f, 0xf9, 0xab, 0xdf, 0x89, 0x6b, 0x37, 0xf8, 0x01, 0x96, 0x2e, 0xbc, 0x06, 0xb3,
-	0x36, 0xb8, 0xfa, 0x59, 0x69, 0x7c, 0x7a, 0xc3, 0x2c, 0xc1, 0xde, 0x77, 0x50, 0x62, 0x1f, 0x36,
-	0xe8, 0x6e, 0x7e, 0xfa, 0xc4, 0xa7, 0x60, 0x63, 0x63, 0x1e, 0xa8, 0x58, 0xbe, 0x07, 0x72, 0xd2,
-	0x60, 0xe8, 0xde, 0x1c, 0x8d, 0x94, 0x5d, 0x66, 0x73, 0x3e, 0xb0, 0xd8, 0xe4, 0x25, 0x28, 0x13,
-	0xda, 0x42, 0xf7, 0x67, 0xf4, 0xcf, 0x25, 0x19, 0x5e, 0x5b, 0x9c, 0x97, 0xa0, 0x4c, 0xe8, 0x6b,
-	0xd6, 0xc2, 0x97, 0xa5, 0x78, 0xed, 0xc2, 0xaf, 0xa0, 0xcc, 0x0d, 0x1f, 0x6d, 0xcc, 0x76, 0xf6,
-	0x8c, 0x94, 0x7b, 0x73, 0x61, 0x05, 0x27, 0xaf, 0xa0, 0x9c, 0x74, 0xd3, 0xc6, 0x6c, 0xaf, 0x9b,
-	0x77, 0x87, 0xa9, 0xce, 0xd9, 0xdd, 0x7e, 0x13, 0xaf, 0x48, 0xbf, 0xc6, 0x2b, 0xd2, 0xef, 0xf1,
-	0x8a, 0xf4, 0xcd, 0x47, 0x73, 0xfc, 0x7f, 0x7b, 0x78, 0xba, 0x75, 0x28, 0x73, 0x26, 0x3e, 0xf9,
-	0x27, 0x00, 0x00, 0xff, 0xff, 0x27, 0x96, 0x88, 0x70, 0xf0, 0x0d, 0x00, 0x00,
+func (m *Route) GetNodes() []*RouteNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// HeimdallClient is the client API for Heimdall service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type HeimdallClient interface {
-	Connect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*ConnectResponse, error)
-	AuthorizePeer(ctx context.Context, in *AuthorizePeerRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	DeauthorizePeer(ctx context.Context, in *DeauthorizePeerRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	AuthorizedPeers(ctx context.Context, in *AuthorizedPeersRequest, opts ...grpc.CallOption) (*AuthorizedPeersResponse, error)
-	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
-	Routes(ctx context.Context, in *RoutesRequest, opts ...grpc.CallOption) (*RoutesResponse, error)
-	CreateRoute(ctx context.Context, in *CreateRouteRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	DeleteRoute(ctx context.Context, in *DeleteRouteRequest, opts ...grpc.CallOption) (*types.Empty, error)
-	Nodes(ctx context.Context, in *NodesRequest, opts ...grpc.CallOption) (*NodesResponse, error)
-	Peers(ctx context.Context, in *PeersRequest, opts ...grpc.CallOption) (*PeersResponse, error)
+func (m *Route) GetActiveNodeID() string {
+	if m != nil {
+		return m.ActiveNodeID
+	}
+	return ""
 }
 
-type heimdallClient struct {
-	cc *grpc.ClientConn
+func (m *Route) GetStale() bool {
+	if m != nil {
+		return m.Stale
+	}
+	return false
 }
 
-func NewHeimdallClient(cc *grpc.ClientConn) HeimdallClient {
-	return &heimdallClient{cc}
+func (m *Route) GetPeerID() string {
+	if m != nil {
+		return m.PeerID
+	}
+	return ""
 }
 
-func (c *heimdallClient) Connect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*ConnectResponse, error) {
-	out := new(ConnectResponse)
-	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/Connect", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Route) GetPending() bool {
+	if m != nil {
+		return m.Pending
 	}
-	return out, nil
+	return false
 }
 
-func (c *heimdallClient) AuthorizePeer(ctx context.Context, in *AuthorizePeerRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/AuthorizePeer", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type RouteNode struct {
+	NodeID string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// priority orders the candidates (lowest first)
+	Priority             uint32   `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *heimdallClient) DeauthorizePeer(ctx context.Context, in *DeauthorizePeerRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/DeauthorizePeer", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *RouteNode) Reset()         { *m = RouteNode{} }
+func (m *RouteNode) String() string { return proto.CompactTextString(m) }
+func (*RouteNode) ProtoMessage()    {}
+func (*RouteNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{23}
+}
+func (m *RouteNode) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RouteNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RouteNode.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *RouteNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RouteNode.Merge(m, src)
+}
+func (m *RouteNode) XXX_Size() int {
+	return m.Size()
+}
+func (m *RouteNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_RouteNode.DiscardUnknown(m)
 }
 
-func (c *heimdallClient) AuthorizedPeers(ctx context.Context, in *AuthorizedPeersRequest, opts ...grpc.CallOption) (*AuthorizedPeersResponse, error) {
-	out := new(AuthorizedPeersResponse)
-	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/AuthorizedPeers", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RouteNode proto.InternalMessageInfo
+
+func (m *RouteNode) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
 	}
-	return out, nil
+	return ""
 }
 
-func (c *heimdallClient) Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
-	out := new(JoinResponse)
-	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/Join", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *RouteNode) GetPriority() uint32 {
+	if m != nil {
+		return m.Priority
 	}
-	return out, nil
+	return 0
 }
 
-func (c *heimdallClient) Routes(ctx context.Context, in *RoutesRequest, opts ...grpc.CallOption) (*RoutesResponse, error) {
-	out := new(RoutesResponse)
-	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/Routes", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type CreateRouteRequest struct {
+	NodeID   string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Network  string   `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	Tags     []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	Priority uint32   `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	// force allows the route to overlap other routes and cluster networks
+	Force                bool     `protobuf:"varint,5,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *heimdallClient) CreateRoute(ctx context.Context, in *CreateRouteRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/CreateRoute", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *CreateRouteRequest) Reset()         { *m = CreateRouteRequest{} }
+func (m *CreateRouteRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRouteRequest) ProtoMessage()    {}
+func (*CreateRouteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{24}
+}
+func (m *CreateRouteRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreateRouteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreateRouteRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *CreateRouteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRouteRequest.Merge(m, src)
+}
+func (m *CreateRouteRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CreateRouteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRouteRequest.DiscardUnknown(m)
 }
 
-func (c *heimdallClient) DeleteRoute(ctx context.Context, in *DeleteRouteRequest, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/DeleteRoute", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_CreateRouteRequest proto.InternalMessageInfo
+
+func (m *CreateRouteRequest) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
 	}
-	return out, nil
+	return ""
 }
 
-func (c *heimdallClient) Nodes(ctx context.Context, in *NodesRequest, opts ...grpc.CallOption) (*NodesResponse, error) {
-	out := new(NodesResponse)
-	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/Nodes", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *CreateRouteRequest) GetNetwork() string {
+	if m != nil {
+		return m.Network
 	}
-	return out, nil
+	return ""
 }
 
-func (c *heimdallClient) Peers(ctx context.Context, in *PeersRequest, opts ...grpc.CallOption) (*PeersResponse, error) {
-	out := new(PeersResponse)
-	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/Peers", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *CreateRouteRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
 	}
-	return out, nil
+	return nil
 }
 
-// HeimdallServer is the server API for Heimdall service.
-type HeimdallServer interface {
-	Connect(context.Context, *ConnectRequest) (*ConnectResponse, error)
-	AuthorizePeer(context.Context, *AuthorizePeerRequest) (*types.Empty, error)
-	DeauthorizePeer(context.Context, *DeauthorizePeerRequest) (*types.Empty, error)
-	AuthorizedPeers(context.Context, *AuthorizedPeersRequest) (*AuthorizedPeersResponse, error)
-	Join(context.Context, *JoinRequest) (*JoinResponse, error)
-	Routes(context.Context, *RoutesRequest) (*RoutesResponse, error)
-	CreateRoute(context.Context, *CreateRouteRequest) (*types.Empty, error)
-	DeleteRoute(context.Context, *DeleteRouteRequest) (*types.Empty, error)
-	Nodes(context.Context, *NodesRequest) (*NodesResponse, error)
-	Peers(context.Context, *PeersRequest) (*PeersResponse, error)
+func (m *CreateRouteRequest) GetPriority() uint32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
 }
 
-// UnimplementedHeimdallServer can be embedded to have forward compatible implementations.
-type UnimplementedHeimdallServer struct {
+func (m *CreateRouteRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
 }
 
-func (*UnimplementedHeimdallServer) Connect(ctx context.Context, req *ConnectRequest) (*ConnectResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Connect not implemented")
-}
-func (*UnimplementedHeimdallServer) AuthorizePeer(ctx context.Context, req *AuthorizePeerRequest) (*types.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AuthorizePeer not implemented")
-}
-func (*UnimplementedHeimdallServer) DeauthorizePeer(ctx context.Context, req *DeauthorizePeerRequest) (*types.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeauthorizePeer not implemented")
-}
-func (*UnimplementedHeimdallServer) AuthorizedPeers(ctx context.Context, req *AuthorizedPeersRequest) (*AuthorizedPeersResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AuthorizedPeers not implemented")
+type ApproveRouteRequest struct {
+	PeerID  string   `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	Network string   `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	Tags    []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// force allows the route to overlap other routes and cluster networks
+	Force                bool     `protobuf:"varint,4,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (*UnimplementedHeimdallServer) Join(ctx context.Context, req *JoinRequest) (*JoinResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
+
+func (m *ApproveRouteRequest) Reset()         { *m = ApproveRouteRequest{} }
+func (m *ApproveRouteRequest) String() string { return proto.CompactTextString(m) }
+func (*ApproveRouteRequest) ProtoMessage()    {}
+func (*ApproveRouteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{25}
 }
-func (*UnimplementedHeimdallServer) Routes(ctx context.Context, req *RoutesRequest) (*RoutesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Routes not implemented")
+func (m *ApproveRouteRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedHeimdallServer) CreateRoute(ctx context.Context, req *CreateRouteRequest) (*types.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateRoute not implemented")
+func (m *ApproveRouteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ApproveRouteRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-func (*UnimplementedHeimdallServer) DeleteRoute(ctx context.Context, req *DeleteRouteRequest) (*types.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoute not implemented")
+func (m *ApproveRouteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveRouteRequest.Merge(m, src)
 }
-func (*UnimplementedHeimdallServer) Nodes(ctx context.Context, req *NodesRequest) (*NodesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Nodes not implemented")
+func (m *ApproveRouteRequest) XXX_Size() int {
+	return m.Size()
 }
-func (*UnimplementedHeimdallServer) Peers(ctx context.Context, req *PeersRequest) (*PeersResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Peers not implemented")
+func (m *ApproveRouteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveRouteRequest.DiscardUnknown(m)
 }
 
-func RegisterHeimdallServer(s *grpc.Server, srv HeimdallServer) {
-	s.RegisterService(&_Heimdall_serviceDesc, srv)
-}
+var xxx_messageInfo_ApproveRouteRequest proto.InternalMessageInfo
 
-func _Heimdall_Connect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ConnectRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(HeimdallServer).Connect(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/Connect",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeimdallServer).Connect(ctx, req.(*ConnectRequest))
+func (m *ApproveRouteRequest) GetPeerID() string {
+	if m != nil {
+		return m.PeerID
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Heimdall_AuthorizePeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AuthorizePeerRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(HeimdallServer).AuthorizePeer(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/AuthorizePeer",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeimdallServer).AuthorizePeer(ctx, req.(*AuthorizePeerRequest))
+func (m *ApproveRouteRequest) GetNetwork() string {
+	if m != nil {
+		return m.Network
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Heimdall_DeauthorizePeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeauthorizePeerRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(HeimdallServer).DeauthorizePeer(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/DeauthorizePeer",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeimdallServer).DeauthorizePeer(ctx, req.(*DeauthorizePeerRequest))
+func (m *ApproveRouteRequest) GetTags() []string {
+	if m != nil {
+		return m.Tags
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Heimdall_AuthorizedPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AuthorizedPeersRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(HeimdallServer).AuthorizedPeers(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/AuthorizedPeers",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeimdallServer).AuthorizedPeers(ctx, req.(*AuthorizedPeersRequest))
+func (m *ApproveRouteRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
 	}
-	return interceptor(ctx, in, info, handler)
+	return false
 }
 
-func _Heimdall_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(JoinRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(HeimdallServer).Join(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/Join",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeimdallServer).Join(ctx, req.(*JoinRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type PeerRoutes struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Networks             []string `protobuf:"bytes,2,rep,name=networks,proto3" json:"networks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func _Heimdall_Routes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RoutesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(HeimdallServer).Routes(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/Routes",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeimdallServer).Routes(ctx, req.(*RoutesRequest))
+func (m *PeerRoutes) Reset()         { *m = PeerRoutes{} }
+func (m *PeerRoutes) String() string { return proto.CompactTextString(m) }
+func (*PeerRoutes) ProtoMessage()    {}
+func (*PeerRoutes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{26}
+}
+func (m *PeerRoutes) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerRoutes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerRoutes.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *PeerRoutes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerRoutes.Merge(m, src)
+}
+func (m *PeerRoutes) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerRoutes) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerRoutes.DiscardUnknown(m)
 }
 
-func _Heimdall_CreateRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRouteRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(HeimdallServer).CreateRoute(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/CreateRoute",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeimdallServer).CreateRoute(ctx, req.(*CreateRouteRequest))
+var xxx_messageInfo_PeerRoutes proto.InternalMessageInfo
+
+func (m *PeerRoutes) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Heimdall_DeleteRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteRouteRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(HeimdallServer).DeleteRoute(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/DeleteRoute",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeimdallServer).DeleteRoute(ctx, req.(*DeleteRouteRequest))
+func (m *PeerRoutes) GetNetworks() []string {
+	if m != nil {
+		return m.Networks
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Heimdall_Nodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NodesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(HeimdallServer).Nodes(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/Nodes",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeimdallServer).Nodes(ctx, req.(*NodesRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type DeleteRouteRequest struct {
+	Network string `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	// node_id removes only the candidate node from the route
+	NodeID               string   `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func _Heimdall_Peers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PeersRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(HeimdallServer).Peers(ctx, in)
+func (m *DeleteRouteRequest) Reset()         { *m = DeleteRouteRequest{} }
+func (m *DeleteRouteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRouteRequest) ProtoMessage()    {}
+func (*DeleteRouteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{27}
+}
+func (m *DeleteRouteRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteRouteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteRouteRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/Peers",
+}
+func (m *DeleteRouteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRouteRequest.Merge(m, src)
+}
+func (m *DeleteRouteRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteRouteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRouteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRouteRequest proto.InternalMessageInfo
+
+func (m *DeleteRouteRequest) GetNetwork() string {
+	if m != nil {
+		return m.Network
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(HeimdallServer).Peers(ctx, req.(*PeersRequest))
+	return ""
+}
+
+func (m *DeleteRouteRequest) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-var _Heimdall_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "dev.ehazlett.heimdall.api.v1.Heimdall",
-	HandlerType: (*HeimdallServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Connect",
-			Handler:    _Heimdall_Connect_Handler,
-		},
-		{
-			MethodName: "AuthorizePeer",
-			Handler:    _Heimdall_AuthorizePeer_Handler,
-		},
-		{
-			MethodName: "DeauthorizePeer",
-			Handler:    _Heimdall_DeauthorizePeer_Handler,
-		},
-		{
-			MethodName: "AuthorizedPeers",
-			Handler:    _Heimdall_AuthorizedPeers_Handler,
-		},
-		{
-			MethodName: "Join",
-			Handler:    _Heimdall_Join_Handler,
-		},
-		{
-			MethodName: "Routes",
-			Handler:    _Heimdall_Routes_Handler,
-		},
-		{
-			MethodName: "CreateRoute",
-			Handler:    _Heimdall_CreateRoute_Handler,
-		},
-		{
-			MethodName: "DeleteRoute",
-			Handler:    _Heimdall_DeleteRoute_Handler,
-		},
-		{
-			MethodName: "Nodes",
-			Handler:    _Heimdall_Nodes_Handler,
-		},
-		{
-			MethodName: "Peers",
-			Handler:    _Heimdall_Peers_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "github.com/ehazlett/heimdall/api/v1/heimdall.proto",
+type RoutesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Master) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *RoutesRequest) Reset()         { *m = RoutesRequest{} }
+func (m *RoutesRequest) String() string { return proto.CompactTextString(m) }
+func (*RoutesRequest) ProtoMessage()    {}
+func (*RoutesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{28}
+}
+func (m *RoutesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RoutesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RoutesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *RoutesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoutesRequest.Merge(m, src)
+}
+func (m *RoutesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RoutesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoutesRequest.DiscardUnknown(m)
 }
 
-func (m *Master) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_RoutesRequest proto.InternalMessageInfo
+
+type RoutesResponse struct {
+	Routes               []*Route `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Master) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Peers) > 0 {
-		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintHeimdall(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x2a
+func (m *RoutesResponse) Reset()         { *m = RoutesResponse{} }
+func (m *RoutesResponse) String() string { return proto.CompactTextString(m) }
+func (*RoutesResponse) ProtoMessage()    {}
+func (*RoutesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{29}
+}
+func (m *RoutesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RoutesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RoutesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.GatewayIP) > 0 {
-		i -= len(m.GatewayIP)
-		copy(dAtA[i:], m.GatewayIP)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.GatewayIP)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.RedisURL) > 0 {
-		i -= len(m.RedisURL)
-		copy(dAtA[i:], m.RedisURL)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.RedisURL)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.GRPCAddress) > 0 {
-		i -= len(m.GRPCAddress)
-		copy(dAtA[i:], m.GRPCAddress)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.GRPCAddress)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+}
+func (m *RoutesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoutesResponse.Merge(m, src)
+}
+func (m *RoutesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RoutesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoutesResponse.DiscardUnknown(m)
 }
 
-func (m *JoinRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RoutesResponse proto.InternalMessageInfo
+
+func (m *RoutesResponse) GetRoutes() []*Route {
+	if m != nil {
+		return m.Routes
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *JoinRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type Policy struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// peer_id is the peer the policy applies to.  If peer_id and tag are
+	// empty the policy applies to all peers.
+	PeerID string `protobuf:"bytes,2,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	// destination is the destination network
+	Destination string `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	// protocol is tcp, udp or icmp; empty matches all protocols
+	Protocol string `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// ports is a tcp or udp destination port or range (i.e. 8000-8100)
+	Ports  string        `protobuf:"bytes,5,opt,name=ports,proto3" json:"ports,omitempty"`
+	Action Policy_Action `protobuf:"varint,6,opt,name=action,proto3,enum=dev.ehazlett.heimdall.api.v1.Policy_Action" json:"action,omitempty"`
+	// priority orders the policies; lower priorities are evaluated first
+	Priority uint32 `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	// tag applies the policy to the peers with the tag
+	Tag                  string   `protobuf:"bytes,8,opt,name=tag,proto3" json:"tag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *JoinRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0x3a
-	}
-	if len(m.InterfaceName) > 0 {
-		i -= len(m.InterfaceName)
-		copy(dAtA[i:], m.InterfaceName)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.InterfaceName)))
-		i--
-		dAtA[i] = 0x32
-	}
-	if m.EndpointPort != 0 {
-		i = encodeVarintHeimdall(dAtA, i, uint64(m.EndpointPort))
-		i--
-		dAtA[i] = 0x28
-	}
-	if len(m.EndpointIP) > 0 {
-		i -= len(m.EndpointIP)
-		copy(dAtA[i:], m.EndpointIP)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.EndpointIP)))
-		i--
-		dAtA[i] = 0x22
+func (m *Policy) Reset()         { *m = Policy{} }
+func (m *Policy) String() string { return proto.CompactTextString(m) }
+func (*Policy) ProtoMessage()    {}
+func (*Policy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{30}
+}
+func (m *Policy) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Policy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Policy.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(m.GRPCAddress) > 0 {
-		i -= len(m.GRPCAddress)
-		copy(dAtA[i:], m.GRPCAddress)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.GRPCAddress)))
-		i--
-		dAtA[i] = 0x1a
+}
+func (m *Policy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Policy.Merge(m, src)
+}
+func (m *Policy) XXX_Size() int {
+	return m.Size()
+}
+func (m *Policy) XXX_DiscardUnknown() {
+	xxx_messageInfo_Policy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Policy proto.InternalMessageInfo
+
+func (m *Policy) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	if len(m.ClusterKey) > 0 {
-		i -= len(m.ClusterKey)
-		copy(dAtA[i:], m.ClusterKey)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ClusterKey)))
-		i--
-		dAtA[i] = 0x12
+	return ""
+}
+
+func (m *Policy) GetPeerID() string {
+	if m != nil {
+		return m.PeerID
 	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0xa
+	return ""
+}
+
+func (m *Policy) GetDestination() string {
+	if m != nil {
+		return m.Destination
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *JoinResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *Policy) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *JoinResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *Policy) GetPorts() string {
+	if m != nil {
+		return m.Ports
+	}
+	return ""
 }
 
-func (m *JoinResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Peers) > 0 {
-		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintHeimdall(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
-	}
-	if m.Node != nil {
-		{
-			size, err := m.Node.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintHeimdall(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Master != nil {
-		{
-			size, err := m.Master.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintHeimdall(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+func (m *Policy) GetAction() Policy_Action {
+	if m != nil {
+		return m.Action
 	}
-	return len(dAtA) - i, nil
+	return Policy_ALLOW
 }
 
-func (m *ConnectRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *Policy) GetPriority() uint32 {
+	if m != nil {
+		return m.Priority
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ConnectRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return 0
 }
 
-func (m *ConnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0xa
+func (m *Policy) GetTag() string {
+	if m != nil {
+		return m.Tag
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *ConnectResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type CreatePolicyRequest struct {
+	Policy               *Policy  `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ConnectResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *CreatePolicyRequest) Reset()         { *m = CreatePolicyRequest{} }
+func (m *CreatePolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*CreatePolicyRequest) ProtoMessage()    {}
+func (*CreatePolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{31}
 }
-
-func (m *ConnectResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.DNS) > 0 {
-		for iNdEx := len(m.DNS) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.DNS[iNdEx])
-			copy(dAtA[i:], m.DNS[iNdEx])
-			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.DNS[iNdEx])))
-			i--
-			dAtA[i] = 0x22
-		}
-	}
-	if len(m.Peers) > 0 {
-		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintHeimdall(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
-	}
-	if len(m.Address) > 0 {
-		i -= len(m.Address)
-		copy(dAtA[i:], m.Address)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Address)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.KeyPair != nil {
-		{
-			size, err := m.KeyPair.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintHeimdall(dAtA, i, uint64(size))
+func (m *CreatePolicyRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CreatePolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CreatePolicyRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *AuthorizePeerRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *CreatePolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreatePolicyRequest.Merge(m, src)
 }
-
-func (m *AuthorizePeerRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *CreatePolicyRequest) XXX_Size() int {
+	return m.Size()
 }
-
-func (m *AuthorizePeerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *CreatePolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreatePolicyRequest.DiscardUnknown(m)
 }
 
-func (m *DeauthorizePeerRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_CreatePolicyRequest proto.InternalMessageInfo
+
+func (m *CreatePolicyRequest) GetPolicy() *Policy {
+	if m != nil {
+		return m.Policy
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *DeauthorizePeerRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type DeletePolicyRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeauthorizePeerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0xa
+func (m *DeletePolicyRequest) Reset()         { *m = DeletePolicyRequest{} }
+func (m *DeletePolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*DeletePolicyRequest) ProtoMessage()    {}
+func (*DeletePolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{32}
+}
+func (m *DeletePolicyRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeletePolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeletePolicyRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *DeletePolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeletePolicyRequest.Merge(m, src)
+}
+func (m *DeletePolicyRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeletePolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeletePolicyRequest.DiscardUnknown(m)
 }
 
-func (m *AuthorizedPeersRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DeletePolicyRequest proto.InternalMessageInfo
+
+func (m *DeletePolicyRequest) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *AuthorizedPeersRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type PoliciesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AuthorizedPeersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return len(dAtA) - i, nil
+func (m *PoliciesRequest) Reset()         { *m = PoliciesRequest{} }
+func (m *PoliciesRequest) String() string { return proto.CompactTextString(m) }
+func (*PoliciesRequest) ProtoMessage()    {}
+func (*PoliciesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{33}
 }
-
-func (m *AuthorizedPeersResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *PoliciesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PoliciesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PoliciesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *PoliciesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoliciesRequest.Merge(m, src)
+}
+func (m *PoliciesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PoliciesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoliciesRequest.DiscardUnknown(m)
 }
 
-func (m *AuthorizedPeersResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_PoliciesRequest proto.InternalMessageInfo
+
+type PoliciesResponse struct {
+	Policies             []*Policy `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *AuthorizedPeersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.IDs) > 0 {
-		for iNdEx := len(m.IDs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.IDs[iNdEx])
-			copy(dAtA[i:], m.IDs[iNdEx])
-			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.IDs[iNdEx])))
-			i--
-			dAtA[i] = 0xa
+func (m *PoliciesResponse) Reset()         { *m = PoliciesResponse{} }
+func (m *PoliciesResponse) String() string { return proto.CompactTextString(m) }
+func (*PoliciesResponse) ProtoMessage()    {}
+func (*PoliciesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{34}
+}
+func (m *PoliciesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PoliciesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PoliciesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *PoliciesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoliciesResponse.Merge(m, src)
+}
+func (m *PoliciesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PoliciesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoliciesResponse.DiscardUnknown(m)
 }
 
-func (m *KeyPair) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_PoliciesResponse proto.InternalMessageInfo
+
+func (m *PoliciesResponse) GetPolicies() []*Policy {
+	if m != nil {
+		return m.Policies
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *KeyPair) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type RequestVoteRequest struct {
+	Term                 uint64   `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	CandidateID          string   `protobuf:"bytes,2,opt,name=candidate_id,json=candidateId,proto3" json:"candidate_id,omitempty"`
+	ClusterKey           string   `protobuf:"bytes,3,opt,name=cluster_key,json=clusterKey,proto3" json:"cluster_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KeyPair) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *RequestVoteRequest) Reset()         { *m = RequestVoteRequest{} }
+func (m *RequestVoteRequest) String() string { return proto.CompactTextString(m) }
+func (*RequestVoteRequest) ProtoMessage()    {}
+func (*RequestVoteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{35}
+}
+func (m *RequestVoteRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestVoteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestVoteRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(m.PublicKey) > 0 {
-		i -= len(m.PublicKey)
-		copy(dAtA[i:], m.PublicKey)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.PublicKey)))
-		i--
-		dAtA[i] = 0x12
+}
+func (m *RequestVoteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestVoteRequest.Merge(m, src)
+}
+func (m *RequestVoteRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestVoteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestVoteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestVoteRequest proto.InternalMessageInfo
+
+func (m *RequestVoteRequest) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
 	}
-	if len(m.PrivateKey) > 0 {
-		i -= len(m.PrivateKey)
-		copy(dAtA[i:], m.PrivateKey)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.PrivateKey)))
-		i--
-		dAtA[i] = 0xa
+	return 0
+}
+
+func (m *RequestVoteRequest) GetCandidateID() string {
+	if m != nil {
+		return m.CandidateID
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *Node) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *RequestVoteRequest) GetClusterKey() string {
+	if m != nil {
+		return m.ClusterKey
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *Node) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type RequestVoteResponse struct {
+	Granted              bool     `protobuf:"varint,1,opt,name=granted,proto3" json:"granted,omitempty"`
+	Term                 uint64   `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Node) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0x4a
-	}
-	if len(m.InterfaceName) > 0 {
-		i -= len(m.InterfaceName)
-		copy(dAtA[i:], m.InterfaceName)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.InterfaceName)))
-		i--
-		dAtA[i] = 0x42
-	}
-	n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Updated, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Updated):])
-	if err4 != nil {
-		return 0, err4
-	}
-	i -= n4
-	i = encodeVarintHeimdall(dAtA, i, uint64(n4))
-	i--
-	dAtA[i] = 0x3a
-	if len(m.GatewayIP) > 0 {
-		i -= len(m.GatewayIP)
-		copy(dAtA[i:], m.GatewayIP)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.GatewayIP)))
-		i--
-		dAtA[i] = 0x32
-	}
-	if m.EndpointPort != 0 {
-		i = encodeVarintHeimdall(dAtA, i, uint64(m.EndpointPort))
-		i--
-		dAtA[i] = 0x28
-	}
-	if len(m.EndpointIP) > 0 {
-		i -= len(m.EndpointIP)
-		copy(dAtA[i:], m.EndpointIP)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.EndpointIP)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.KeyPair != nil {
-		{
-			size, err := m.KeyPair.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintHeimdall(dAtA, i, uint64(size))
+func (m *RequestVoteResponse) Reset()         { *m = RequestVoteResponse{} }
+func (m *RequestVoteResponse) String() string { return proto.CompactTextString(m) }
+func (*RequestVoteResponse) ProtoMessage()    {}
+func (*RequestVoteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{36}
+}
+func (m *RequestVoteResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestVoteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestVoteResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Addr) > 0 {
-		i -= len(m.Addr)
-		copy(dAtA[i:], m.Addr)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Addr)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *RequestVoteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestVoteResponse.Merge(m, src)
+}
+func (m *RequestVoteResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestVoteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestVoteResponse.DiscardUnknown(m)
 }
 
-func (m *NodesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RequestVoteResponse proto.InternalMessageInfo
+
+func (m *RequestVoteResponse) GetGranted() bool {
+	if m != nil {
+		return m.Granted
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *NodesRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *RequestVoteResponse) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
 }
 
-func (m *NodesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return len(dAtA) - i, nil
+type MasterRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NodesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *MasterRequest) Reset()         { *m = MasterRequest{} }
+func (m *MasterRequest) String() string { return proto.CompactTextString(m) }
+func (*MasterRequest) ProtoMessage()    {}
+func (*MasterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{37}
+}
+func (m *MasterRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MasterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MasterRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *MasterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MasterRequest.Merge(m, src)
+}
+func (m *MasterRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *MasterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MasterRequest.DiscardUnknown(m)
 }
 
-func (m *NodesResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_MasterRequest proto.InternalMessageInfo
+
+type MasterResponse struct {
+	Master               *Master  `protobuf:"bytes,1,opt,name=master,proto3" json:"master,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NodesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Nodes) > 0 {
-		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Nodes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintHeimdall(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+func (m *MasterResponse) Reset()         { *m = MasterResponse{} }
+func (m *MasterResponse) String() string { return proto.CompactTextString(m) }
+func (*MasterResponse) ProtoMessage()    {}
+func (*MasterResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{38}
+}
+func (m *MasterResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MasterResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MasterResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *MasterResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MasterResponse.Merge(m, src)
+}
+func (m *MasterResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *MasterResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MasterResponse.DiscardUnknown(m)
 }
 
-func (m *Peer) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_MasterResponse proto.InternalMessageInfo
+
+func (m *MasterResponse) GetMaster() *Master {
+	if m != nil {
+		return m.Master
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Peer) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ReconcileConflict struct {
+	Kind                 string   `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Local                string   `protobuf:"bytes,3,opt,name=local,proto3" json:"local,omitempty"`
+	Master               string   `protobuf:"bytes,4,opt,name=master,proto3" json:"master,omitempty"`
+	Resolution           string   `protobuf:"bytes,5,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Peer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0x32
-	}
-	if len(m.PeerIP) > 0 {
-		i -= len(m.PeerIP)
-		copy(dAtA[i:], m.PeerIP)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.PeerIP)))
-		i--
-		dAtA[i] = 0x2a
-	}
-	if len(m.Endpoint) > 0 {
-		i -= len(m.Endpoint)
-		copy(dAtA[i:], m.Endpoint)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Endpoint)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.AllowedIPs) > 0 {
-		for iNdEx := len(m.AllowedIPs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.AllowedIPs[iNdEx])
-			copy(dAtA[i:], m.AllowedIPs[iNdEx])
-			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.AllowedIPs[iNdEx])))
-			i--
-			dAtA[i] = 0x1a
-		}
-	}
-	if m.KeyPair != nil {
-		{
-			size, err := m.KeyPair.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintHeimdall(dAtA, i, uint64(size))
+func (m *ReconcileConflict) Reset()         { *m = ReconcileConflict{} }
+func (m *ReconcileConflict) String() string { return proto.CompactTextString(m) }
+func (*ReconcileConflict) ProtoMessage()    {}
+func (*ReconcileConflict) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{39}
+}
+func (m *ReconcileConflict) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReconcileConflict) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReconcileConflict.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x12
+		return b[:n], nil
 	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0xa
+}
+func (m *ReconcileConflict) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconcileConflict.Merge(m, src)
+}
+func (m *ReconcileConflict) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReconcileConflict) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconcileConflict.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconcileConflict proto.InternalMessageInfo
+
+func (m *ReconcileConflict) GetKind() string {
+	if m != nil {
+		return m.Kind
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *PeersRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ReconcileConflict) GetKey() string {
+	if m != nil {
+		return m.Key
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *PeersRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ReconcileConflict) GetLocal() string {
+	if m != nil {
+		return m.Local
+	}
+	return ""
 }
 
-func (m *PeersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *ReconcileConflict) GetMaster() string {
+	if m != nil {
+		return m.Master
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *PeersResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ReconcileConflict) GetResolution() string {
+	if m != nil {
+		return m.Resolution
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *PeersResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ReconcileReport struct {
+	Created     time.Time            `protobuf:"bytes,1,opt,name=created,proto3,stdtime" json:"created"`
+	MasterID    string               `protobuf:"bytes,2,opt,name=master_id,json=masterId,proto3" json:"master_id,omitempty"`
+	MasterTerm  uint64               `protobuf:"varint,3,opt,name=master_term,json=masterTerm,proto3" json:"master_term,omitempty"`
+	DemotedID   string               `protobuf:"bytes,4,opt,name=demoted_id,json=demotedId,proto3" json:"demoted_id,omitempty"`
+	DemotedTerm uint64               `protobuf:"varint,5,opt,name=demoted_term,json=demotedTerm,proto3" json:"demoted_term,omitempty"`
+	Restored    uint64               `protobuf:"varint,6,opt,name=restored,proto3" json:"restored,omitempty"`
+	Conflicts   []*ReconcileConflict `protobuf:"bytes,7,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	// deleted is the number of deletes of the demoted master applied to the master
+	Deleted              uint64   `protobuf:"varint,8,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PeersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Peers) > 0 {
-		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintHeimdall(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+func (m *ReconcileReport) Reset()         { *m = ReconcileReport{} }
+func (m *ReconcileReport) String() string { return proto.CompactTextString(m) }
+func (*ReconcileReport) ProtoMessage()    {}
+func (*ReconcileReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{40}
+}
+func (m *ReconcileReport) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReconcileReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReconcileReport.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ReconcileReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconcileReport.Merge(m, src)
+}
+func (m *ReconcileReport) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReconcileReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconcileReport.DiscardUnknown(m)
 }
 
-func (m *Route) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ReconcileReport proto.InternalMessageInfo
+
+func (m *ReconcileReport) GetCreated() time.Time {
+	if m != nil {
+		return m.Created
 	}
-	return dAtA[:n], nil
+	return time.Time{}
 }
 
-func (m *Route) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ReconcileReport) GetMasterID() string {
+	if m != nil {
+		return m.MasterID
+	}
+	return ""
 }
 
-func (m *Route) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Network) > 0 {
-		i -= len(m.Network)
-		copy(dAtA[i:], m.Network)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Network)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.NodeID) > 0 {
-		i -= len(m.NodeID)
-		copy(dAtA[i:], m.NodeID)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.NodeID)))
-		i--
-		dAtA[i] = 0xa
+func (m *ReconcileReport) GetMasterTerm() uint64 {
+	if m != nil {
+		return m.MasterTerm
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *CreateRouteRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ReconcileReport) GetDemotedID() string {
+	if m != nil {
+		return m.DemotedID
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *CreateRouteRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ReconcileReport) GetDemotedTerm() uint64 {
+	if m != nil {
+		return m.DemotedTerm
+	}
+	return 0
 }
 
-func (m *CreateRouteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *ReconcileReport) GetRestored() uint64 {
+	if m != nil {
+		return m.Restored
 	}
-	if len(m.Network) > 0 {
-		i -= len(m.Network)
-		copy(dAtA[i:], m.Network)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Network)))
-		i--
-		dAtA[i] = 0x12
+	return 0
+}
+
+func (m *ReconcileReport) GetConflicts() []*ReconcileConflict {
+	if m != nil {
+		return m.Conflicts
 	}
-	if len(m.NodeID) > 0 {
-		i -= len(m.NodeID)
-		copy(dAtA[i:], m.NodeID)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.NodeID)))
-		i--
-		dAtA[i] = 0xa
+	return nil
+}
+
+func (m *ReconcileReport) GetDeleted() uint64 {
+	if m != nil {
+		return m.Deleted
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *DeleteRouteRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+type ReconcileReportsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconcileReportsRequest) Reset()         { *m = ReconcileReportsRequest{} }
+func (m *ReconcileReportsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReconcileReportsRequest) ProtoMessage()    {}
+func (*ReconcileReportsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{41}
+}
+func (m *ReconcileReportsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReconcileReportsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReconcileReportsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *ReconcileReportsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconcileReportsRequest.Merge(m, src)
+}
+func (m *ReconcileReportsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReconcileReportsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconcileReportsRequest.DiscardUnknown(m)
 }
 
-func (m *DeleteRouteRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_ReconcileReportsRequest proto.InternalMessageInfo
+
+type ReconcileReportsResponse struct {
+	Reports              []*ReconcileReport `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
-func (m *DeleteRouteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *ReconcileReportsResponse) Reset()         { *m = ReconcileReportsResponse{} }
+func (m *ReconcileReportsResponse) String() string { return proto.CompactTextString(m) }
+func (*ReconcileReportsResponse) ProtoMessage()    {}
+func (*ReconcileReportsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{42}
+}
+func (m *ReconcileReportsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReconcileReportsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReconcileReportsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(m.Network) > 0 {
-		i -= len(m.Network)
-		copy(dAtA[i:], m.Network)
-		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Network)))
-		i--
-		dAtA[i] = 0xa
+}
+func (m *ReconcileReportsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconcileReportsResponse.Merge(m, src)
+}
+func (m *ReconcileReportsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReconcileReportsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconcileReportsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconcileReportsResponse proto.InternalMessageInfo
+
+func (m *ReconcileReportsResponse) GetReports() []*ReconcileReport {
+	if m != nil {
+		return m.Reports
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *RoutesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+type StepDownRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StepDownRequest) Reset()         { *m = StepDownRequest{} }
+func (m *StepDownRequest) String() string { return proto.CompactTextString(m) }
+func (*StepDownRequest) ProtoMessage()    {}
+func (*StepDownRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{43}
+}
+func (m *StepDownRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StepDownRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StepDownRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *StepDownRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StepDownRequest.Merge(m, src)
+}
+func (m *StepDownRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *StepDownRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StepDownRequest.DiscardUnknown(m)
 }
 
-func (m *RoutesRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_StepDownRequest proto.InternalMessageInfo
+
+type PromoteNodeRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RoutesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *PromoteNodeRequest) Reset()         { *m = PromoteNodeRequest{} }
+func (m *PromoteNodeRequest) String() string { return proto.CompactTextString(m) }
+func (*PromoteNodeRequest) ProtoMessage()    {}
+func (*PromoteNodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{44}
+}
+func (m *PromoteNodeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PromoteNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PromoteNodeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *PromoteNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PromoteNodeRequest.Merge(m, src)
+}
+func (m *PromoteNodeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PromoteNodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PromoteNodeRequest.DiscardUnknown(m)
 }
 
-func (m *RoutesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_PromoteNodeRequest proto.InternalMessageInfo
+
+func (m *PromoteNodeRequest) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *RoutesResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type TakeMasterRequest struct {
+	Term                 uint64   `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	Offset               int64    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	ClusterKey           string   `protobuf:"bytes,3,opt,name=cluster_key,json=clusterKey,proto3" json:"cluster_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RoutesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Routes) > 0 {
-		for iNdEx := len(m.Routes) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Routes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintHeimdall(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+func (m *TakeMasterRequest) Reset()         { *m = TakeMasterRequest{} }
+func (m *TakeMasterRequest) String() string { return proto.CompactTextString(m) }
+func (*TakeMasterRequest) ProtoMessage()    {}
+func (*TakeMasterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{45}
+}
+func (m *TakeMasterRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TakeMasterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TakeMasterRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *TakeMasterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TakeMasterRequest.Merge(m, src)
+}
+func (m *TakeMasterRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *TakeMasterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TakeMasterRequest.DiscardUnknown(m)
 }
 
-func encodeVarintHeimdall(dAtA []byte, offset int, v uint64) int {
-	offset -= sovHeimdall(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+var xxx_messageInfo_TakeMasterRequest proto.InternalMessageInfo
+
+func (m *TakeMasterRequest) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return 0
 }
-func (m *Master) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	l = len(m.GRPCAddress)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	l = len(m.RedisURL)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	l = len(m.GatewayIP)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	if len(m.Peers) > 0 {
-		for _, e := range m.Peers {
-			l = e.Size()
-			n += 1 + l + sovHeimdall(uint64(l))
-		}
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+
+func (m *TakeMasterRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
 	}
-	return n
+	return 0
 }
 
-func (m *JoinRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	l = len(m.ClusterKey)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	l = len(m.GRPCAddress)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	l = len(m.EndpointIP)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	if m.EndpointPort != 0 {
-		n += 1 + sovHeimdall(uint64(m.EndpointPort))
-	}
-	l = len(m.InterfaceName)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *TakeMasterRequest) GetClusterKey() string {
+	if m != nil {
+		return m.ClusterKey
 	}
-	return n
+	return ""
 }
 
-func (m *JoinResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Master != nil {
-		l = m.Master.Size()
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	if m.Node != nil {
-		l = m.Node.Size()
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	if len(m.Peers) > 0 {
-		for _, e := range m.Peers {
-			l = e.Size()
-			n += 1 + l + sovHeimdall(uint64(l))
+type TakeMasterResponse struct {
+	Master               *Master  `protobuf:"bytes,1,opt,name=master,proto3" json:"master,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TakeMasterResponse) Reset()         { *m = TakeMasterResponse{} }
+func (m *TakeMasterResponse) String() string { return proto.CompactTextString(m) }
+func (*TakeMasterResponse) ProtoMessage()    {}
+func (*TakeMasterResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{46}
+}
+func (m *TakeMasterResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TakeMasterResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TakeMasterResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+}
+func (m *TakeMasterResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TakeMasterResponse.Merge(m, src)
+}
+func (m *TakeMasterResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *TakeMasterResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TakeMasterResponse.DiscardUnknown(m)
 }
 
-func (m *ConnectRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+var xxx_messageInfo_TakeMasterResponse proto.InternalMessageInfo
+
+func (m *TakeMasterResponse) GetMaster() *Master {
+	if m != nil {
+		return m.Master
 	}
-	return n
+	return nil
 }
 
-func (m *ConnectResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.KeyPair != nil {
-		l = m.KeyPair.Size()
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	l = len(m.Address)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	if len(m.Peers) > 0 {
-		for _, e := range m.Peers {
-			l = e.Size()
-			n += 1 + l + sovHeimdall(uint64(l))
-		}
-	}
-	if len(m.DNS) > 0 {
-		for _, s := range m.DNS {
-			l = len(s)
-			n += 1 + l + sovHeimdall(uint64(l))
+type RemoveNodeRequest struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// reassign_routes_to is the node that takes over the routes of the
+	// removed node.  If empty the routes are deleted.
+	ReassignRoutesTo     string   `protobuf:"bytes,2,opt,name=reassign_routes_to,json=reassignRoutesTo,proto3" json:"reassign_routes_to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RemoveNodeRequest) Reset()         { *m = RemoveNodeRequest{} }
+func (m *RemoveNodeRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveNodeRequest) ProtoMessage()    {}
+func (*RemoveNodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{47}
+}
+func (m *RemoveNodeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RemoveNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RemoveNodeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+}
+func (m *RemoveNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveNodeRequest.Merge(m, src)
+}
+func (m *RemoveNodeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RemoveNodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveNodeRequest.DiscardUnknown(m)
 }
 
-func (m *AuthorizePeerRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+var xxx_messageInfo_RemoveNodeRequest proto.InternalMessageInfo
+
+func (m *RemoveNodeRequest) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	return n
+	return ""
 }
 
-func (m *DeauthorizePeerRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *RemoveNodeRequest) GetReassignRoutesTo() string {
+	if m != nil {
+		return m.ReassignRoutesTo
 	}
-	return n
+	return ""
 }
 
-func (m *AuthorizedPeersRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+type RestoreNodeRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AuthorizedPeersResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.IDs) > 0 {
-		for _, s := range m.IDs {
-			l = len(s)
-			n += 1 + l + sovHeimdall(uint64(l))
+func (m *RestoreNodeRequest) Reset()         { *m = RestoreNodeRequest{} }
+func (m *RestoreNodeRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreNodeRequest) ProtoMessage()    {}
+func (*RestoreNodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{48}
+}
+func (m *RestoreNodeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RestoreNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RestoreNodeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+}
+func (m *RestoreNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreNodeRequest.Merge(m, src)
+}
+func (m *RestoreNodeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RestoreNodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreNodeRequest.DiscardUnknown(m)
 }
 
-func (m *KeyPair) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.PrivateKey)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	l = len(m.PublicKey)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+var xxx_messageInfo_RestoreNodeRequest proto.InternalMessageInfo
+
+func (m *RestoreNodeRequest) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	return n
+	return ""
 }
 
-func (m *Node) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	l = len(m.Addr)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	if m.KeyPair != nil {
-		l = m.KeyPair.Size()
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	l = len(m.EndpointIP)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	if m.EndpointPort != 0 {
-		n += 1 + sovHeimdall(uint64(m.EndpointPort))
-	}
-	l = len(m.GatewayIP)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Updated)
-	n += 1 + l + sovHeimdall(uint64(l))
-	l = len(m.InterfaceName)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+type DrainNodeRequest struct {
+	ID                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Resume               bool     `protobuf:"varint,2,opt,name=resume,proto3" json:"resume,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NodesRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DrainNodeRequest) Reset()         { *m = DrainNodeRequest{} }
+func (m *DrainNodeRequest) String() string { return proto.CompactTextString(m) }
+func (*DrainNodeRequest) ProtoMessage()    {}
+func (*DrainNodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{49}
+}
+func (m *DrainNodeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DrainNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DrainNodeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+}
+func (m *DrainNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainNodeRequest.Merge(m, src)
+}
+func (m *DrainNodeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DrainNodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainNodeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainNodeRequest proto.InternalMessageInfo
+
+func (m *DrainNodeRequest) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	return n
+	return ""
 }
 
-func (m *NodesResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DrainNodeRequest) GetResume() bool {
+	if m != nil {
+		return m.Resume
 	}
-	var l int
-	_ = l
-	if len(m.Nodes) > 0 {
-		for _, e := range m.Nodes {
-			l = e.Size()
-			n += 1 + l + sovHeimdall(uint64(l))
+	return false
+}
+
+type WatchRequest struct {
+	// revision to resume after; zero starts at the current revision
+	Revision             uint64   `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
+func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()    {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{50}
+}
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+}
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
+}
+func (m *WatchRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
 }
 
-func (m *Peer) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
-	}
-	if m.KeyPair != nil {
-		l = m.KeyPair.Size()
-		n += 1 + l + sovHeimdall(uint64(l))
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+
+func (m *WatchRequest) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
 	}
-	if len(m.AllowedIPs) > 0 {
-		for _, s := range m.AllowedIPs {
-			l = len(s)
-			n += 1 + l + sovHeimdall(uint64(l))
+	return 0
+}
+
+type WatchEvent struct {
+	Revision uint64          `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Type     WatchEvent_Type `protobuf:"varint,2,opt,name=type,proto3,enum=dev.ehazlett.heimdall.api.v1.WatchEvent_Type" json:"type,omitempty"`
+	// id is the node, peer or policy id or the route network
+	ID                   string    `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Created              time.Time `protobuf:"bytes,4,opt,name=created,proto3,stdtime" json:"created"`
+	Node                 *Node     `protobuf:"bytes,5,opt,name=node,proto3" json:"node,omitempty"`
+	Peer                 *Peer     `protobuf:"bytes,6,opt,name=peer,proto3" json:"peer,omitempty"`
+	Route                *Route    `protobuf:"bytes,7,opt,name=route,proto3" json:"route,omitempty"`
+	Policy               *Policy   `protobuf:"bytes,8,opt,name=policy,proto3" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *WatchEvent) Reset()         { *m = WatchEvent{} }
+func (m *WatchEvent) String() string { return proto.CompactTextString(m) }
+func (*WatchEvent) ProtoMessage()    {}
+func (*WatchEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{51}
+}
+func (m *WatchEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	l = len(m.Endpoint)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
+}
+func (m *WatchEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchEvent.Merge(m, src)
+}
+func (m *WatchEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchEvent proto.InternalMessageInfo
+
+func (m *WatchEvent) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
 	}
-	l = len(m.PeerIP)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
+	return 0
+}
+
+func (m *WatchEvent) GetType() WatchEvent_Type {
+	if m != nil {
+		return m.Type
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
+	return WatchEvent_UNKNOWN
+}
+
+func (m *WatchEvent) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return ""
+}
+
+func (m *WatchEvent) GetCreated() time.Time {
+	if m != nil {
+		return m.Created
 	}
-	return n
+	return time.Time{}
 }
 
-func (m *PeersRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *WatchEvent) GetNode() *Node {
+	if m != nil {
+		return m.Node
 	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+
+func (m *WatchEvent) GetPeer() *Peer {
+	if m != nil {
+		return m.Peer
 	}
-	return n
+	return nil
 }
 
-func (m *PeersResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *WatchEvent) GetRoute() *Route {
+	if m != nil {
+		return m.Route
 	}
-	var l int
-	_ = l
-	if len(m.Peers) > 0 {
-		for _, e := range m.Peers {
-			l = e.Size()
-			n += 1 + l + sovHeimdall(uint64(l))
+	return nil
+}
+
+func (m *WatchEvent) GetPolicy() *Policy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+type SyncConfigRequest struct {
+	// connect identifies the peer and must be sent first
+	Connect *ConnectRequest `protobuf:"bytes,1,opt,name=connect,proto3" json:"connect,omitempty"`
+	// ack acknowledges a desired config version
+	Ack                  *ConfigAck `protobuf:"bytes,2,opt,name=ack,proto3" json:"ack,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SyncConfigRequest) Reset()         { *m = SyncConfigRequest{} }
+func (m *SyncConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*SyncConfigRequest) ProtoMessage()    {}
+func (*SyncConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{52}
+}
+func (m *SyncConfigRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SyncConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SyncConfigRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+}
+func (m *SyncConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncConfigRequest.Merge(m, src)
+}
+func (m *SyncConfigRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SyncConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncConfigRequest proto.InternalMessageInfo
+
+func (m *SyncConfigRequest) GetConnect() *ConnectRequest {
+	if m != nil {
+		return m.Connect
 	}
-	return n
+	return nil
 }
 
-func (m *Route) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SyncConfigRequest) GetAck() *ConfigAck {
+	if m != nil {
+		return m.Ack
 	}
-	var l int
-	_ = l
-	l = len(m.NodeID)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
+	return nil
+}
+
+type ConfigAck struct {
+	Version uint64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// error is set if the config could not be applied
+	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfigAck) Reset()         { *m = ConfigAck{} }
+func (m *ConfigAck) String() string { return proto.CompactTextString(m) }
+func (*ConfigAck) ProtoMessage()    {}
+func (*ConfigAck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{53}
+}
+func (m *ConfigAck) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConfigAck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConfigAck.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	l = len(m.Network)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
+}
+func (m *ConfigAck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigAck.Merge(m, src)
+}
+func (m *ConfigAck) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConfigAck) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigAck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigAck proto.InternalMessageInfo
+
+func (m *ConfigAck) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return 0
+}
+
+func (m *ConfigAck) GetError() string {
+	if m != nil {
+		return m.Error
 	}
-	return n
+	return ""
 }
 
-func (m *CreateRouteRequest) Size() (n int) {
-	if m == nil {
-		return 0
+type DesiredConfig struct {
+	Version              uint64   `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Peers                []*Peer  `protobuf:"bytes,3,rep,name=peers,proto3" json:"peers,omitempty"`
+	DNS                  []string `protobuf:"bytes,4,rep,name=dns,proto3" json:"dns,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DesiredConfig) Reset()         { *m = DesiredConfig{} }
+func (m *DesiredConfig) String() string { return proto.CompactTextString(m) }
+func (*DesiredConfig) ProtoMessage()    {}
+func (*DesiredConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{54}
+}
+func (m *DesiredConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DesiredConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DesiredConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	l = len(m.NodeID)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
+}
+func (m *DesiredConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DesiredConfig.Merge(m, src)
+}
+func (m *DesiredConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *DesiredConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_DesiredConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DesiredConfig proto.InternalMessageInfo
+
+func (m *DesiredConfig) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
 	}
-	l = len(m.Network)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
+	return 0
+}
+
+func (m *DesiredConfig) GetAddress() string {
+	if m != nil {
+		return m.Address
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return ""
+}
+
+func (m *DesiredConfig) GetPeers() []*Peer {
+	if m != nil {
+		return m.Peers
 	}
-	return n
+	return nil
 }
 
-func (m *DeleteRouteRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DesiredConfig) GetDNS() []string {
+	if m != nil {
+		return m.DNS
 	}
-	var l int
-	_ = l
-	l = len(m.Network)
-	if l > 0 {
-		n += 1 + l + sovHeimdall(uint64(l))
+	return nil
+}
+
+type PeerConfigStatus struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// node_id is the node delivering the config to the peer
+	NodeID         string    `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	DesiredVersion uint64    `protobuf:"varint,3,opt,name=desired_version,json=desiredVersion,proto3" json:"desired_version,omitempty"`
+	DesiredHash    string    `protobuf:"bytes,4,opt,name=desired_hash,json=desiredHash,proto3" json:"desired_hash,omitempty"`
+	Desired        time.Time `protobuf:"bytes,5,opt,name=desired,proto3,stdtime" json:"desired"`
+	AppliedVersion uint64    `protobuf:"varint,6,opt,name=applied_version,json=appliedVersion,proto3" json:"applied_version,omitempty"`
+	Applied        time.Time `protobuf:"bytes,7,opt,name=applied,proto3,stdtime" json:"applied"`
+	Error          string    `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	Connected      bool      `protobuf:"varint,9,opt,name=connected,proto3" json:"connected,omitempty"`
+	// disconnected is when the peer closed its config sync
+	Disconnected         time.Time `protobuf:"bytes,10,opt,name=disconnected,proto3,stdtime" json:"disconnected"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *PeerConfigStatus) Reset()         { *m = PeerConfigStatus{} }
+func (m *PeerConfigStatus) String() string { return proto.CompactTextString(m) }
+func (*PeerConfigStatus) ProtoMessage()    {}
+func (*PeerConfigStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{55}
+}
+func (m *PeerConfigStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerConfigStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerConfigStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+}
+func (m *PeerConfigStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerConfigStatus.Merge(m, src)
+}
+func (m *PeerConfigStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerConfigStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerConfigStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerConfigStatus proto.InternalMessageInfo
+
+func (m *PeerConfigStatus) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *PeerConfigStatus) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *PeerConfigStatus) GetDesiredVersion() uint64 {
+	if m != nil {
+		return m.DesiredVersion
+	}
+	return 0
+}
+
+func (m *PeerConfigStatus) GetDesiredHash() string {
+	if m != nil {
+		return m.DesiredHash
+	}
+	return ""
+}
+
+func (m *PeerConfigStatus) GetDesired() time.Time {
+	if m != nil {
+		return m.Desired
+	}
+	return time.Time{}
+}
+
+func (m *PeerConfigStatus) GetAppliedVersion() uint64 {
+	if m != nil {
+		return m.AppliedVersion
+	}
+	return 0
+}
+
+func (m *PeerConfigStatus) GetApplied() time.Time {
+	if m != nil {
+		return m.Applied
+	}
+	return time.Time{}
+}
+
+func (m *PeerConfigStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *PeerConfigStatus) GetConnected() bool {
+	if m != nil {
+		return m.Connected
+	}
+	return false
+}
+
+func (m *PeerConfigStatus) GetDisconnected() time.Time {
+	if m != nil {
+		return m.Disconnected
+	}
+	return time.Time{}
+}
+
+type PeerConfigStatusesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerConfigStatusesRequest) Reset()         { *m = PeerConfigStatusesRequest{} }
+func (m *PeerConfigStatusesRequest) String() string { return proto.CompactTextString(m) }
+func (*PeerConfigStatusesRequest) ProtoMessage()    {}
+func (*PeerConfigStatusesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{56}
+}
+func (m *PeerConfigStatusesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerConfigStatusesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerConfigStatusesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PeerConfigStatusesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerConfigStatusesRequest.Merge(m, src)
+}
+func (m *PeerConfigStatusesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerConfigStatusesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerConfigStatusesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerConfigStatusesRequest proto.InternalMessageInfo
+
+type PeerConfigStatusesResponse struct {
+	Statuses             []*PeerConfigStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *PeerConfigStatusesResponse) Reset()         { *m = PeerConfigStatusesResponse{} }
+func (m *PeerConfigStatusesResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerConfigStatusesResponse) ProtoMessage()    {}
+func (*PeerConfigStatusesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_601158708112ddb8, []int{57}
+}
+func (m *PeerConfigStatusesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerConfigStatusesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerConfigStatusesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PeerConfigStatusesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerConfigStatusesResponse.Merge(m, src)
+}
+func (m *PeerConfigStatusesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerConfigStatusesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerConfigStatusesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerConfigStatusesResponse proto.InternalMessageInfo
+
+func (m *PeerConfigStatusesResponse) GetStatuses() []*PeerConfigStatus {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("dev.ehazlett.heimdall.api.v1.Policy_Action", Policy_Action_name, Policy_Action_value)
+	proto.RegisterEnum("dev.ehazlett.heimdall.api.v1.WatchEvent_Type", WatchEvent_Type_name, WatchEvent_Type_value)
+	proto.RegisterType((*Master)(nil), "dev.ehazlett.heimdall.api.v1.Master")
+	proto.RegisterType((*JoinRequest)(nil), "dev.ehazlett.heimdall.api.v1.JoinRequest")
+	proto.RegisterType((*JoinResponse)(nil), "dev.ehazlett.heimdall.api.v1.JoinResponse")
+	proto.RegisterType((*ConnectRequest)(nil), "dev.ehazlett.heimdall.api.v1.ConnectRequest")
+	proto.RegisterType((*ConnectResponse)(nil), "dev.ehazlett.heimdall.api.v1.ConnectResponse")
+	proto.RegisterType((*AuthorizePeerRequest)(nil), "dev.ehazlett.heimdall.api.v1.AuthorizePeerRequest")
+	proto.RegisterType((*DeauthorizePeerRequest)(nil), "dev.ehazlett.heimdall.api.v1.DeauthorizePeerRequest")
+	proto.RegisterType((*AuthorizedPeersRequest)(nil), "dev.ehazlett.heimdall.api.v1.AuthorizedPeersRequest")
+	proto.RegisterType((*AuthorizedPeersResponse)(nil), "dev.ehazlett.heimdall.api.v1.AuthorizedPeersResponse")
+	proto.RegisterType((*PeerNetworkUsageRequest)(nil), "dev.ehazlett.heimdall.api.v1.PeerNetworkUsageRequest")
+	proto.RegisterType((*NetworkUsage)(nil), "dev.ehazlett.heimdall.api.v1.NetworkUsage")
+	proto.RegisterType((*PeerNetworkUsageResponse)(nil), "dev.ehazlett.heimdall.api.v1.PeerNetworkUsageResponse")
+	proto.RegisterType((*Node)(nil), "dev.ehazlett.heimdall.api.v1.Node")
+	proto.RegisterType((*NodesRequest)(nil), "dev.ehazlett.heimdall.api.v1.NodesRequest")
+	proto.RegisterType((*NodesResponse)(nil), "dev.ehazlett.heimdall.api.v1.NodesResponse")
+	proto.RegisterType((*Peer)(nil), "dev.ehazlett.heimdall.api.v1.Peer")
+	proto.RegisterType((*PeerTags)(nil), "dev.ehazlett.heimdall.api.v1.PeerTags")
+	proto.RegisterType((*PeersRequest)(nil), "dev.ehazlett.heimdall.api.v1.PeersRequest")
+	proto.RegisterType((*PeersResponse)(nil), "dev.ehazlett.heimdall.api.v1.PeersResponse")
+	proto.RegisterType((*CheckPeerIPsRequest)(nil), "dev.ehazlett.heimdall.api.v1.CheckPeerIPsRequest")
+	proto.RegisterType((*PeerIPConflict)(nil), "dev.ehazlett.heimdall.api.v1.PeerIPConflict")
+	proto.RegisterType((*CheckPeerIPsResponse)(nil), "dev.ehazlett.heimdall.api.v1.CheckPeerIPsResponse")
+	proto.RegisterType((*Route)(nil), "dev.ehazlett.heimdall.api.v1.Route")
+	proto.RegisterType((*RouteNode)(nil), "dev.ehazlett.heimdall.api.v1.RouteNode")
+	proto.RegisterType((*CreateRouteRequest)(nil), "dev.ehazlett.heimdall.api.v1.CreateRouteRequest")
+	proto.RegisterType((*ApproveRouteRequest)(nil), "dev.ehazlett.heimdall.api.v1.ApproveRouteRequest")
+	proto.RegisterType((*PeerRoutes)(nil), "dev.ehazlett.heimdall.api.v1.PeerRoutes")
+	proto.RegisterType((*DeleteRouteRequest)(nil), "dev.ehazlett.heimdall.api.v1.DeleteRouteRequest")
+	proto.RegisterType((*RoutesRequest)(nil), "dev.ehazlett.heimdall.api.v1.RoutesRequest")
+	proto.RegisterType((*RoutesResponse)(nil), "dev.ehazlett.heimdall.api.v1.RoutesResponse")
+	proto.RegisterType((*Policy)(nil), "dev.ehazlett.heimdall.api.v1.Policy")
+	proto.RegisterType((*CreatePolicyRequest)(nil), "dev.ehazlett.heimdall.api.v1.CreatePolicyRequest")
+	proto.RegisterType((*DeletePolicyRequest)(nil), "dev.ehazlett.heimdall.api.v1.DeletePolicyRequest")
+	proto.RegisterType((*PoliciesRequest)(nil), "dev.ehazlett.heimdall.api.v1.PoliciesRequest")
+	proto.RegisterType((*PoliciesResponse)(nil), "dev.ehazlett.heimdall.api.v1.PoliciesResponse")
+	proto.RegisterType((*RequestVoteRequest)(nil), "dev.ehazlett.heimdall.api.v1.RequestVoteRequest")
+	proto.RegisterType((*RequestVoteResponse)(nil), "dev.ehazlett.heimdall.api.v1.RequestVoteResponse")
+	proto.RegisterType((*MasterRequest)(nil), "dev.ehazlett.heimdall.api.v1.MasterRequest")
+	proto.RegisterType((*MasterResponse)(nil), "dev.ehazlett.heimdall.api.v1.MasterResponse")
+	proto.RegisterType((*ReconcileConflict)(nil), "dev.ehazlett.heimdall.api.v1.ReconcileConflict")
+	proto.RegisterType((*ReconcileReport)(nil), "dev.ehazlett.heimdall.api.v1.ReconcileReport")
+	proto.RegisterType((*ReconcileReportsRequest)(nil), "dev.ehazlett.heimdall.api.v1.ReconcileReportsRequest")
+	proto.RegisterType((*ReconcileReportsResponse)(nil), "dev.ehazlett.heimdall.api.v1.ReconcileReportsResponse")
+	proto.RegisterType((*StepDownRequest)(nil), "dev.ehazlett.heimdall.api.v1.StepDownRequest")
+	proto.RegisterType((*PromoteNodeRequest)(nil), "dev.ehazlett.heimdall.api.v1.PromoteNodeRequest")
+	proto.RegisterType((*TakeMasterRequest)(nil), "dev.ehazlett.heimdall.api.v1.TakeMasterRequest")
+	proto.RegisterType((*TakeMasterResponse)(nil), "dev.ehazlett.heimdall.api.v1.TakeMasterResponse")
+	proto.RegisterType((*RemoveNodeRequest)(nil), "dev.ehazlett.heimdall.api.v1.RemoveNodeRequest")
+	proto.RegisterType((*RestoreNodeRequest)(nil), "dev.ehazlett.heimdall.api.v1.RestoreNodeRequest")
+	proto.RegisterType((*DrainNodeRequest)(nil), "dev.ehazlett.heimdall.api.v1.DrainNodeRequest")
+	proto.RegisterType((*WatchRequest)(nil), "dev.ehazlett.heimdall.api.v1.WatchRequest")
+	proto.RegisterType((*WatchEvent)(nil), "dev.ehazlett.heimdall.api.v1.WatchEvent")
+	proto.RegisterType((*SyncConfigRequest)(nil), "dev.ehazlett.heimdall.api.v1.SyncConfigRequest")
+	proto.RegisterType((*ConfigAck)(nil), "dev.ehazlett.heimdall.api.v1.ConfigAck")
+	proto.RegisterType((*DesiredConfig)(nil), "dev.ehazlett.heimdall.api.v1.DesiredConfig")
+	proto.RegisterType((*PeerConfigStatus)(nil), "dev.ehazlett.heimdall.api.v1.PeerConfigStatus")
+	proto.RegisterType((*PeerConfigStatusesRequest)(nil), "dev.ehazlett.heimdall.api.v1.PeerConfigStatusesRequest")
+	proto.RegisterType((*PeerConfigStatusesResponse)(nil), "dev.ehazlett.heimdall.api.v1.PeerConfigStatusesResponse")
+}
+
+func init() {
+	proto.RegisterFile("github.com/ehazlett/heimdall/api/v1/heimdall.proto", fileDescriptor_601158708112ddb8)
+}
+
+var fileDescriptor_601158708112ddb8 = []byte{
+	// 3238 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5a, 0x5f, 0x6f, 0x1b, 0xc7,
+	0xb5, 0xcf, 0x92, 0x4b, 0x72, 0x79, 0xf8, 0x47, 0xab, 0xb1, 0xa3, 0xd0, 0x4c, 0x62, 0xf9, 0x6e,
+	0xee, 0x4d, 0x1c, 0xff, 0x91, 0x6c, 0x25, 0xd1, 0x4d, 0x90, 0xdc, 0x20, 0xb2, 0x96, 0xb1, 0xe9,
+	0xc8, 0x92, 0xee, 0x88, 0x92, 0xe1, 0x14, 0x01, 0xb3, 0xe2, 0x8e, 0xc8, 0x85, 0xa8, 0xdd, 0xed,
+	0xee, 0x52, 0x8e, 0x0c, 0x34, 0x40, 0x51, 0xa0, 0x79, 0xed, 0x53, 0xdb, 0x7c, 0x81, 0xbe, 0xf4,
+	0x23, 0x14, 0x28, 0xd0, 0x3e, 0xe5, 0x31, 0x9f, 0x40, 0x2d, 0xf4, 0x09, 0x8a, 0xf6, 0x0b, 0x14,
+	0xf3, 0x67, 0xff, 0x90, 0x14, 0xb9, 0x64, 0x90, 0xf4, 0x8d, 0xe7, 0xcc, 0x9c, 0x99, 0x33, 0x67,
+	0xce, 0xbf, 0xf9, 0x2d, 0x61, 0xad, 0x6b, 0x05, 0xbd, 0xc1, 0xe1, 0x4a, 0xc7, 0x39, 0x59, 0x25,
+	0x3d, 0xe3, 0x45, 0x9f, 0x04, 0xc1, 0x6a, 0x8f, 0x58, 0x27, 0xa6, 0xd1, 0xef, 0xaf, 0x1a, 0xae,
+	0xb5, 0x7a, 0x7a, 0x3f, 0xa2, 0x57, 0x5c, 0xcf, 0x09, 0x1c, 0xf4, 0x9a, 0x49, 0x4e, 0x57, 0xc2,
+	0xc9, 0x2b, 0xd1, 0xa0, 0xe1, 0x5a, 0x2b, 0xa7, 0xf7, 0xeb, 0x57, 0xbb, 0x4e, 0xd7, 0x61, 0x13,
+	0x57, 0xe9, 0x2f, 0x2e, 0x53, 0x7f, 0xb5, 0xeb, 0x38, 0xdd, 0x3e, 0x59, 0x65, 0xd4, 0xe1, 0xe0,
+	0x68, 0x95, 0x9c, 0xb8, 0xc1, 0x99, 0x18, 0x5c, 0x1e, 0x1d, 0x0c, 0xac, 0x13, 0xe2, 0x07, 0xc6,
+	0x89, 0xcb, 0x27, 0x68, 0xbf, 0xcb, 0x40, 0xfe, 0x89, 0xe1, 0x07, 0xc4, 0x43, 0x4b, 0x90, 0xb1,
+	0xcc, 0x9a, 0x74, 0x43, 0xba, 0x59, 0x7c, 0x90, 0xbf, 0x38, 0x5f, 0xce, 0x34, 0x75, 0x9c, 0xb1,
+	0x4c, 0xb4, 0x06, 0xe5, 0xae, 0xe7, 0x76, 0xda, 0x86, 0x69, 0x7a, 0xc4, 0xf7, 0x6b, 0x19, 0x36,
+	0x63, 0xe1, 0xe2, 0x7c, 0xb9, 0xf4, 0x10, 0xef, 0x6e, 0x6e, 0x70, 0x36, 0x2e, 0xd1, 0x49, 0x82,
+	0x40, 0x6f, 0x43, 0xd1, 0x23, 0xa6, 0xe5, 0xb7, 0x07, 0x5e, 0xbf, 0x96, 0x65, 0x02, 0xe5, 0x8b,
+	0xf3, 0x65, 0x05, 0x53, 0xe6, 0x3e, 0xde, 0xc2, 0x0a, 0x1b, 0xde, 0xf7, 0xfa, 0xe8, 0x0e, 0x40,
+	0xd7, 0x08, 0xc8, 0x73, 0xe3, 0xac, 0x6d, 0xb9, 0x35, 0x99, 0xcd, 0xad, 0x5c, 0x9c, 0x2f, 0x17,
+	0x1f, 0x72, 0x6e, 0x73, 0x17, 0x17, 0xc5, 0x84, 0xa6, 0x8b, 0xde, 0x87, 0x9c, 0x4b, 0x88, 0xe7,
+	0xd7, 0x72, 0x37, 0xb2, 0x37, 0x4b, 0x6b, 0xda, 0xca, 0x34, 0x8b, 0xad, 0xec, 0x12, 0xe2, 0x61,
+	0x2e, 0x80, 0x10, 0xc8, 0x01, 0xf1, 0x4e, 0x6a, 0xf9, 0x1b, 0xd2, 0x4d, 0x19, 0xb3, 0xdf, 0xa8,
+	0x0e, 0x8a, 0x47, 0x4e, 0x2d, 0xdf, 0x72, 0xec, 0x5a, 0x81, 0xf1, 0x23, 0x5a, 0xfb, 0x36, 0x0b,
+	0xa5, 0xc7, 0x8e, 0x65, 0x63, 0xf2, 0xf3, 0x01, 0xf1, 0x83, 0x89, 0xe6, 0x59, 0x86, 0x52, 0xa7,
+	0x3f, 0xa0, 0x16, 0x6c, 0x1f, 0x93, 0x33, 0x6e, 0x1d, 0x0c, 0x82, 0xf5, 0x19, 0x39, 0x1b, 0xb3,
+	0x5f, 0x76, 0x06, 0xfb, 0xad, 0x42, 0x89, 0xd8, 0xa6, 0xeb, 0x58, 0x76, 0x10, 0x5b, 0xa5, 0x7a,
+	0x71, 0xbe, 0x0c, 0x0d, 0xc1, 0x6e, 0xee, 0x62, 0x08, 0xa7, 0x34, 0x5d, 0xf4, 0x06, 0x54, 0x22,
+	0x01, 0xd7, 0xf1, 0x82, 0x5a, 0x8e, 0x1d, 0xa7, 0x1c, 0x32, 0x77, 0x1d, 0x2f, 0x40, 0xff, 0x03,
+	0x55, 0xcb, 0x0e, 0x88, 0x77, 0x64, 0x74, 0x48, 0xdb, 0x36, 0x4e, 0x08, 0x33, 0x46, 0x11, 0x57,
+	0x22, 0xee, 0xb6, 0x71, 0x42, 0xa8, 0xa5, 0xd8, 0x60, 0x81, 0x0d, 0xb2, 0xdf, 0xe8, 0x75, 0x00,
+	0x77, 0x70, 0xd8, 0xb7, 0x3a, 0xec, 0x90, 0x0a, 0x1b, 0x29, 0x72, 0x0e, 0x3d, 0xe3, 0x4d, 0x50,
+	0x6d, 0xc7, 0x24, 0x6d, 0x7f, 0x70, 0x68, 0x93, 0xa0, 0xed, 0x5b, 0x2f, 0x48, 0xad, 0x78, 0x43,
+	0xba, 0x59, 0xc1, 0x55, 0xca, 0xdf, 0x63, 0xec, 0x3d, 0xeb, 0x05, 0x41, 0x9b, 0x70, 0x65, 0x74,
+	0x66, 0xfb, 0x74, 0xbd, 0x06, 0x74, 0xf2, 0x83, 0xab, 0x17, 0xe7, 0xcb, 0xea, 0xf6, 0x90, 0xc0,
+	0xc1, 0x3a, 0x56, 0xed, 0x11, 0x8e, 0xf6, 0x67, 0x09, 0xca, 0xfc, 0x6e, 0x7c, 0xd7, 0xb1, 0x7d,
+	0x82, 0x3e, 0x82, 0xfc, 0x09, 0xf3, 0x62, 0x76, 0x41, 0xa5, 0xb5, 0xff, 0x9e, 0xee, 0x17, 0xdc,
+	0xe3, 0xb1, 0x90, 0x41, 0xeb, 0x20, 0xd3, 0x2d, 0xd8, 0xdd, 0xa5, 0xfa, 0x14, 0x55, 0x0f, 0xb3,
+	0xf9, 0xb1, 0x33, 0x66, 0xe7, 0x74, 0x46, 0xed, 0x57, 0x19, 0xa8, 0x6e, 0x3a, 0xb6, 0x4d, 0x3a,
+	0x41, 0x9a, 0x7f, 0x85, 0xb7, 0x91, 0x99, 0x78, 0x1b, 0xd9, 0xd1, 0xdb, 0x78, 0x15, 0x8a, 0xe4,
+	0x2b, 0x2b, 0x68, 0xb3, 0x43, 0x31, 0xdf, 0xc1, 0x0a, 0x65, 0x50, 0xd5, 0xd1, 0x6d, 0x58, 0x34,
+	0xcc, 0x53, 0xe2, 0x05, 0x96, 0x4f, 0xcc, 0xb6, 0xe7, 0x0c, 0x02, 0xc2, 0xa3, 0xa9, 0x88, 0xd5,
+	0x78, 0x00, 0x33, 0x3e, 0xdd, 0xfc, 0x84, 0xf8, 0x3d, 0xe6, 0x27, 0x0a, 0x66, 0xbf, 0xa9, 0xc3,
+	0xf7, 0x2d, 0x3f, 0x20, 0x36, 0x77, 0x34, 0x1e, 0x37, 0xc0, 0x59, 0xcc, 0xcd, 0xde, 0x82, 0x85,
+	0xbe, 0xd3, 0x31, 0xfa, 0xa1, 0xc7, 0x13, 0xbf, 0xa6, 0xb0, 0xf5, 0xab, 0x8c, 0xbd, 0x11, 0x72,
+	0xb5, 0x5f, 0x4b, 0xb0, 0x10, 0x59, 0x41, 0xdc, 0x64, 0x0d, 0x0a, 0x43, 0x89, 0x06, 0x87, 0xe4,
+	0x0f, 0xb7, 0x36, 0xba, 0x06, 0x59, 0xd3, 0xf6, 0x6b, 0x32, 0x55, 0xe2, 0x41, 0xe1, 0xe2, 0x7c,
+	0x39, 0xab, 0x6f, 0xef, 0x61, 0xca, 0x7b, 0x2c, 0x2b, 0x92, 0x9a, 0xd1, 0xbe, 0x80, 0xab, 0x1b,
+	0x83, 0xa0, 0xe7, 0x78, 0xd6, 0x0b, 0xc2, 0x04, 0x53, 0xee, 0xe4, 0x1a, 0x64, 0x2d, 0x97, 0x2a,
+	0x18, 0x2d, 0xd8, 0xdc, 0xf5, 0x31, 0xe5, 0xb1, 0x34, 0x63, 0x74, 0xb9, 0x92, 0x45, 0xcc, 0x7e,
+	0x6b, 0xf7, 0x60, 0x49, 0x27, 0xc6, 0x1c, 0x1b, 0x68, 0x35, 0x58, 0x8a, 0x14, 0x32, 0xa9, 0x80,
+	0x2f, 0x24, 0xb4, 0x77, 0xe1, 0x95, 0xb1, 0x11, 0x61, 0x3a, 0xaa, 0x95, 0xe9, 0xd7, 0xa4, 0x84,
+	0x56, 0x3a, 0xd5, 0xca, 0xf4, 0xb5, 0x6b, 0xf0, 0x0a, 0x9d, 0xbb, 0x4d, 0x82, 0xe7, 0x8e, 0x77,
+	0xbc, 0xef, 0x1b, 0x5d, 0x12, 0x2e, 0xf8, 0x5b, 0x09, 0xca, 0x49, 0x3e, 0xbd, 0x01, 0x9b, 0xd3,
+	0x5c, 0x31, 0x1c, 0x92, 0xe8, 0x2a, 0xe4, 0x02, 0x27, 0x30, 0xfa, 0xec, 0x66, 0x64, 0xcc, 0x09,
+	0xf4, 0x1a, 0x14, 0x8d, 0x3e, 0xbd, 0xd9, 0x80, 0x98, 0xcc, 0x17, 0x65, 0x1c, 0x33, 0x68, 0x8a,
+	0x25, 0x5f, 0x75, 0xfa, 0x03, 0x93, 0x98, 0xcc, 0x15, 0x65, 0x1c, 0xd1, 0x4c, 0xf2, 0xd4, 0xb0,
+	0xfa, 0xc6, 0x61, 0x9f, 0x88, 0x84, 0x15, 0x33, 0xb4, 0x43, 0xa8, 0x8d, 0xeb, 0x2c, 0x8e, 0xfa,
+	0x29, 0x28, 0x42, 0x29, 0x7e, 0xde, 0xd2, 0xda, 0xad, 0x94, 0xa8, 0x4d, 0xae, 0x12, 0xc9, 0x6a,
+	0xdf, 0xcb, 0x20, 0xb3, 0xa8, 0x98, 0x12, 0x7d, 0xd4, 0xff, 0xc2, 0xe8, 0xa3, 0xbf, 0x7f, 0xa2,
+	0xe4, 0x3c, 0x5c, 0x07, 0xf3, 0x29, 0x75, 0xf0, 0x63, 0x28, 0x0c, 0x5c, 0x93, 0x99, 0xbc, 0xc0,
+	0xb2, 0x56, 0x7d, 0x85, 0x97, 0xfa, 0x95, 0xb0, 0xd4, 0xaf, 0xb4, 0xc2, 0x52, 0xff, 0x40, 0xf9,
+	0xee, 0x7c, 0xf9, 0xa5, 0xdf, 0xfc, 0x6d, 0x59, 0xc2, 0xa1, 0xd0, 0x25, 0xa5, 0x40, 0x99, 0x56,
+	0x0a, 0x8a, 0x13, 0x93, 0x0f, 0x8c, 0x26, 0x9f, 0x3a, 0x28, 0xa6, 0x67, 0x58, 0xb6, 0x65, 0x77,
+	0x6b, 0x25, 0x96, 0x36, 0x22, 0x9a, 0xba, 0x56, 0x8f, 0x18, 0xfd, 0xa0, 0x77, 0x56, 0x2b, 0xb3,
+	0xa1, 0x90, 0xa4, 0x26, 0xe2, 0x3f, 0xdb, 0x1e, 0x31, 0x7c, 0xc7, 0xae, 0x55, 0xd8, 0xba, 0x65,
+	0xce, 0xc4, 0x8c, 0x87, 0x3e, 0x83, 0x6a, 0xdf, 0xf0, 0x83, 0x76, 0xcf, 0xb0, 0x4d, 0xbf, 0x67,
+	0x1c, 0x93, 0x5a, 0x75, 0x8e, 0xb3, 0x57, 0xa8, 0xec, 0xa3, 0x50, 0x14, 0xbd, 0x03, 0x95, 0xd8,
+	0xde, 0xb4, 0x04, 0x2d, 0x24, 0xea, 0x72, 0x68, 0xf2, 0x83, 0x75, 0x5c, 0x8a, 0x8c, 0x7e, 0xb0,
+	0x3e, 0x9c, 0x59, 0x55, 0x7e, 0xba, 0x30, 0xb3, 0x3e, 0x96, 0x95, 0xac, 0x2a, 0x6b, 0x55, 0x28,
+	0x53, 0x2a, 0x0a, 0xd8, 0x6f, 0x24, 0xa8, 0x08, 0x86, 0x70, 0xde, 0xf7, 0x21, 0x47, 0xe5, 0x43,
+	0xcf, 0x9d, 0xa5, 0xde, 0x70, 0x81, 0x44, 0x99, 0xcb, 0xcc, 0x5f, 0xe6, 0xb4, 0x7f, 0x66, 0x41,
+	0xa6, 0x11, 0x35, 0xd1, 0xd9, 0x57, 0xa1, 0x44, 0x03, 0xf7, 0x39, 0x31, 0xdb, 0x96, 0x2b, 0x52,
+	0x18, 0x77, 0xec, 0x0d, 0xce, 0xa6, 0x59, 0x0e, 0xc4, 0x94, 0xa6, 0xeb, 0xb3, 0xe0, 0x16, 0x3e,
+	0x1c, 0xd5, 0x19, 0x41, 0xa3, 0x37, 0xa0, 0xe0, 0x12, 0xe2, 0x51, 0x67, 0xce, 0xb1, 0x9d, 0xe0,
+	0xe2, 0x7c, 0x39, 0x4f, 0xf7, 0x6f, 0xee, 0xe2, 0x3c, 0x1d, 0x6a, 0xba, 0x91, 0x7f, 0xe5, 0x27,
+	0xfa, 0x57, 0x61, 0xd4, 0xbf, 0x6e, 0x01, 0x88, 0x75, 0xe9, 0xa5, 0x29, 0x71, 0x6f, 0xc9, 0x97,
+	0x3e, 0x58, 0xc7, 0x0a, 0x5f, 0xfc, 0x60, 0x3d, 0x4a, 0xc6, 0xc5, 0x38, 0x19, 0x0f, 0x5f, 0x21,
+	0x8c, 0x14, 0xc7, 0xbb, 0x80, 0x3c, 0x72, 0xd4, 0x27, 0x5f, 0x59, 0xa7, 0xa4, 0x1d, 0x1d, 0xad,
+	0xc4, 0x66, 0x2d, 0x46, 0x23, 0x61, 0x98, 0xd3, 0x28, 0xf2, 0xc8, 0x89, 0x13, 0x90, 0xa8, 0xb9,
+	0x2b, 0xf3, 0x28, 0xe2, 0xdc, 0xb0, 0x9b, 0x5b, 0x82, 0xbc, 0x69, 0x79, 0xa4, 0x13, 0x30, 0xaf,
+	0x56, 0xb0, 0xa0, 0xa2, 0xea, 0x5a, 0x9d, 0x5c, 0x5d, 0x17, 0x66, 0xa9, 0xae, 0xea, 0x65, 0xd5,
+	0xf5, 0xb1, 0xac, 0x64, 0xd4, 0xac, 0xb6, 0x0e, 0xcc, 0x30, 0x2d, 0x7a, 0xf4, 0x29, 0x49, 0x8e,
+	0x99, 0x29, 0x93, 0xa8, 0x59, 0x37, 0xa0, 0x9c, 0xac, 0x3b, 0x48, 0x85, 0x6c, 0x60, 0x74, 0xb9,
+	0x30, 0xa6, 0x3f, 0xb5, 0x26, 0x54, 0x86, 0xeb, 0x4f, 0x54, 0xa0, 0xa5, 0x79, 0xdb, 0xa1, 0x97,
+	0xe1, 0xca, 0x66, 0x8f, 0x74, 0x8e, 0xf9, 0x15, 0x46, 0xa1, 0xb3, 0x0b, 0x55, 0xce, 0xd9, 0x74,
+	0xec, 0xa3, 0xbe, 0xd5, 0xe1, 0xf5, 0xd2, 0x1d, 0x3a, 0xc1, 0x2e, 0xce, 0x58, 0x2e, 0x7a, 0x13,
+	0x14, 0xee, 0x14, 0x66, 0x58, 0x95, 0x4b, 0x17, 0xe7, 0xcb, 0x05, 0x26, 0xad, 0xfb, 0x98, 0x79,
+	0x62, 0xd3, 0xf4, 0xb5, 0x43, 0xb8, 0x3a, 0xbc, 0x91, 0x50, 0xfd, 0x31, 0x14, 0x3b, 0x62, 0x8f,
+	0x50, 0xfd, 0x3b, 0xe9, 0xea, 0xc7, 0x8a, 0xe1, 0x58, 0x5c, 0xfb, 0x43, 0x06, 0x72, 0xac, 0x7d,
+	0xa2, 0x21, 0xc0, 0x7a, 0xdd, 0xc8, 0xe8, 0x2c, 0x04, 0xa8, 0xa3, 0x35, 0x75, 0x9c, 0xa7, 0x43,
+	0x4d, 0x33, 0x59, 0x6e, 0x33, 0xc3, 0xe5, 0xf6, 0x92, 0x56, 0x02, 0xfd, 0x5f, 0x98, 0x3b, 0x64,
+	0xa6, 0xe4, 0x5b, 0xd3, 0x95, 0x64, 0x6a, 0x24, 0x13, 0xc8, 0x3a, 0x54, 0x8d, 0x4e, 0x40, 0x9d,
+	0x3b, 0x54, 0x8c, 0xc7, 0xa6, 0x7a, 0x71, 0xbe, 0x5c, 0xde, 0x60, 0x23, 0x42, 0xbd, 0xb2, 0x11,
+	0x53, 0x26, 0xad, 0xfc, 0x7e, 0x60, 0xf4, 0x89, 0x68, 0x04, 0x39, 0x11, 0x87, 0x38, 0x2f, 0x42,
+	0xc9, 0x10, 0xd7, 0x45, 0x88, 0xb3, 0xf3, 0xb9, 0xc4, 0x36, 0x69, 0x39, 0x50, 0x78, 0xce, 0x17,
+	0xa4, 0xb6, 0x05, 0xc5, 0x48, 0xc1, 0xd9, 0x6c, 0x55, 0x07, 0xc5, 0xf5, 0x2c, 0xc7, 0xb3, 0x02,
+	0xfe, 0xd0, 0xaa, 0xe0, 0x88, 0xd6, 0xbe, 0x95, 0x00, 0x6d, 0x7a, 0xc4, 0x08, 0x08, 0x5b, 0x34,
+	0xf4, 0xdb, 0x9f, 0xe0, 0x0e, 0x92, 0x5a, 0xc8, 0xc3, 0x5a, 0x50, 0x43, 0x1d, 0x39, 0x5e, 0x87,
+	0xb7, 0x33, 0x0a, 0xe6, 0x84, 0xf6, 0x35, 0x5c, 0xd9, 0x70, 0x5d, 0xcf, 0x39, 0x1d, 0xd3, 0x2d,
+	0xb4, 0x9f, 0x34, 0xcd, 0x7e, 0x73, 0xe8, 0x16, 0xed, 0x2f, 0x27, 0xf7, 0xff, 0x04, 0x80, 0x85,
+	0x1b, 0x6f, 0xea, 0x27, 0xa5, 0x81, 0x7a, 0xa2, 0xa9, 0xe2, 0xa9, 0x20, 0xa2, 0xb5, 0x3d, 0x40,
+	0x3a, 0xe9, 0x93, 0x11, 0xe3, 0x4e, 0x6e, 0x15, 0x13, 0x66, 0xcf, 0x4c, 0x32, 0xbb, 0xb6, 0x00,
+	0x15, 0xae, 0x52, 0x18, 0xf0, 0x4f, 0xa0, 0x1a, 0x32, 0x44, 0x60, 0x7e, 0x08, 0x79, 0xf1, 0x44,
+	0xe1, 0x51, 0xf9, 0xc6, 0x0c, 0x0e, 0x8f, 0x85, 0x88, 0xf6, 0xc7, 0x0c, 0xe4, 0x77, 0x9d, 0xbe,
+	0xd5, 0x39, 0x9b, 0x78, 0xe6, 0xc4, 0x15, 0x64, 0x26, 0x5e, 0xc1, 0x0d, 0x28, 0x99, 0xc4, 0x0f,
+	0x2c, 0xdb, 0x08, 0x28, 0x52, 0xc0, 0xdf, 0x5b, 0x49, 0x16, 0x77, 0x09, 0x27, 0x70, 0x3a, 0x4e,
+	0x3f, 0x2c, 0x84, 0x21, 0x4d, 0xaf, 0x84, 0xa6, 0x72, 0x9f, 0x87, 0x1a, 0xe6, 0x04, 0xda, 0x84,
+	0x3c, 0x8d, 0x30, 0xc7, 0x66, 0x21, 0x55, 0x5d, 0xbb, 0x9d, 0x92, 0x6e, 0xd8, 0x31, 0x56, 0x36,
+	0x98, 0x08, 0x16, 0xa2, 0x43, 0x9e, 0x58, 0x18, 0xf1, 0x44, 0x91, 0xb0, 0x95, 0x38, 0x61, 0xbf,
+	0x0e, 0x79, 0x2e, 0x8f, 0x8a, 0x90, 0xdb, 0xd8, 0xda, 0xda, 0x79, 0xaa, 0xbe, 0x84, 0x14, 0x90,
+	0xf5, 0xc6, 0xf6, 0x33, 0x55, 0xd2, 0xf6, 0xe0, 0x0a, 0x8f, 0x1f, 0xbe, 0x57, 0x78, 0xc7, 0x1f,
+	0x41, 0xde, 0x65, 0x8c, 0xd9, 0x9e, 0xd6, 0x42, 0x58, 0xc8, 0x68, 0x77, 0xe1, 0x0a, 0xf7, 0x9b,
+	0xe1, 0x45, 0x27, 0xbd, 0x7b, 0x16, 0x61, 0x81, 0x4d, 0xb4, 0x62, 0x9f, 0x68, 0x81, 0x1a, 0xb3,
+	0x84, 0x57, 0x7c, 0x02, 0x8a, 0x2b, 0x78, 0xc2, 0x2f, 0x66, 0xd3, 0x2a, 0x92, 0xd2, 0x7e, 0x01,
+	0x48, 0x6c, 0x70, 0xe0, 0xc4, 0xfe, 0x1c, 0x62, 0x44, 0x52, 0x02, 0x23, 0x5a, 0x83, 0x72, 0xc7,
+	0xb0, 0x4d, 0xcb, 0x34, 0x82, 0x84, 0x3b, 0xb3, 0x36, 0x71, 0x33, 0xe4, 0x37, 0x75, 0x5c, 0x8a,
+	0x26, 0x35, 0xc7, 0x30, 0xa1, 0xec, 0x28, 0x26, 0xa4, 0x6d, 0xc2, 0x95, 0xa1, 0xed, 0xe3, 0xc7,
+	0x6f, 0xd7, 0x33, 0x6c, 0xda, 0xd5, 0x4b, 0x3c, 0x57, 0x0a, 0x32, 0xd2, 0x2c, 0x13, 0x6b, 0x46,
+	0xc3, 0x47, 0x74, 0x78, 0xc2, 0x54, 0xdb, 0x50, 0x0d, 0x19, 0x3f, 0x06, 0x2e, 0x42, 0x5b, 0xd7,
+	0x45, 0x4c, 0x3a, 0x8e, 0xdd, 0xb1, 0xfa, 0x24, 0xaa, 0xc1, 0x08, 0xe4, 0x63, 0xcb, 0x16, 0xb7,
+	0x87, 0xd9, 0x6f, 0xea, 0x6c, 0x31, 0xf8, 0x45, 0x7f, 0x52, 0xaf, 0x67, 0xfd, 0x88, 0x38, 0x3c,
+	0x27, 0x68, 0x27, 0x24, 0xf4, 0xe1, 0x51, 0x22, 0x28, 0x74, 0x1d, 0xc0, 0x23, 0xbe, 0xd3, 0x1f,
+	0xb0, 0x88, 0xe0, 0x81, 0x92, 0xe0, 0x68, 0xff, 0xca, 0xc0, 0x42, 0xa4, 0x09, 0x26, 0x34, 0x84,
+	0xe8, 0x13, 0xa8, 0xc3, 0xfc, 0xd5, 0xac, 0x49, 0x73, 0x3c, 0x03, 0x42, 0x21, 0x8a, 0x51, 0xf2,
+	0xdd, 0xe3, 0x5b, 0x65, 0x7d, 0x24, 0x37, 0x42, 0x53, 0xc7, 0x0a, 0x1f, 0xe6, 0xf7, 0x29, 0xa6,
+	0xb2, 0x4b, 0xe0, 0x8f, 0x5c, 0xe0, 0xac, 0x16, 0x75, 0x92, 0x3b, 0x00, 0x26, 0x6b, 0xf9, 0x4c,
+	0xba, 0x58, 0x02, 0xc4, 0xd4, 0x39, 0xb7, 0xa9, 0xe3, 0xa2, 0x98, 0xd0, 0x34, 0xd1, 0x7f, 0x41,
+	0x39, 0x9c, 0xcd, 0xd6, 0xe3, 0xcf, 0xc1, 0x92, 0xe0, 0xb5, 0x22, 0x64, 0xd2, 0x0f, 0x1c, 0x8f,
+	0x98, 0x02, 0xb1, 0x8c, 0x68, 0xf4, 0x24, 0xd9, 0xac, 0x14, 0x98, 0xfb, 0xaf, 0xa6, 0xa4, 0xc5,
+	0xd1, 0x4b, 0x4c, 0xf4, 0x2b, 0xd4, 0xe9, 0x4c, 0x16, 0xa2, 0x26, 0x4b, 0x16, 0x32, 0x0e, 0x49,
+	0x8a, 0x1a, 0x8c, 0x18, 0x3d, 0x8a, 0xca, 0x0e, 0xd4, 0xc6, 0x87, 0x84, 0xd3, 0x3d, 0x84, 0x82,
+	0xc7, 0x59, 0x22, 0x38, 0xef, 0xce, 0xa8, 0x1d, 0x5f, 0x08, 0x87, 0xd2, 0x34, 0x1b, 0xec, 0x05,
+	0xc4, 0xd5, 0x9d, 0xe7, 0x21, 0x0a, 0xab, 0xdd, 0x01, 0xb4, 0xeb, 0x39, 0xd4, 0x4e, 0xac, 0xad,
+	0x49, 0x49, 0x27, 0x5f, 0xc2, 0x62, 0xcb, 0x38, 0x26, 0x43, 0x51, 0x72, 0x69, 0x90, 0x2f, 0x41,
+	0xde, 0x39, 0x3a, 0xf2, 0x49, 0xc0, 0x1c, 0x21, 0x8b, 0x05, 0x95, 0x1e, 0xc8, 0x18, 0x50, 0x72,
+	0x87, 0x1f, 0x25, 0xec, 0x1c, 0x1a, 0x75, 0x27, 0xce, 0xe9, 0x2c, 0x47, 0x44, 0x0f, 0xe8, 0x8b,
+	0xc5, 0xf0, 0x7d, 0xab, 0x6b, 0x0b, 0x30, 0xaf, 0x1d, 0x38, 0xc2, 0x9d, 0x19, 0x9c, 0x8a, 0xc5,
+	0x28, 0x2f, 0xac, 0x2d, 0x07, 0xab, 0xde, 0x08, 0x87, 0x1a, 0x15, 0x73, 0xe7, 0x9a, 0xc5, 0xa8,
+	0x0f, 0x40, 0xd5, 0xe9, 0x83, 0x7e, 0x16, 0xed, 0x96, 0x20, 0xef, 0x11, 0x7f, 0x20, 0xe0, 0x4b,
+	0x05, 0x0b, 0x4a, 0xbb, 0x05, 0xe5, 0xa7, 0x46, 0xd0, 0xe9, 0x85, 0xf2, 0x49, 0x20, 0x5e, 0x1a,
+	0x01, 0xe2, 0xff, 0x91, 0x03, 0x60, 0x93, 0x1b, 0xa7, 0xc4, 0x9e, 0x3a, 0x15, 0x6d, 0x80, 0x1c,
+	0x9c, 0xb9, 0x7c, 0xb3, 0x6a, 0x9a, 0xdb, 0xc5, 0x6b, 0xae, 0xb4, 0xce, 0x5c, 0x82, 0x99, 0xa8,
+	0x38, 0x49, 0x76, 0xec, 0x24, 0x89, 0x6c, 0x23, 0xff, 0x90, 0x6c, 0x13, 0x62, 0xcc, 0xb9, 0x39,
+	0x31, 0xe6, 0x75, 0x90, 0x5d, 0x42, 0xbc, 0x5a, 0x7e, 0x16, 0x39, 0xd6, 0xe4, 0xb1, 0xf9, 0xe8,
+	0x03, 0xc8, 0x31, 0x77, 0x10, 0xf0, 0xd0, 0x4c, 0x7d, 0x13, 0x97, 0x48, 0x54, 0x7c, 0xe5, 0x07,
+	0x54, 0xfc, 0xbf, 0x64, 0x40, 0xa6, 0xf6, 0x44, 0x25, 0x28, 0xec, 0x6f, 0x7f, 0xb6, 0xbd, 0xf3,
+	0x74, 0x5b, 0x7d, 0x09, 0x01, 0xe4, 0xf7, 0x9e, 0x6d, 0x6f, 0x36, 0x74, 0x55, 0x42, 0x0b, 0x50,
+	0xda, 0xde, 0xd1, 0x1b, 0xed, 0xc7, 0x3b, 0xcd, 0xed, 0x86, 0xae, 0x66, 0x50, 0x05, 0x8a, 0x8c,
+	0xb1, 0xd5, 0xf8, 0xb4, 0xa5, 0x66, 0x51, 0x15, 0x60, 0xb7, 0xd1, 0xc0, 0xed, 0x0d, 0x5d, 0x6f,
+	0xe8, 0xaa, 0x8c, 0x54, 0x28, 0x33, 0x7a, 0x7f, 0x57, 0xdf, 0x68, 0x35, 0x74, 0x35, 0x17, 0x71,
+	0x70, 0xe3, 0xc9, 0xce, 0x41, 0x43, 0x57, 0xf3, 0x68, 0x11, 0x2a, 0x78, 0x67, 0xbf, 0xd5, 0x68,
+	0x6f, 0xe2, 0x06, 0x9b, 0x54, 0x88, 0x59, 0xa1, 0x9c, 0x12, 0xb3, 0xf4, 0xc6, 0x56, 0x83, 0xb2,
+	0x8a, 0xe8, 0x0a, 0x2c, 0xf0, 0xcd, 0xf6, 0x5b, 0x8f, 0x76, 0x70, 0xf3, 0xf3, 0x86, 0xae, 0x02,
+	0x7a, 0x19, 0x16, 0x19, 0x53, 0x6f, 0x24, 0xd8, 0x25, 0x84, 0xa0, 0xba, 0xbb, 0xb3, 0xd5, 0xdc,
+	0x7c, 0x16, 0xed, 0x52, 0x4e, 0xf0, 0xc2, 0x6d, 0x2a, 0x09, 0x5e, 0xb8, 0x4f, 0x95, 0x1e, 0x9a,
+	0x2d, 0xd9, 0xda, 0x78, 0xf8, 0xb0, 0xa1, 0xab, 0x0b, 0xa8, 0x0e, 0x4b, 0xfc, 0x0c, 0x54, 0xa1,
+	0xbd, 0xf6, 0x86, 0x7e, 0xd0, 0xc0, 0xad, 0xe6, 0x5e, 0x43, 0x57, 0x55, 0x8a, 0xc9, 0x2e, 0xee,
+	0x9d, 0xd9, 0x1d, 0x9a, 0xae, 0xad, 0x6e, 0x18, 0x24, 0x9f, 0x42, 0xa1, 0xc3, 0xd1, 0x72, 0x91,
+	0x56, 0x52, 0x9e, 0xa8, 0xc3, 0x1f, 0x18, 0x70, 0x28, 0x8c, 0x3e, 0x80, 0xac, 0xd1, 0x39, 0x16,
+	0x10, 0xd2, 0x5b, 0xa9, 0x6b, 0x1c, 0x59, 0xdd, 0x8d, 0xce, 0x31, 0xa6, 0x32, 0xda, 0x87, 0x50,
+	0x8c, 0x38, 0xb4, 0x70, 0x9c, 0x12, 0x2f, 0x11, 0x88, 0x21, 0x49, 0x8b, 0x3f, 0xf1, 0x3c, 0x27,
+	0x84, 0x4d, 0x39, 0xa1, 0xfd, 0x5e, 0x82, 0x8a, 0x4e, 0x7c, 0xcb, 0x23, 0x26, 0x5f, 0x64, 0xca,
+	0x0a, 0xff, 0xd9, 0xcf, 0x00, 0xda, 0x5f, 0xb3, 0xa0, 0xd2, 0xa9, 0x5c, 0xaf, 0xbd, 0xc0, 0x08,
+	0x06, 0xfe, 0xb4, 0x37, 0x43, 0xea, 0xdb, 0x86, 0xc2, 0x34, 0x26, 0x3f, 0x6b, 0x3b, 0x3c, 0x22,
+	0x6f, 0x1b, 0xaa, 0x82, 0x7d, 0x20, 0x4e, 0xca, 0x9a, 0x01, 0x3e, 0xb1, 0x67, 0xf8, 0xbd, 0x9a,
+	0x1c, 0xbd, 0x2e, 0x28, 0xef, 0x91, 0xe1, 0xf7, 0x68, 0xee, 0x11, 0x64, 0x2d, 0x37, 0x4f, 0xee,
+	0x11, 0x42, 0x54, 0x17, 0xc3, 0x75, 0xfb, 0x56, 0x42, 0x17, 0xde, 0x53, 0x54, 0x05, 0x3b, 0xd4,
+	0xe5, 0x63, 0x28, 0x08, 0xce, 0x7c, 0xa8, 0xb2, 0x10, 0x8a, 0xef, 0x5d, 0x49, 0xdc, 0x3b, 0x85,
+	0xf9, 0x85, 0xeb, 0x11, 0x93, 0x21, 0xc9, 0x0a, 0x8e, 0x19, 0xe8, 0x11, 0x94, 0x4d, 0xcb, 0x8f,
+	0x27, 0xc0, 0x1c, 0x1b, 0x0f, 0x49, 0x6a, 0xaf, 0xc2, 0xb5, 0xd1, 0x3b, 0x8c, 0x9f, 0x11, 0x3d,
+	0xa8, 0x5f, 0x36, 0x18, 0xe1, 0x3f, 0x8a, 0x2f, 0x78, 0xa2, 0x67, 0x59, 0x49, 0xf7, 0xab, 0xe4,
+	0x5a, 0x38, 0x92, 0x5f, 0xfb, 0xd3, 0x12, 0x28, 0x8f, 0xc4, 0x74, 0x74, 0x04, 0x05, 0x11, 0x86,
+	0x68, 0xae, 0x68, 0xad, 0xdf, 0x9d, 0x71, 0xb6, 0x38, 0xc0, 0xcf, 0xa0, 0x32, 0xf4, 0x05, 0x0b,
+	0xad, 0x4d, 0x97, 0xbf, 0xec, 0x73, 0x57, 0x7d, 0x69, 0xcc, 0xe8, 0x0d, 0xfa, 0x5f, 0x02, 0xd4,
+	0x86, 0x85, 0x91, 0xef, 0x57, 0xe8, 0xdd, 0xe9, 0xcb, 0x5f, 0xfe, 0xb9, 0x6b, 0xe2, 0x06, 0x5f,
+	0xc3, 0xc2, 0xc8, 0x47, 0xad, 0xb4, 0x0d, 0x2e, 0xff, 0x3a, 0x56, 0x7f, 0x6f, 0x4e, 0x29, 0x61,
+	0xbd, 0x5f, 0x4a, 0x3c, 0xfc, 0x87, 0xbe, 0x83, 0xbd, 0x97, 0xee, 0x01, 0x97, 0x7c, 0x4f, 0xab,
+	0xaf, 0xcf, 0x2b, 0x26, 0x74, 0xf8, 0x02, 0x64, 0xfa, 0x49, 0x1b, 0xbd, 0x3d, 0x5d, 0x3e, 0xf1,
+	0x97, 0x84, 0xfa, 0xad, 0x59, 0xa6, 0x8a, 0xe5, 0x3b, 0x90, 0x17, 0xf0, 0xcf, 0xed, 0x19, 0x5a,
+	0x81, 0xc8, 0xa0, 0x77, 0x66, 0x9b, 0x2c, 0x36, 0x79, 0x0a, 0xa5, 0x04, 0x04, 0x87, 0xee, 0xa5,
+	0xf8, 0xf0, 0x18, 0x5a, 0x37, 0xd1, 0x41, 0x9e, 0x42, 0x29, 0x01, 0x3f, 0xa5, 0x2d, 0x3c, 0x8e,
+	0x54, 0x4d, 0x5c, 0xf8, 0x19, 0x94, 0x93, 0xc8, 0x1c, 0xba, 0x9f, 0xe2, 0x40, 0xe3, 0x28, 0xde,
+	0xc4, 0xa5, 0x2d, 0x50, 0x42, 0xe0, 0x02, 0xdd, 0x9d, 0xa1, 0x85, 0x8a, 0x31, 0x8f, 0xfa, 0xca,
+	0xac, 0xd3, 0x85, 0xdd, 0x9f, 0x41, 0x39, 0x09, 0xdd, 0xa4, 0x9d, 0xe2, 0x12, 0x98, 0x67, 0x9a,
+	0x81, 0x92, 0x00, 0x4e, 0xda, 0xd2, 0x97, 0x80, 0x3d, 0x13, 0x97, 0xfe, 0x12, 0x72, 0xec, 0xc3,
+	0x18, 0xba, 0x95, 0xde, 0x0d, 0x47, 0xa6, 0xb9, 0x3d, 0xd3, 0x5c, 0x61, 0x97, 0x2f, 0x21, 0xc7,
+	0xb3, 0xc9, 0xad, 0xf4, 0xa0, 0x9c, 0x75, 0x87, 0xe1, 0xcc, 0x31, 0x80, 0x72, 0xf2, 0x83, 0x42,
+	0xaa, 0xe5, 0xc7, 0xbf, 0x72, 0xd4, 0xd7, 0xe6, 0x11, 0x11, 0xdb, 0x7a, 0x50, 0x4a, 0xe0, 0x47,
+	0x69, 0xf1, 0x30, 0x8e, 0x74, 0xd5, 0xef, 0xcf, 0x21, 0x11, 0x67, 0x10, 0xf1, 0x4f, 0xb1, 0xdb,
+	0x33, 0x3d, 0x67, 0x67, 0xcb, 0x20, 0x23, 0x2f, 0x67, 0x9a, 0x89, 0x47, 0x81, 0x85, 0xb4, 0x4c,
+	0x3c, 0x01, 0xa3, 0xa8, 0xaf, 0xcf, 0x2b, 0x26, 0x74, 0xf8, 0x7f, 0x50, 0x42, 0xd8, 0x21, 0x2d,
+	0x70, 0x47, 0xe0, 0x89, 0x69, 0xf9, 0x2b, 0x01, 0x5b, 0xa4, 0xdd, 0xd7, 0x38, 0xc2, 0x31, 0x71,
+	0x61, 0x07, 0x20, 0xc6, 0x1f, 0x50, 0x0a, 0x0c, 0x34, 0x86, 0x85, 0xd4, 0xef, 0xcd, 0x2e, 0x20,
+	0x8c, 0xb3, 0x0f, 0x10, 0x83, 0x13, 0x28, 0x15, 0x77, 0x1a, 0x81, 0x31, 0x26, 0x9e, 0x63, 0x0f,
+	0x8a, 0x11, 0xa8, 0x80, 0x52, 0xd2, 0xdf, 0x28, 0xfa, 0x30, 0xcd, 0xea, 0x09, 0x5c, 0x23, 0x3d,
+	0x4a, 0x46, 0x21, 0x90, 0x29, 0x0d, 0x51, 0x8e, 0xa1, 0x07, 0x69, 0x79, 0x25, 0x89, 0x71, 0xd4,
+	0x6f, 0xce, 0x0a, 0x47, 0xdc, 0x93, 0x90, 0x0d, 0x10, 0xbf, 0xff, 0xd2, 0xac, 0x3c, 0xf6, 0x52,
+	0x4c, 0x4b, 0x61, 0x43, 0x8f, 0xb0, 0x9b, 0xd2, 0x3d, 0x09, 0x7d, 0x23, 0x01, 0x1a, 0x6f, 0x8f,
+	0xd1, 0xff, 0xce, 0xd7, 0x04, 0xc7, 0x59, 0xfa, 0xfd, 0xf9, 0x05, 0xb9, 0x7f, 0x3d, 0x78, 0xf7,
+	0xbb, 0x8b, 0xeb, 0xd2, 0xf7, 0x17, 0xd7, 0xa5, 0xbf, 0x5f, 0x5c, 0x97, 0x3e, 0x7f, 0x73, 0x86,
+	0x3f, 0xd1, 0x7e, 0x78, 0x7a, 0xff, 0x30, 0xcf, 0x2e, 0xe8, 0x9d, 0x7f, 0x0f, 0x00, 0xac, 0xb6,
+	0x7d, 0x85, 0x75, 0x2b, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// HeimdallClient is the client API for Heimdall service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HeimdallClient interface {
+	Connect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*ConnectResponse, error)
+	AuthorizePeer(ctx context.Context, in *AuthorizePeerRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	DeauthorizePeer(ctx context.Context, in *DeauthorizePeerRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	AuthorizedPeers(ctx context.Context, in *AuthorizedPeersRequest, opts ...grpc.CallOption) (*AuthorizedPeersResponse, error)
+	PeerNetworkUsage(ctx context.Context, in *PeerNetworkUsageRequest, opts ...grpc.CallOption) (*PeerNetworkUsageResponse, error)
+	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
+	Routes(ctx context.Context, in *RoutesRequest, opts ...grpc.CallOption) (*RoutesResponse, error)
+	CreateRoute(ctx context.Context, in *CreateRouteRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	DeleteRoute(ctx context.Context, in *DeleteRouteRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	ApproveRoute(ctx context.Context, in *ApproveRouteRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	Policies(ctx context.Context, in *PoliciesRequest, opts ...grpc.CallOption) (*PoliciesResponse, error)
+	CreatePolicy(ctx context.Context, in *CreatePolicyRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	DeletePolicy(ctx context.Context, in *DeletePolicyRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	Nodes(ctx context.Context, in *NodesRequest, opts ...grpc.CallOption) (*NodesResponse, error)
+	Peers(ctx context.Context, in *PeersRequest, opts ...grpc.CallOption) (*PeersResponse, error)
+	CheckPeerIPs(ctx context.Context, in *CheckPeerIPsRequest, opts ...grpc.CallOption) (*CheckPeerIPsResponse, error)
+	RequestVote(ctx context.Context, in *RequestVoteRequest, opts ...grpc.CallOption) (*RequestVoteResponse, error)
+	Master(ctx context.Context, in *MasterRequest, opts ...grpc.CallOption) (*MasterResponse, error)
+	ReconcileReports(ctx context.Context, in *ReconcileReportsRequest, opts ...grpc.CallOption) (*ReconcileReportsResponse, error)
+	StepDown(ctx context.Context, in *StepDownRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	PromoteNode(ctx context.Context, in *PromoteNodeRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	TakeMaster(ctx context.Context, in *TakeMasterRequest, opts ...grpc.CallOption) (*TakeMasterResponse, error)
+	RemoveNode(ctx context.Context, in *RemoveNodeRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	DrainNode(ctx context.Context, in *DrainNodeRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	RestoreNode(ctx context.Context, in *RestoreNodeRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Heimdall_WatchClient, error)
+	SyncConfig(ctx context.Context, opts ...grpc.CallOption) (Heimdall_SyncConfigClient, error)
+	PeerConfigStatuses(ctx context.Context, in *PeerConfigStatusesRequest, opts ...grpc.CallOption) (*PeerConfigStatusesResponse, error)
+}
+
+type heimdallClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewHeimdallClient(cc *grpc.ClientConn) HeimdallClient {
+	return &heimdallClient{cc}
+}
+
+func (c *heimdallClient) Connect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*ConnectResponse, error) {
+	out := new(ConnectResponse)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/Connect", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) AuthorizePeer(ctx context.Context, in *AuthorizePeerRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/AuthorizePeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) DeauthorizePeer(ctx context.Context, in *DeauthorizePeerRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/DeauthorizePeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) AuthorizedPeers(ctx context.Context, in *AuthorizedPeersRequest, opts ...grpc.CallOption) (*AuthorizedPeersResponse, error) {
+	out := new(AuthorizedPeersResponse)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/AuthorizedPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) PeerNetworkUsage(ctx context.Context, in *PeerNetworkUsageRequest, opts ...grpc.CallOption) (*PeerNetworkUsageResponse, error) {
+	out := new(PeerNetworkUsageResponse)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/PeerNetworkUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
+	out := new(JoinResponse)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/Join", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) Routes(ctx context.Context, in *RoutesRequest, opts ...grpc.CallOption) (*RoutesResponse, error) {
+	out := new(RoutesResponse)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/Routes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) CreateRoute(ctx context.Context, in *CreateRouteRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/CreateRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) DeleteRoute(ctx context.Context, in *DeleteRouteRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/DeleteRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) ApproveRoute(ctx context.Context, in *ApproveRouteRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/ApproveRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) Policies(ctx context.Context, in *PoliciesRequest, opts ...grpc.CallOption) (*PoliciesResponse, error) {
+	out := new(PoliciesResponse)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/Policies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) CreatePolicy(ctx context.Context, in *CreatePolicyRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/CreatePolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) DeletePolicy(ctx context.Context, in *DeletePolicyRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/DeletePolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) Nodes(ctx context.Context, in *NodesRequest, opts ...grpc.CallOption) (*NodesResponse, error) {
+	out := new(NodesResponse)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/Nodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) Peers(ctx context.Context, in *PeersRequest, opts ...grpc.CallOption) (*PeersResponse, error) {
+	out := new(PeersResponse)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/Peers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) CheckPeerIPs(ctx context.Context, in *CheckPeerIPsRequest, opts ...grpc.CallOption) (*CheckPeerIPsResponse, error) {
+	out := new(CheckPeerIPsResponse)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/CheckPeerIPs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) RequestVote(ctx context.Context, in *RequestVoteRequest, opts ...grpc.CallOption) (*RequestVoteResponse, error) {
+	out := new(RequestVoteResponse)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/RequestVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) Master(ctx context.Context, in *MasterRequest, opts ...grpc.CallOption) (*MasterResponse, error) {
+	out := new(MasterResponse)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/Master", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) ReconcileReports(ctx context.Context, in *ReconcileReportsRequest, opts ...grpc.CallOption) (*ReconcileReportsResponse, error) {
+	out := new(ReconcileReportsResponse)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/ReconcileReports", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) StepDown(ctx context.Context, in *StepDownRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/StepDown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) PromoteNode(ctx context.Context, in *PromoteNodeRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/PromoteNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) TakeMaster(ctx context.Context, in *TakeMasterRequest, opts ...grpc.CallOption) (*TakeMasterResponse, error) {
+	out := new(TakeMasterResponse)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/TakeMaster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) RemoveNode(ctx context.Context, in *RemoveNodeRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/RemoveNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) DrainNode(ctx context.Context, in *DrainNodeRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/DrainNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) RestoreNode(ctx context.Context, in *RestoreNodeRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/RestoreNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *heimdallClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Heimdall_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Heimdall_serviceDesc.Streams[0], "/dev.ehazlett.heimdall.api.v1.Heimdall/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &heimdallWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Heimdall_WatchClient interface {
+	Recv() (*WatchEvent, error)
+	grpc.ClientStream
+}
+
+type heimdallWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *heimdallWatchClient) Recv() (*WatchEvent, error) {
+	m := new(WatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *heimdallClient) SyncConfig(ctx context.Context, opts ...grpc.CallOption) (Heimdall_SyncConfigClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Heimdall_serviceDesc.Streams[1], "/dev.ehazlett.heimdall.api.v1.Heimdall/SyncConfig", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &heimdallSyncConfigClient{stream}
+	return x, nil
+}
+
+type Heimdall_SyncConfigClient interface {
+	Send(*SyncConfigRequest) error
+	Recv() (*DesiredConfig, error)
+	grpc.ClientStream
+}
+
+type heimdallSyncConfigClient struct {
+	grpc.ClientStream
+}
+
+func (x *heimdallSyncConfigClient) Send(m *SyncConfigRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *heimdallSyncConfigClient) Recv() (*DesiredConfig, error) {
+	m := new(DesiredConfig)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *heimdallClient) PeerConfigStatuses(ctx context.Context, in *PeerConfigStatusesRequest, opts ...grpc.CallOption) (*PeerConfigStatusesResponse, error) {
+	out := new(PeerConfigStatusesResponse)
+	err := c.cc.Invoke(ctx, "/dev.ehazlett.heimdall.api.v1.Heimdall/PeerConfigStatuses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HeimdallServer is the server API for Heimdall service.
+type HeimdallServer interface {
+	Connect(context.Context, *ConnectRequest) (*ConnectResponse, error)
+	AuthorizePeer(context.Context, *AuthorizePeerRequest) (*types.Empty, error)
+	DeauthorizePeer(context.Context, *DeauthorizePeerRequest) (*types.Empty, error)
+	AuthorizedPeers(context.Context, *AuthorizedPeersRequest) (*AuthorizedPeersResponse, error)
+	PeerNetworkUsage(context.Context, *PeerNetworkUsageRequest) (*PeerNetworkUsageResponse, error)
+	Join(context.Context, *JoinRequest) (*JoinResponse, error)
+	Routes(context.Context, *RoutesRequest) (*RoutesResponse, error)
+	CreateRoute(context.Context, *CreateRouteRequest) (*types.Empty, error)
+	DeleteRoute(context.Context, *DeleteRouteRequest) (*types.Empty, error)
+	ApproveRoute(context.Context, *ApproveRouteRequest) (*types.Empty, error)
+	Policies(context.Context, *PoliciesRequest) (*PoliciesResponse, error)
+	CreatePolicy(context.Context, *CreatePolicyRequest) (*types.Empty, error)
+	DeletePolicy(context.Context, *DeletePolicyRequest) (*types.Empty, error)
+	Nodes(context.Context, *NodesRequest) (*NodesResponse, error)
+	Peers(context.Context, *PeersRequest) (*PeersResponse, error)
+	CheckPeerIPs(context.Context, *CheckPeerIPsRequest) (*CheckPeerIPsResponse, error)
+	RequestVote(context.Context, *RequestVoteRequest) (*RequestVoteResponse, error)
+	Master(context.Context, *MasterRequest) (*MasterResponse, error)
+	ReconcileReports(context.Context, *ReconcileReportsRequest) (*ReconcileReportsResponse, error)
+	StepDown(context.Context, *StepDownRequest) (*types.Empty, error)
+	PromoteNode(context.Context, *PromoteNodeRequest) (*types.Empty, error)
+	TakeMaster(context.Context, *TakeMasterRequest) (*TakeMasterResponse, error)
+	RemoveNode(context.Context, *RemoveNodeRequest) (*types.Empty, error)
+	DrainNode(context.Context, *DrainNodeRequest) (*types.Empty, error)
+	RestoreNode(context.Context, *RestoreNodeRequest) (*types.Empty, error)
+	Watch(*WatchRequest, Heimdall_WatchServer) error
+	SyncConfig(Heimdall_SyncConfigServer) error
+	PeerConfigStatuses(context.Context, *PeerConfigStatusesRequest) (*PeerConfigStatusesResponse, error)
+}
+
+// UnimplementedHeimdallServer can be embedded to have forward compatible implementations.
+type UnimplementedHeimdallServer struct {
+}
+
+func (*UnimplementedHeimdallServer) Connect(ctx context.Context, req *ConnectRequest) (*ConnectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Connect not implemented")
+}
+func (*UnimplementedHeimdallServer) AuthorizePeer(ctx context.Context, req *AuthorizePeerRequest) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthorizePeer not implemented")
+}
+func (*UnimplementedHeimdallServer) DeauthorizePeer(ctx context.Context, req *DeauthorizePeerRequest) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeauthorizePeer not implemented")
+}
+func (*UnimplementedHeimdallServer) AuthorizedPeers(ctx context.Context, req *AuthorizedPeersRequest) (*AuthorizedPeersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthorizedPeers not implemented")
+}
+func (*UnimplementedHeimdallServer) PeerNetworkUsage(ctx context.Context, req *PeerNetworkUsageRequest) (*PeerNetworkUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeerNetworkUsage not implemented")
+}
+func (*UnimplementedHeimdallServer) Join(ctx context.Context, req *JoinRequest) (*JoinResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
+}
+func (*UnimplementedHeimdallServer) Routes(ctx context.Context, req *RoutesRequest) (*RoutesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Routes not implemented")
+}
+func (*UnimplementedHeimdallServer) CreateRoute(ctx context.Context, req *CreateRouteRequest) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoute not implemented")
+}
+func (*UnimplementedHeimdallServer) DeleteRoute(ctx context.Context, req *DeleteRouteRequest) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoute not implemented")
+}
+func (*UnimplementedHeimdallServer) ApproveRoute(ctx context.Context, req *ApproveRouteRequest) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveRoute not implemented")
+}
+func (*UnimplementedHeimdallServer) Policies(ctx context.Context, req *PoliciesRequest) (*PoliciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Policies not implemented")
+}
+func (*UnimplementedHeimdallServer) CreatePolicy(ctx context.Context, req *CreatePolicyRequest) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePolicy not implemented")
+}
+func (*UnimplementedHeimdallServer) DeletePolicy(ctx context.Context, req *DeletePolicyRequest) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePolicy not implemented")
+}
+func (*UnimplementedHeimdallServer) Nodes(ctx context.Context, req *NodesRequest) (*NodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Nodes not implemented")
+}
+func (*UnimplementedHeimdallServer) Peers(ctx context.Context, req *PeersRequest) (*PeersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Peers not implemented")
+}
+func (*UnimplementedHeimdallServer) CheckPeerIPs(ctx context.Context, req *CheckPeerIPsRequest) (*CheckPeerIPsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPeerIPs not implemented")
+}
+func (*UnimplementedHeimdallServer) RequestVote(ctx context.Context, req *RequestVoteRequest) (*RequestVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestVote not implemented")
+}
+func (*UnimplementedHeimdallServer) Master(ctx context.Context, req *MasterRequest) (*MasterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Master not implemented")
+}
+func (*UnimplementedHeimdallServer) ReconcileReports(ctx context.Context, req *ReconcileReportsRequest) (*ReconcileReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileReports not implemented")
+}
+func (*UnimplementedHeimdallServer) StepDown(ctx context.Context, req *StepDownRequest) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StepDown not implemented")
+}
+func (*UnimplementedHeimdallServer) PromoteNode(ctx context.Context, req *PromoteNodeRequest) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteNode not implemented")
+}
+func (*UnimplementedHeimdallServer) TakeMaster(ctx context.Context, req *TakeMasterRequest) (*TakeMasterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TakeMaster not implemented")
+}
+func (*UnimplementedHeimdallServer) RemoveNode(ctx context.Context, req *RemoveNodeRequest) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveNode not implemented")
+}
+func (*UnimplementedHeimdallServer) DrainNode(ctx context.Context, req *DrainNodeRequest) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainNode not implemented")
+}
+func (*UnimplementedHeimdallServer) RestoreNode(ctx context.Context, req *RestoreNodeRequest) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreNode not implemented")
+}
+func (*UnimplementedHeimdallServer) Watch(req *WatchRequest, srv Heimdall_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (*UnimplementedHeimdallServer) SyncConfig(srv Heimdall_SyncConfigServer) error {
+	return status.Errorf(codes.Unimplemented, "method SyncConfig not implemented")
+}
+func (*UnimplementedHeimdallServer) PeerConfigStatuses(ctx context.Context, req *PeerConfigStatusesRequest) (*PeerConfigStatusesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeerConfigStatuses not implemented")
+}
+
+func RegisterHeimdallServer(s *grpc.Server, srv HeimdallServer) {
+	s.RegisterService(&_Heimdall_serviceDesc, srv)
+}
+
+func _Heimdall_Connect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).Connect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/Connect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).Connect(ctx, req.(*ConnectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_AuthorizePeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizePeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).AuthorizePeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/AuthorizePeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).AuthorizePeer(ctx, req.(*AuthorizePeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_DeauthorizePeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeauthorizePeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).DeauthorizePeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/DeauthorizePeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).DeauthorizePeer(ctx, req.(*DeauthorizePeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_AuthorizedPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizedPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).AuthorizedPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/AuthorizedPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).AuthorizedPeers(ctx, req.(*AuthorizedPeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_PeerNetworkUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerNetworkUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).PeerNetworkUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/PeerNetworkUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).PeerNetworkUsage(ctx, req.(*PeerNetworkUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).Join(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/Join",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).Join(ctx, req.(*JoinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_Routes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).Routes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/Routes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).Routes(ctx, req.(*RoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_CreateRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).CreateRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/CreateRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).CreateRoute(ctx, req.(*CreateRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_DeleteRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).DeleteRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/DeleteRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).DeleteRoute(ctx, req.(*DeleteRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_ApproveRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).ApproveRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/ApproveRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).ApproveRoute(ctx, req.(*ApproveRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_Policies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoliciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).Policies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/Policies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).Policies(ctx, req.(*PoliciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_CreatePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).CreatePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/CreatePolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).CreatePolicy(ctx, req.(*CreatePolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_DeletePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).DeletePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/DeletePolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).DeletePolicy(ctx, req.(*DeletePolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_Nodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).Nodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/Nodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).Nodes(ctx, req.(*NodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_Peers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).Peers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/Peers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).Peers(ctx, req.(*PeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_CheckPeerIPs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPeerIPsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).CheckPeerIPs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/CheckPeerIPs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).CheckPeerIPs(ctx, req.(*CheckPeerIPsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_RequestVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).RequestVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/RequestVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).RequestVote(ctx, req.(*RequestVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_Master_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MasterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).Master(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/Master",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).Master(ctx, req.(*MasterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_ReconcileReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).ReconcileReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/ReconcileReports",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).ReconcileReports(ctx, req.(*ReconcileReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_StepDown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StepDownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).StepDown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/StepDown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).StepDown(ctx, req.(*StepDownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_PromoteNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).PromoteNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/PromoteNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).PromoteNode(ctx, req.(*PromoteNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_TakeMaster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TakeMasterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).TakeMaster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/TakeMaster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).TakeMaster(ctx, req.(*TakeMasterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_RemoveNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).RemoveNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/RemoveNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).RemoveNode(ctx, req.(*RemoveNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_DrainNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).DrainNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/DrainNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).DrainNode(ctx, req.(*DrainNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_RestoreNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).RestoreNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/RestoreNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).RestoreNode(ctx, req.(*RestoreNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Heimdall_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HeimdallServer).Watch(m, &heimdallWatchServer{stream})
+}
+
+type Heimdall_WatchServer interface {
+	Send(*WatchEvent) error
+	grpc.ServerStream
+}
+
+type heimdallWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *heimdallWatchServer) Send(m *WatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Heimdall_SyncConfig_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(HeimdallServer).SyncConfig(&heimdallSyncConfigServer{stream})
+}
+
+type Heimdall_SyncConfigServer interface {
+	Send(*DesiredConfig) error
+	Recv() (*SyncConfigRequest, error)
+	grpc.ServerStream
+}
+
+type heimdallSyncConfigServer struct {
+	grpc.ServerStream
+}
+
+func (x *heimdallSyncConfigServer) Send(m *DesiredConfig) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *heimdallSyncConfigServer) Recv() (*SyncConfigRequest, error) {
+	m := new(SyncConfigRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Heimdall_PeerConfigStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerConfigStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeimdallServer).PeerConfigStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dev.ehazlett.heimdall.api.v1.Heimdall/PeerConfigStatuses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeimdallServer).PeerConfigStatuses(ctx, req.(*PeerConfigStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Heimdall_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dev.ehazlett.heimdall.api.v1.Heimdall",
+	HandlerType: (*HeimdallServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Connect",
+			Handler:    _Heimdall_Connect_Handler,
+		},
+		{
+			MethodName: "AuthorizePeer",
+			Handler:    _Heimdall_AuthorizePeer_Handler,
+		},
+		{
+			MethodName: "DeauthorizePeer",
+			Handler:    _Heimdall_DeauthorizePeer_Handler,
+		},
+		{
+			MethodName: "AuthorizedPeers",
+			Handler:    _Heimdall_AuthorizedPeers_Handler,
+		},
+		{
+			MethodName: "PeerNetworkUsage",
+			Handler:    _Heimdall_PeerNetworkUsage_Handler,
+		},
+		{
+			MethodName: "Join",
+			Handler:    _Heimdall_Join_Handler,
+		},
+		{
+			MethodName: "Routes",
+			Handler:    _Heimdall_Routes_Handler,
+		},
+		{
+			MethodName: "CreateRoute",
+			Handler:    _Heimdall_CreateRoute_Handler,
+		},
+		{
+			MethodName: "DeleteRoute",
+			Handler:    _Heimdall_DeleteRoute_Handler,
+		},
+		{
+			MethodName: "ApproveRoute",
+			Handler:    _Heimdall_ApproveRoute_Handler,
+		},
+		{
+			MethodName: "Policies",
+			Handler:    _Heimdall_Policies_Handler,
+		},
+		{
+			MethodName: "CreatePolicy",
+			Handler:    _Heimdall_CreatePolicy_Handler,
+		},
+		{
+			MethodName: "DeletePolicy",
+			Handler:    _Heimdall_DeletePolicy_Handler,
+		},
+		{
+			MethodName: "Nodes",
+			Handler:    _Heimdall_Nodes_Handler,
+		},
+		{
+			MethodName: "Peers",
+			Handler:    _Heimdall_Peers_Handler,
+		},
+		{
+			MethodName: "CheckPeerIPs",
+			Handler:    _Heimdall_CheckPeerIPs_Handler,
+		},
+		{
+			MethodName: "RequestVote",
+			Handler:    _Heimdall_RequestVote_Handler,
+		},
+		{
+			MethodName: "Master",
+			Handler:    _Heimdall_Master_Handler,
+		},
+		{
+			MethodName: "ReconcileReports",
+			Handler:    _Heimdall_ReconcileReports_Handler,
+		},
+		{
+			MethodName: "StepDown",
+			Handler:    _Heimdall_StepDown_Handler,
+		},
+		{
+			MethodName: "PromoteNode",
+			Handler:    _Heimdall_PromoteNode_Handler,
+		},
+		{
+			MethodName: "TakeMaster",
+			Handler:    _Heimdall_TakeMaster_Handler,
+		},
+		{
+			MethodName: "RemoveNode",
+			Handler:    _Heimdall_RemoveNode_Handler,
+		},
+		{
+			MethodName: "DrainNode",
+			Handler:    _Heimdall_DrainNode_Handler,
+		},
+		{
+			MethodName: "RestoreNode",
+			Handler:    _Heimdall_RestoreNode_Handler,
+		},
+		{
+			MethodName: "PeerConfigStatuses",
+			Handler:    _Heimdall_PeerConfigStatuses_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Heimdall_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SyncConfig",
+			Handler:       _Heimdall_SyncConfig_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "github.com/ehazlett/heimdall/api/v1/heimdall.proto",
+}
+
+func (m *Master) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Master) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Master) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Revision != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Revision))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Term != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeimdall(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.GatewayIP) > 0 {
+		i -= len(m.GatewayIP)
+		copy(dAtA[i:], m.GatewayIP)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.GatewayIP)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.RedisURL) > 0 {
+		i -= len(m.RedisURL)
+		copy(dAtA[i:], m.RedisURL)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.RedisURL)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.GRPCAddress) > 0 {
+		i -= len(m.GRPCAddress)
+		copy(dAtA[i:], m.GRPCAddress)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.GRPCAddress)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *JoinRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JoinRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JoinRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.NodeSubnetSizeV6 != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.NodeSubnetSizeV6))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.NodeSubnetSize != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.NodeSubnetSize))
+		i--
+		dAtA[i] = 0x48
+	}
+	if len(m.PublicKey) > 0 {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.InterfaceName) > 0 {
+		i -= len(m.InterfaceName)
+		copy(dAtA[i:], m.InterfaceName)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.InterfaceName)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.EndpointPort != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.EndpointPort))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.EndpointIP) > 0 {
+		i -= len(m.EndpointIP)
+		copy(dAtA[i:], m.EndpointIP)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.EndpointIP)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.GRPCAddress) > 0 {
+		i -= len(m.GRPCAddress)
+		copy(dAtA[i:], m.GRPCAddress)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.GRPCAddress)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.ClusterKey) > 0 {
+		i -= len(m.ClusterKey)
+		copy(dAtA[i:], m.ClusterKey)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ClusterKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *JoinResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JoinResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JoinResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeimdall(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Node != nil {
+		{
+			size, err := m.Node.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeimdall(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Master != nil {
+		{
+			size, err := m.Master.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeimdall(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnectRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.LocalAddresses) > 0 {
+		for iNdEx := len(m.LocalAddresses) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.LocalAddresses[iNdEx])
+			copy(dAtA[i:], m.LocalAddresses[iNdEx])
+			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.LocalAddresses[iNdEx])))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if m.ListenPort != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.ListenPort))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Mesh {
+		i--
+		if m.Mesh {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.AdvertisedRoutes) > 0 {
+		for iNdEx := len(m.AdvertisedRoutes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AdvertisedRoutes[iNdEx])
+			copy(dAtA[i:], m.AdvertisedRoutes[iNdEx])
+			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.AdvertisedRoutes[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.ExitNode) > 0 {
+		i -= len(m.ExitNode)
+		copy(dAtA[i:], m.ExitNode)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ExitNode)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.PublicKey) > 0 {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnectResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.DNS) > 0 {
+		for iNdEx := len(m.DNS) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DNS[iNdEx])
+			copy(dAtA[i:], m.DNS[iNdEx])
+			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.DNS[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeimdall(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AuthorizePeerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AuthorizePeerRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AuthorizePeerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Tags) > 0 {
+		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Tags[iNdEx])
+			copy(dAtA[i:], m.Tags[iNdEx])
+			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Tags[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.IPs) > 0 {
+		for iNdEx := len(m.IPs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.IPs[iNdEx])
+			copy(dAtA[i:], m.IPs[iNdEx])
+			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.IPs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeauthorizePeerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeauthorizePeerRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeauthorizePeerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AuthorizedPeersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AuthorizedPeersRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AuthorizedPeersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AuthorizedPeersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AuthorizedPeersResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AuthorizedPeersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.IDs) > 0 {
+		for iNdEx := len(m.IDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.IDs[iNdEx])
+			copy(dAtA[i:], m.IDs[iNdEx])
+			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.IDs[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerNetworkUsageRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerNetworkUsageRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerNetworkUsageRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NetworkUsage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NetworkUsage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NetworkUsage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Available != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Available))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Excluded != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Excluded))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Allocated != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Allocated))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Total != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Network) > 0 {
+		i -= len(m.Network)
+		copy(dAtA[i:], m.Network)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Network)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerNetworkUsageResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerNetworkUsageResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerNetworkUsageResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Networks) > 0 {
+		for iNdEx := len(m.Networks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Networks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeimdall(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Node) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Node) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Node) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ExitNode {
+		i--
+		if m.ExitNode {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if len(m.GatewayIPV6) > 0 {
+		i -= len(m.GatewayIPV6)
+		copy(dAtA[i:], m.GatewayIPV6)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.GatewayIPV6)))
+		i--
+		dAtA[i] = 0x7a
+	}
+	n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.LastHandshake, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.LastHandshake):])
+	if err3 != nil {
+		return 0, err3
+	}
+	i -= n3
+	i = encodeVarintHeimdall(dAtA, i, uint64(n3))
+	i--
+	dAtA[i] = 0x72
+	if len(m.HealthReason) > 0 {
+		i -= len(m.HealthReason)
+		copy(dAtA[i:], m.HealthReason)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.HealthReason)))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.Healthy {
+		i--
+		if m.Healthy {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.Draining {
+		i--
+		if m.Draining {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
+	if len(m.PublicKey) > 0 {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.InterfaceName) > 0 {
+		i -= len(m.InterfaceName)
+		copy(dAtA[i:], m.InterfaceName)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.InterfaceName)))
+		i--
+		dAtA[i] = 0x42
+	}
+	n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Updated, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Updated):])
+	if err4 != nil {
+		return 0, err4
+	}
+	i -= n4
+	i = encodeVarintHeimdall(dAtA, i, uint64(n4))
+	i--
+	dAtA[i] = 0x3a
+	if len(m.GatewayIP) > 0 {
+		i -= len(m.GatewayIP)
+		copy(dAtA[i:], m.GatewayIP)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.GatewayIP)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.EndpointPort != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.EndpointPort))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.EndpointIP) > 0 {
+		i -= len(m.EndpointIP)
+		copy(dAtA[i:], m.EndpointIP)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.EndpointIP)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Addr) > 0 {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NodesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NodesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NodesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NodesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NodesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NodesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Master != nil {
+		{
+			size, err := m.Master.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeimdall(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Nodes) > 0 {
+		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Nodes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeimdall(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Peer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Peer) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Peer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.LocalAddresses) > 0 {
+		for iNdEx := len(m.LocalAddresses) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.LocalAddresses[iNdEx])
+			copy(dAtA[i:], m.LocalAddresses[iNdEx])
+			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.LocalAddresses[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x82
+		}
+	}
+	if m.ListenPort != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.ListenPort))
+		i--
+		dAtA[i] = 0x78
+	}
+	if m.Mesh {
+		i--
+		if m.Mesh {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.Direct {
+		i--
+		if m.Direct {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x68
+	}
+	if len(m.RemoteAddress) > 0 {
+		i -= len(m.RemoteAddress)
+		copy(dAtA[i:], m.RemoteAddress)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.RemoteAddress)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if len(m.ReflexiveEndpoint) > 0 {
+		i -= len(m.ReflexiveEndpoint)
+		copy(dAtA[i:], m.ReflexiveEndpoint)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ReflexiveEndpoint)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.ExitNode) > 0 {
+		i -= len(m.ExitNode)
+		copy(dAtA[i:], m.ExitNode)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ExitNode)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.Tags) > 0 {
+		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Tags[iNdEx])
+			copy(dAtA[i:], m.Tags[iNdEx])
+			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Tags[iNdEx])))
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if len(m.PeerIPV6) > 0 {
+		i -= len(m.PeerIPV6)
+		copy(dAtA[i:], m.PeerIPV6)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.PeerIPV6)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.PublicKey) > 0 {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.PeerIP) > 0 {
+		i -= len(m.PeerIP)
+		copy(dAtA[i:], m.PeerIP)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.PeerIP)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Endpoint) > 0 {
+		i -= len(m.Endpoint)
+		copy(dAtA[i:], m.Endpoint)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Endpoint)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.AllowedIPs) > 0 {
+		for iNdEx := len(m.AllowedIPs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AllowedIPs[iNdEx])
+			copy(dAtA[i:], m.AllowedIPs[iNdEx])
+			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.AllowedIPs[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerTags) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerTags) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerTags) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Tags) > 0 {
+		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Tags[iNdEx])
+			copy(dAtA[i:], m.Tags[iNdEx])
+			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Tags[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeersRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Tag) > 0 {
+		i -= len(m.Tag)
+		copy(dAtA[i:], m.Tag)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Tag)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeersResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeimdall(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CheckPeerIPsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CheckPeerIPsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CheckPeerIPsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerIPConflict) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerIPConflict) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerIPConflict) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.PeerIDs) > 0 {
+		for iNdEx := len(m.PeerIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PeerIDs[iNdEx])
+			copy(dAtA[i:], m.PeerIDs[iNdEx])
+			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.PeerIDs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.IP) > 0 {
+		i -= len(m.IP)
+		copy(dAtA[i:], m.IP)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.IP)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CheckPeerIPsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CheckPeerIPsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CheckPeerIPsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Conflicts) > 0 {
+		for iNdEx := len(m.Conflicts) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Conflicts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeimdall(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Route) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Route) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Route) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Pending {
+		i--
+		if m.Pending {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.PeerID) > 0 {
+		i -= len(m.PeerID)
+		copy(dAtA[i:], m.PeerID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.PeerID)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Stale {
+		i--
+		if m.Stale {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.ActiveNodeID) > 0 {
+		i -= len(m.ActiveNodeID)
+		copy(dAtA[i:], m.ActiveNodeID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ActiveNodeID)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Nodes) > 0 {
+		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Nodes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeimdall(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Tags) > 0 {
+		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Tags[iNdEx])
+			copy(dAtA[i:], m.Tags[iNdEx])
+			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Tags[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Network) > 0 {
+		i -= len(m.Network)
+		copy(dAtA[i:], m.Network)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Network)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.NodeID) > 0 {
+		i -= len(m.NodeID)
+		copy(dAtA[i:], m.NodeID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.NodeID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RouteNode) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RouteNode) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RouteNode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Priority != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.NodeID) > 0 {
+		i -= len(m.NodeID)
+		copy(dAtA[i:], m.NodeID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.NodeID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CreateRouteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateRouteRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateRouteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Force {
+		i--
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Priority != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Tags) > 0 {
+		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Tags[iNdEx])
+			copy(dAtA[i:], m.Tags[iNdEx])
+			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Tags[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Network) > 0 {
+		i -= len(m.Network)
+		copy(dAtA[i:], m.Network)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Network)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.NodeID) > 0 {
+		i -= len(m.NodeID)
+		copy(dAtA[i:], m.NodeID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.NodeID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ApproveRouteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ApproveRouteRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ApproveRouteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Force {
+		i--
+		if m.Force {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Tags) > 0 {
+		for iNdEx := len(m.Tags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Tags[iNdEx])
+			copy(dAtA[i:], m.Tags[iNdEx])
+			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Tags[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Network) > 0 {
+		i -= len(m.Network)
+		copy(dAtA[i:], m.Network)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Network)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.PeerID) > 0 {
+		i -= len(m.PeerID)
+		copy(dAtA[i:], m.PeerID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.PeerID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerRoutes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerRoutes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerRoutes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Networks) > 0 {
+		for iNdEx := len(m.Networks) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Networks[iNdEx])
+			copy(dAtA[i:], m.Networks[iNdEx])
+			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Networks[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteRouteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteRouteRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteRouteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.NodeID) > 0 {
+		i -= len(m.NodeID)
+		copy(dAtA[i:], m.NodeID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.NodeID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Network) > 0 {
+		i -= len(m.Network)
+		copy(dAtA[i:], m.Network)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Network)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RoutesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RoutesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RoutesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RoutesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RoutesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RoutesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Routes) > 0 {
+		for iNdEx := len(m.Routes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Routes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeimdall(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Policy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Policy) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Policy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Tag) > 0 {
+		i -= len(m.Tag)
+		copy(dAtA[i:], m.Tag)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Tag)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Priority != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Action != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Action))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Ports) > 0 {
+		i -= len(m.Ports)
+		copy(dAtA[i:], m.Ports)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Ports)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Protocol) > 0 {
+		i -= len(m.Protocol)
+		copy(dAtA[i:], m.Protocol)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Protocol)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Destination) > 0 {
+		i -= len(m.Destination)
+		copy(dAtA[i:], m.Destination)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Destination)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.PeerID) > 0 {
+		i -= len(m.PeerID)
+		copy(dAtA[i:], m.PeerID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.PeerID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CreatePolicyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreatePolicyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreatePolicyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Policy != nil {
+		{
+			size, err := m.Policy.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeimdall(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeletePolicyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeletePolicyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeletePolicyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PoliciesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PoliciesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PoliciesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PoliciesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PoliciesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PoliciesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Policies) > 0 {
+		for iNdEx := len(m.Policies) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Policies[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeimdall(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestVoteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestVoteRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestVoteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ClusterKey) > 0 {
+		i -= len(m.ClusterKey)
+		copy(dAtA[i:], m.ClusterKey)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ClusterKey)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.CandidateID) > 0 {
+		i -= len(m.CandidateID)
+		copy(dAtA[i:], m.CandidateID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.CandidateID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Term != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestVoteResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestVoteResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestVoteResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Term != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Granted {
+		i--
+		if m.Granted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MasterRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MasterRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MasterRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MasterResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MasterResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MasterResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Master != nil {
+		{
+			size, err := m.Master.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeimdall(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReconcileConflict) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReconcileConflict) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReconcileConflict) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Resolution) > 0 {
+		i -= len(m.Resolution)
+		copy(dAtA[i:], m.Resolution)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Resolution)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Master) > 0 {
+		i -= len(m.Master)
+		copy(dAtA[i:], m.Master)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Master)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Local) > 0 {
+		i -= len(m.Local)
+		copy(dAtA[i:], m.Local)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Local)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Kind) > 0 {
+		i -= len(m.Kind)
+		copy(dAtA[i:], m.Kind)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Kind)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReconcileReport) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReconcileReport) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReconcileReport) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Deleted != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Deleted))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.Conflicts) > 0 {
+		for iNdEx := len(m.Conflicts) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Conflicts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeimdall(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if m.Restored != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Restored))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.DemotedTerm != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.DemotedTerm))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.DemotedID) > 0 {
+		i -= len(m.DemotedID)
+		copy(dAtA[i:], m.DemotedID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.DemotedID)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.MasterTerm != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.MasterTerm))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.MasterID) > 0 {
+		i -= len(m.MasterID)
+		copy(dAtA[i:], m.MasterID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.MasterID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	n8, err8 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Created):])
+	if err8 != nil {
+		return 0, err8
+	}
+	i -= n8
+	i = encodeVarintHeimdall(dAtA, i, uint64(n8))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *ReconcileReportsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReconcileReportsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReconcileReportsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReconcileReportsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReconcileReportsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReconcileReportsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Reports) > 0 {
+		for iNdEx := len(m.Reports) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Reports[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeimdall(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StepDownRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StepDownRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StepDownRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PromoteNodeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PromoteNodeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PromoteNodeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TakeMasterRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TakeMasterRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TakeMasterRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ClusterKey) > 0 {
+		i -= len(m.ClusterKey)
+		copy(dAtA[i:], m.ClusterKey)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ClusterKey)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Offset != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Term != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TakeMasterResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TakeMasterResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TakeMasterResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Master != nil {
+		{
+			size, err := m.Master.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeimdall(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RemoveNodeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoveNodeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RemoveNodeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ReassignRoutesTo) > 0 {
+		i -= len(m.ReassignRoutesTo)
+		copy(dAtA[i:], m.ReassignRoutesTo)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ReassignRoutesTo)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RestoreNodeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RestoreNodeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RestoreNodeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DrainNodeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DrainNodeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DrainNodeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Resume {
+		i--
+		if m.Resume {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WatchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Revision != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Revision))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WatchEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Policy != nil {
+		{
+			size, err := m.Policy.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeimdall(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Route != nil {
+		{
+			size, err := m.Route.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeimdall(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Peer != nil {
+		{
+			size, err := m.Peer.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeimdall(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Node != nil {
+		{
+			size, err := m.Node.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeimdall(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	n14, err14 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Created, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Created):])
+	if err14 != nil {
+		return 0, err14
+	}
+	i -= n14
+	i = encodeVarintHeimdall(dAtA, i, uint64(n14))
+	i--
+	dAtA[i] = 0x22
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Type != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Revision != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Revision))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SyncConfigRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SyncConfigRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SyncConfigRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Ack != nil {
+		{
+			size, err := m.Ack.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeimdall(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Connect != nil {
+		{
+			size, err := m.Connect.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeimdall(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConfigAck) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigAck) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConfigAck) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Version != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DesiredConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DesiredConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DesiredConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.DNS) > 0 {
+		for iNdEx := len(m.DNS) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DNS[iNdEx])
+			copy(dAtA[i:], m.DNS[iNdEx])
+			i = encodeVarintHeimdall(dAtA, i, uint64(len(m.DNS[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeimdall(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Version != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerConfigStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerConfigStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerConfigStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	n17, err17 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Disconnected, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Disconnected):])
+	if err17 != nil {
+		return 0, err17
+	}
+	i -= n17
+	i = encodeVarintHeimdall(dAtA, i, uint64(n17))
+	i--
+	dAtA[i] = 0x52
+	if m.Connected {
+		i--
+		if m.Connected {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x42
+	}
+	n18, err18 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Applied, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Applied):])
+	if err18 != nil {
+		return 0, err18
+	}
+	i -= n18
+	i = encodeVarintHeimdall(dAtA, i, uint64(n18))
+	i--
+	dAtA[i] = 0x3a
+	if m.AppliedVersion != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.AppliedVersion))
+		i--
+		dAtA[i] = 0x30
+	}
+	n19, err19 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Desired, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Desired):])
+	if err19 != nil {
+		return 0, err19
+	}
+	i -= n19
+	i = encodeVarintHeimdall(dAtA, i, uint64(n19))
+	i--
+	dAtA[i] = 0x2a
+	if len(m.DesiredHash) > 0 {
+		i -= len(m.DesiredHash)
+		copy(dAtA[i:], m.DesiredHash)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.DesiredHash)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.DesiredVersion != 0 {
+		i = encodeVarintHeimdall(dAtA, i, uint64(m.DesiredVersion))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.NodeID) > 0 {
+		i -= len(m.NodeID)
+		copy(dAtA[i:], m.NodeID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.NodeID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintHeimdall(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerConfigStatusesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerConfigStatusesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerConfigStatusesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerConfigStatusesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerConfigStatusesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerConfigStatusesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Statuses) > 0 {
+		for iNdEx := len(m.Statuses) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Statuses[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeimdall(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintHeimdall(dAtA []byte, offset int, v uint64) int {
+	offset -= sovHeimdall(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Master) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.GRPCAddress)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.RedisURL)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.GatewayIP)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if len(m.Peers) > 0 {
+		for _, e := range m.Peers {
+			l = e.Size()
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.Term != 0 {
+		n += 1 + sovHeimdall(uint64(m.Term))
+	}
+	if m.Revision != 0 {
+		n += 1 + sovHeimdall(uint64(m.Revision))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *JoinRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.ClusterKey)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.GRPCAddress)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.EndpointIP)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.EndpointPort != 0 {
+		n += 1 + sovHeimdall(uint64(m.EndpointPort))
+	}
+	l = len(m.InterfaceName)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.NodeSubnetSize != 0 {
+		n += 1 + sovHeimdall(uint64(m.NodeSubnetSize))
+	}
+	if m.NodeSubnetSizeV6 != 0 {
+		n += 1 + sovHeimdall(uint64(m.NodeSubnetSizeV6))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *JoinResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Master != nil {
+		l = m.Master.Size()
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.Node != nil {
+		l = m.Node.Size()
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if len(m.Peers) > 0 {
+		for _, e := range m.Peers {
+			l = e.Size()
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConnectRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.ExitNode)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if len(m.AdvertisedRoutes) > 0 {
+		for _, s := range m.AdvertisedRoutes {
+			l = len(s)
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.Mesh {
+		n += 2
+	}
+	if m.ListenPort != 0 {
+		n += 1 + sovHeimdall(uint64(m.ListenPort))
+	}
+	if len(m.LocalAddresses) > 0 {
+		for _, s := range m.LocalAddresses {
+			l = len(s)
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConnectResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if len(m.Peers) > 0 {
+		for _, e := range m.Peers {
+			l = e.Size()
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if len(m.DNS) > 0 {
+		for _, s := range m.DNS {
+			l = len(s)
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AuthorizePeerRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if len(m.IPs) > 0 {
+		for _, s := range m.IPs {
+			l = len(s)
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			l = len(s)
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeauthorizePeerRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AuthorizedPeersRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AuthorizedPeersResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.IDs) > 0 {
+		for _, s := range m.IDs {
+			l = len(s)
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerNetworkUsageRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *NetworkUsage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Network)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.Total != 0 {
+		n += 1 + sovHeimdall(uint64(m.Total))
+	}
+	if m.Allocated != 0 {
+		n += 1 + sovHeimdall(uint64(m.Allocated))
+	}
+	if m.Excluded != 0 {
+		n += 1 + sovHeimdall(uint64(m.Excluded))
+	}
+	if m.Available != 0 {
+		n += 1 + sovHeimdall(uint64(m.Available))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerNetworkUsageResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Networks) > 0 {
+		for _, e := range m.Networks {
+			l = e.Size()
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Node) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.Addr)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.EndpointIP)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.EndpointPort != 0 {
+		n += 1 + sovHeimdall(uint64(m.EndpointPort))
+	}
+	l = len(m.GatewayIP)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Updated)
+	n += 1 + l + sovHeimdall(uint64(l))
+	l = len(m.InterfaceName)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.Draining {
+		n += 2
+	}
+	if m.Healthy {
+		n += 2
+	}
+	l = len(m.HealthReason)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.LastHandshake)
+	n += 1 + l + sovHeimdall(uint64(l))
+	l = len(m.GatewayIPV6)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.ExitNode {
+		n += 3
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *NodesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *NodesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Nodes) > 0 {
+		for _, e := range m.Nodes {
+			l = e.Size()
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.Master != nil {
+		l = m.Master.Size()
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Peer) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if len(m.AllowedIPs) > 0 {
+		for _, s := range m.AllowedIPs {
+			l = len(s)
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	l = len(m.Endpoint)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.PeerIP)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.PublicKey)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.PeerIPV6)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			l = len(s)
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	l = len(m.ExitNode)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.ReflexiveEndpoint)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.RemoteAddress)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.Direct {
+		n += 2
+	}
+	if m.Mesh {
+		n += 2
+	}
+	if m.ListenPort != 0 {
+		n += 1 + sovHeimdall(uint64(m.ListenPort))
+	}
+	if len(m.LocalAddresses) > 0 {
+		for _, s := range m.LocalAddresses {
+			l = len(s)
+			n += 2 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerTags) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			l = len(s)
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeersRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Tag)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeersResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Peers) > 0 {
+		for _, e := range m.Peers {
+			l = e.Size()
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CheckPeerIPsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerIPConflict) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.IP)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if len(m.PeerIDs) > 0 {
+		for _, s := range m.PeerIDs {
+			l = len(s)
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CheckPeerIPsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Conflicts) > 0 {
+		for _, e := range m.Conflicts {
+			l = e.Size()
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Route) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NodeID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.Network)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			l = len(s)
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if len(m.Nodes) > 0 {
+		for _, e := range m.Nodes {
+			l = e.Size()
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	l = len(m.ActiveNodeID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.Stale {
+		n += 2
+	}
+	l = len(m.PeerID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.Pending {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RouteNode) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NodeID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.Priority != 0 {
+		n += 1 + sovHeimdall(uint64(m.Priority))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CreateRouteRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NodeID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.Network)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			l = len(s)
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.Priority != 0 {
+		n += 1 + sovHeimdall(uint64(m.Priority))
+	}
+	if m.Force {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ApproveRouteRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PeerID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.Network)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for _, s := range m.Tags {
+			l = len(s)
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.Force {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerRoutes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if len(m.Networks) > 0 {
+		for _, s := range m.Networks {
+			l = len(s)
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteRouteRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Network)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.NodeID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RoutesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RoutesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Routes) > 0 {
+		for _, e := range m.Routes {
+			l = e.Size()
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Policy) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.PeerID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.Destination)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.Protocol)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.Ports)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.Action != 0 {
+		n += 1 + sovHeimdall(uint64(m.Action))
+	}
+	if m.Priority != 0 {
+		n += 1 + sovHeimdall(uint64(m.Priority))
+	}
+	l = len(m.Tag)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CreatePolicyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Policy != nil {
+		l = m.Policy.Size()
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeletePolicyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PoliciesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PoliciesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Policies) > 0 {
+		for _, e := range m.Policies {
+			l = e.Size()
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestVoteRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Term != 0 {
+		n += 1 + sovHeimdall(uint64(m.Term))
+	}
+	l = len(m.CandidateID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.ClusterKey)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestVoteResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Granted {
+		n += 2
+	}
+	if m.Term != 0 {
+		n += 1 + sovHeimdall(uint64(m.Term))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *MasterRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *MasterResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Master != nil {
+		l = m.Master.Size()
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReconcileConflict) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Kind)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.Local)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.Master)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.Resolution)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReconcileReport) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Created)
+	n += 1 + l + sovHeimdall(uint64(l))
+	l = len(m.MasterID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.MasterTerm != 0 {
+		n += 1 + sovHeimdall(uint64(m.MasterTerm))
+	}
+	l = len(m.DemotedID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.DemotedTerm != 0 {
+		n += 1 + sovHeimdall(uint64(m.DemotedTerm))
+	}
+	if m.Restored != 0 {
+		n += 1 + sovHeimdall(uint64(m.Restored))
+	}
+	if len(m.Conflicts) > 0 {
+		for _, e := range m.Conflicts {
+			l = e.Size()
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.Deleted != 0 {
+		n += 1 + sovHeimdall(uint64(m.Deleted))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReconcileReportsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReconcileReportsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Reports) > 0 {
+		for _, e := range m.Reports {
+			l = e.Size()
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StepDownRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PromoteNodeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TakeMasterRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Term != 0 {
+		n += 1 + sovHeimdall(uint64(m.Term))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovHeimdall(uint64(m.Offset))
+	}
+	l = len(m.ClusterKey)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TakeMasterResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Master != nil {
+		l = m.Master.Size()
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RemoveNodeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.ReassignRoutesTo)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RestoreNodeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DrainNodeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.Resume {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WatchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Revision != 0 {
+		n += 1 + sovHeimdall(uint64(m.Revision))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *WatchEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Revision != 0 {
+		n += 1 + sovHeimdall(uint64(m.Revision))
+	}
+	if m.Type != 0 {
+		n += 1 + sovHeimdall(uint64(m.Type))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Created)
+	n += 1 + l + sovHeimdall(uint64(l))
+	if m.Node != nil {
+		l = m.Node.Size()
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.Peer != nil {
+		l = m.Peer.Size()
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.Route != nil {
+		l = m.Route.Size()
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.Policy != nil {
+		l = m.Policy.Size()
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SyncConfigRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Connect != nil {
+		l = m.Connect.Size()
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.Ack != nil {
+		l = m.Ack.Size()
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConfigAck) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovHeimdall(uint64(m.Version))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DesiredConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovHeimdall(uint64(m.Version))
+	}
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if len(m.Peers) > 0 {
+		for _, e := range m.Peers {
+			l = e.Size()
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if len(m.DNS) > 0 {
+		for _, s := range m.DNS {
+			l = len(s)
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerConfigStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = len(m.NodeID)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.DesiredVersion != 0 {
+		n += 1 + sovHeimdall(uint64(m.DesiredVersion))
+	}
+	l = len(m.DesiredHash)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Desired)
+	n += 1 + l + sovHeimdall(uint64(l))
+	if m.AppliedVersion != 0 {
+		n += 1 + sovHeimdall(uint64(m.AppliedVersion))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Applied)
+	n += 1 + l + sovHeimdall(uint64(l))
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovHeimdall(uint64(l))
+	}
+	if m.Connected {
+		n += 2
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Disconnected)
+	n += 1 + l + sovHeimdall(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerConfigStatusesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerConfigStatusesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Statuses) > 0 {
+		for _, e := range m.Statuses {
+			l = e.Size()
+			n += 1 + l + sovHeimdall(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovHeimdall(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozHeimdall(x uint64) (n int) {
+	return sovHeimdall(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Master) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Master: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Master: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GRPCAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GRPCAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RedisURL", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RedisURL = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayIP", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayIP = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers, &Peer{})
+			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
+			}
+			m.Revision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Revision |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JoinRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JoinRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JoinRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClusterKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GRPCAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GRPCAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndpointIP", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EndpointIP = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndpointPort", wireType)
+			}
+			m.EndpointPort = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndpointPort |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InterfaceName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InterfaceName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeSubnetSize", wireType)
+			}
+			m.NodeSubnetSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NodeSubnetSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeSubnetSizeV6", wireType)
+			}
+			m.NodeSubnetSizeV6 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NodeSubnetSizeV6 |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JoinResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JoinResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JoinResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Master", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Master == nil {
+				m.Master = &Master{}
+			}
+			if err := m.Master.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Node", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Node == nil {
+				m.Node = &Node{}
+			}
+			if err := m.Node.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers, &Peer{})
+			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConnectRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExitNode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExitNode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AdvertisedRoutes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AdvertisedRoutes = append(m.AdvertisedRoutes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mesh", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Mesh = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ListenPort", wireType)
+			}
+			m.ListenPort = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ListenPort |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LocalAddresses", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LocalAddresses = append(m.LocalAddresses, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnectResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConnectResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConnectResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers, &Peer{})
+			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DNS", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DNS = append(m.DNS, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AuthorizePeerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AuthorizePeerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AuthorizePeerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IPs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IPs = append(m.IPs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeauthorizePeerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeauthorizePeerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeauthorizePeerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AuthorizedPeersRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AuthorizedPeersRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AuthorizedPeersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AuthorizedPeersResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AuthorizedPeersResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AuthorizedPeersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IDs = append(m.IDs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerNetworkUsageRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerNetworkUsageRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerNetworkUsageRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NetworkUsage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NetworkUsage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NetworkUsage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Network", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Network = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			m.Total = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Total |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Allocated", wireType)
+			}
+			m.Allocated = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Allocated |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Excluded", wireType)
+			}
+			m.Excluded = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Excluded |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Available", wireType)
+			}
+			m.Available = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Available |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerNetworkUsageResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerNetworkUsageResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerNetworkUsageResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Networks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Networks = append(m.Networks, &NetworkUsage{})
+			if err := m.Networks[len(m.Networks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Node) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Node: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Node: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndpointIP", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EndpointIP = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndpointPort", wireType)
+			}
+			m.EndpointPort = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndpointPort |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayIP", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayIP = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Updated, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InterfaceName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InterfaceName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Draining", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Draining = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Healthy", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Healthy = bool(v != 0)
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HealthReason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HealthReason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastHandshake", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.LastHandshake, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayIPV6", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GatewayIPV6 = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExitNode", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ExitNode = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NodesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NodesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NodesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NodesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NodesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NodesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nodes = append(m.Nodes, &Node{})
+			if err := m.Nodes[len(m.Nodes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Master", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Master == nil {
+				m.Master = &Master{}
+			}
+			if err := m.Master.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Peer) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Peer: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Peer: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedIPs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AllowedIPs = append(m.AllowedIPs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Endpoint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerIP", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerIP = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerIPV6", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerIPV6 = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExitNode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExitNode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReflexiveEndpoint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReflexiveEndpoint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoteAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RemoteAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Direct", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Direct = bool(v != 0)
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mesh", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Mesh = bool(v != 0)
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ListenPort", wireType)
+			}
+			m.ListenPort = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ListenPort |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LocalAddresses", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LocalAddresses = append(m.LocalAddresses, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerTags) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerTags: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerTags: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeersRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeersRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tag", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tag = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeersResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeersResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers, &Peer{})
+			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CheckPeerIPsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckPeerIPsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckPeerIPsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerIPConflict) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerIPConflict: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerIPConflict: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IP", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IP = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerIDs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerIDs = append(m.PeerIDs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CheckPeerIPsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckPeerIPsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckPeerIPsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Conflicts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Conflicts = append(m.Conflicts, &PeerIPConflict{})
+			if err := m.Conflicts[len(m.Conflicts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Route) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Route: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Route: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Network", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Network = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nodes = append(m.Nodes, &RouteNode{})
+			if err := m.Nodes[len(m.Nodes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActiveNodeID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActiveNodeID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stale", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Stale = bool(v != 0)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pending", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Pending = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RouteNode) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RouteNode: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RouteNode: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateRouteRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateRouteRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateRouteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Network", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Network = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Force = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ApproveRouteRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ApproveRouteRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ApproveRouteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Network", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Network = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Force", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Force = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerRoutes) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerRoutes: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerRoutes: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Networks", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Networks = append(m.Networks, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteRouteRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteRouteRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteRouteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Network", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Network = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *RoutesRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
-
-func (m *RoutesResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Routes) > 0 {
-		for _, e := range m.Routes {
-			l = e.Size()
-			n += 1 + l + sovHeimdall(uint64(l))
+func (m *RoutesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RoutesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RoutesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
+func (m *RoutesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RoutesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RoutesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Routes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Routes = append(m.Routes, &Route{})
+			if err := m.Routes[len(m.Routes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
 
-func sovHeimdall(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozHeimdall(x uint64) (n int) {
-	return sovHeimdall(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *Master) Unmarshal(dAtA []byte) error {
+func (m *Policy) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3231,15 +14228,111 @@ func (m *Master) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Master: wiretype end group for non-group")
+			return fmt.Errorf("proto: Policy: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Master: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Policy: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Destination", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Destination = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Protocol", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3267,11 +14360,11 @@ func (m *Master) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
+			m.Protocol = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GRPCAddress", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ports", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3299,11 +14392,49 @@ func (m *Master) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GRPCAddress = string(dAtA[iNdEx:postIndex])
+			m.Ports = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
+			}
+			m.Action = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Action |= Policy_Action(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RedisURL", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tag", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3331,11 +14462,149 @@ func (m *Master) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RedisURL = string(dAtA[iNdEx:postIndex])
+			m.Tag = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreatePolicyRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreatePolicyRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreatePolicyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GatewayIP", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Policy == nil {
+				m.Policy = &Policy{}
+			}
+			if err := m.Policy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeletePolicyRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeletePolicyRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeletePolicyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3360,14 +14629,116 @@ func (m *Master) Unmarshal(dAtA []byte) error {
 			if postIndex < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			if postIndex > l {
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PoliciesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PoliciesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PoliciesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PoliciesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GatewayIP = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PoliciesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PoliciesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Policies", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3394,8 +14765,8 @@ func (m *Master) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Peers = append(m.Peers, &Peer{})
-			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Policies = append(m.Policies, &Policy{})
+			if err := m.Policies[len(m.Policies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3421,7 +14792,7 @@ func (m *Master) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *JoinRequest) Unmarshal(dAtA []byte) error {
+func (m *RequestVoteRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3444,17 +14815,17 @@ func (m *JoinRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: JoinRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RequestVoteRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JoinRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RequestVoteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
 			}
-			var stringLen uint64
+			m.Term = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -3464,27 +14835,14 @@ func (m *JoinRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Term |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHeimdall
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeimdall
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClusterKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CandidateID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3512,11 +14870,11 @@ func (m *JoinRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ClusterKey = string(dAtA[iNdEx:postIndex])
+			m.CandidateID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GRPCAddress", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterKey", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3544,45 +14902,64 @@ func (m *JoinRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GRPCAddress = string(dAtA[iNdEx:postIndex])
+			m.ClusterKey = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndpointIP", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeimdall
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeimdall
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			if postIndex > l {
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestVoteResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EndpointIP = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestVoteResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestVoteResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndpointPort", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Granted", wireType)
 			}
-			m.EndpointPort = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -3592,16 +14969,17 @@ func (m *JoinRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.EndpointPort |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InterfaceName", wireType)
+			m.Granted = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
 			}
-			var stringLen uint64
+			m.Term = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -3611,29 +14989,118 @@ func (m *JoinRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Term |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MasterRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MasterRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MasterRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.InterfaceName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 7:
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MasterResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MasterResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MasterResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Master", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -3643,23 +15110,27 @@ func (m *JoinRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeimdall
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			if m.Master == nil {
+				m.Master = &Master{}
+			}
+			if err := m.Master.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3683,7 +15154,7 @@ func (m *JoinRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *JoinResponse) Unmarshal(dAtA []byte) error {
+func (m *ReconcileConflict) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3706,17 +15177,17 @@ func (m *JoinResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: JoinResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReconcileConflict: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JoinResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReconcileConflict: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Master", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -3726,33 +15197,29 @@ func (m *JoinResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeimdall
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Master == nil {
-				m.Master = &Master{}
-			}
-			if err := m.Master.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Kind = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Node", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -3762,33 +15229,29 @@ func (m *JoinResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeimdall
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Node == nil {
-				m.Node = &Node{}
-			}
-			if err := m.Node.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Key = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Local", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -3798,80 +15261,27 @@ func (m *JoinResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeimdall
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Peers = append(m.Peers, &Peer{})
-			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Local = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipHeimdall(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthHeimdall
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowHeimdall
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ConnectRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Master", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3899,11 +15309,11 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
+			m.Master = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Resolution", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3931,7 +15341,7 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Resolution = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3955,7 +15365,7 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConnectResponse) Unmarshal(dAtA []byte) error {
+func (m *ReconcileReport) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3978,15 +15388,15 @@ func (m *ConnectResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConnectResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReconcileReport: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConnectResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReconcileReport: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KeyPair", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4013,16 +15423,13 @@ func (m *ConnectResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.KeyPair == nil {
-				m.KeyPair = &KeyPair{}
-			}
-			if err := m.KeyPair.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Created, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MasterID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4050,13 +15457,32 @@ func (m *ConnectResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Address = string(dAtA[iNdEx:postIndex])
+			m.MasterID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MasterTerm", wireType)
+			}
+			m.MasterTerm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MasterTerm |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DemotedID", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -4066,31 +15492,67 @@ func (m *ConnectResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeimdall
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Peers = append(m.Peers, &Peer{})
-			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.DemotedID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DemotedTerm", wireType)
+			}
+			m.DemotedTerm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DemotedTerm |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Restored", wireType)
+			}
+			m.Restored = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Restored |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DNS", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Conflicts", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -4100,24 +15562,45 @@ func (m *ConnectResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeimdall
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DNS = append(m.DNS, string(dAtA[iNdEx:postIndex]))
+			m.Conflicts = append(m.Conflicts, &ReconcileConflict{})
+			if err := m.Conflicts[len(m.Conflicts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deleted", wireType)
+			}
+			m.Deleted = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Deleted |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeimdall(dAtA[iNdEx:])
@@ -4140,7 +15623,7 @@ func (m *ConnectResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AuthorizePeerRequest) Unmarshal(dAtA []byte) error {
+func (m *ReconcileReportsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4148,59 +15631,27 @@ func (m *AuthorizePeerRequest) Unmarshal(dAtA []byte) error {
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
 			if shift >= 64 {
-				return ErrIntOverflowHeimdall
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AuthorizePeerRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AuthorizePeerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeimdall
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHeimdall
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeimdall
+				return ErrIntOverflowHeimdall
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReconcileReportsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReconcileReportsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeimdall(dAtA[iNdEx:])
@@ -4223,7 +15674,7 @@ func (m *AuthorizePeerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeauthorizePeerRequest) Unmarshal(dAtA []byte) error {
+func (m *ReconcileReportsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4246,17 +15697,17 @@ func (m *DeauthorizePeerRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeauthorizePeerRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReconcileReportsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeauthorizePeerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReconcileReportsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Reports", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -4266,23 +15717,25 @@ func (m *DeauthorizePeerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeimdall
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
+			m.Reports = append(m.Reports, &ReconcileReport{})
+			if err := m.Reports[len(m.Reports)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4306,7 +15759,7 @@ func (m *DeauthorizePeerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AuthorizedPeersRequest) Unmarshal(dAtA []byte) error {
+func (m *StepDownRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4329,10 +15782,10 @@ func (m *AuthorizedPeersRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AuthorizedPeersRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: StepDownRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AuthorizedPeersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StepDownRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -4357,7 +15810,7 @@ func (m *AuthorizedPeersRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AuthorizedPeersResponse) Unmarshal(dAtA []byte) error {
+func (m *PromoteNodeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4380,15 +15833,15 @@ func (m *AuthorizedPeersResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AuthorizedPeersResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: PromoteNodeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AuthorizedPeersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PromoteNodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4416,7 +15869,7 @@ func (m *AuthorizedPeersResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IDs = append(m.IDs, string(dAtA[iNdEx:postIndex]))
+			m.ID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4440,7 +15893,7 @@ func (m *AuthorizedPeersResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *KeyPair) Unmarshal(dAtA []byte) error {
+func (m *TakeMasterRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4463,17 +15916,17 @@ func (m *KeyPair) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: KeyPair: wiretype end group for non-group")
+			return fmt.Errorf("proto: TakeMasterRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: KeyPair: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TakeMasterRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PrivateKey", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
 			}
-			var stringLen uint64
+			m.Term = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -4483,27 +15936,33 @@ func (m *KeyPair) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Term |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHeimdall
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeimdall
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.PrivateKey = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterKey", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4531,7 +15990,7 @@ func (m *KeyPair) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PublicKey = string(dAtA[iNdEx:postIndex])
+			m.ClusterKey = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4555,7 +16014,7 @@ func (m *KeyPair) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Node) Unmarshal(dAtA []byte) error {
+func (m *TakeMasterResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4578,79 +16037,15 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Node: wiretype end group for non-group")
+			return fmt.Errorf("proto: TakeMasterResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Node: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TakeMasterResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeimdall
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHeimdall
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeimdall
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeimdall
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHeimdall
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeimdall
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Addr = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KeyPair", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Master", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4677,101 +16072,69 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.KeyPair == nil {
-				m.KeyPair = &KeyPair{}
+			if m.Master == nil {
+				m.Master = &Master{}
 			}
-			if err := m.KeyPair.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Master.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndpointIP", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeimdall
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHeimdall
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EndpointIP = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndpointPort", wireType)
-			}
-			m.EndpointPort = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeimdall
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.EndpointPort |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GatewayIP", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeimdall
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthHeimdall
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeimdall
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoveNodeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GatewayIP = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 7:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoveNodeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoveNodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -4781,28 +16144,27 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeimdall
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Updated, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InterfaceName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReassignRoutesTo", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4830,11 +16192,62 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.InterfaceName = string(dAtA[iNdEx:postIndex])
+			m.ReassignRoutesTo = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 9:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RestoreNodeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RestoreNodeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RestoreNodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4862,7 +16275,7 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.ID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4886,7 +16299,7 @@ func (m *Node) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NodesRequest) Unmarshal(dAtA []byte) error {
+func (m *DrainNodeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4909,12 +16322,64 @@ func (m *NodesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NodesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DrainNodeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NodesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DrainNodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resume", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Resume = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeimdall(dAtA[iNdEx:])
@@ -4937,7 +16402,7 @@ func (m *NodesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NodesResponse) Unmarshal(dAtA []byte) error {
+func (m *WatchRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4960,17 +16425,17 @@ func (m *NodesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NodesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: WatchRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NodesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WatchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
 			}
-			var msglen int
+			m.Revision = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -4980,26 +16445,11 @@ func (m *NodesResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Revision |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHeimdall
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeimdall
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Nodes = append(m.Nodes, &Node{})
-			if err := m.Nodes[len(m.Nodes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeimdall(dAtA[iNdEx:])
@@ -5022,7 +16472,7 @@ func (m *NodesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Peer) Unmarshal(dAtA []byte) error {
+func (m *WatchEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5045,13 +16495,51 @@ func (m *Peer) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Peer: wiretype end group for non-group")
+			return fmt.Errorf("proto: WatchEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Peer: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WatchEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
+			}
+			m.Revision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Revision |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= WatchEvent_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
@@ -5070,22 +16558,91 @@ func (m *Peer) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Created, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Node", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeimdall
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
+			if m.Node == nil {
+				m.Node = &Node{}
+			}
+			if err := m.Node.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 2:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KeyPair", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5112,18 +16669,18 @@ func (m *Peer) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.KeyPair == nil {
-				m.KeyPair = &KeyPair{}
+			if m.Peer == nil {
+				m.Peer = &Peer{}
 			}
-			if err := m.KeyPair.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AllowedIPs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Route", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -5133,29 +16690,33 @@ func (m *Peer) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeimdall
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AllowedIPs = append(m.AllowedIPs, string(dAtA[iNdEx:postIndex]))
+			if m.Route == nil {
+				m.Route = &Route{}
+			}
+			if err := m.Route.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 4:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -5165,29 +16726,84 @@ func (m *Peer) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeimdall
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Endpoint = string(dAtA[iNdEx:postIndex])
+			if m.Policy == nil {
+				m.Policy = &Policy{}
+			}
+			if err := m.Policy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 5:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeimdall(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SyncConfigRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeimdall
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SyncConfigRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SyncConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PeerIP", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Connect", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -5197,29 +16813,33 @@ func (m *Peer) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeimdall
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PeerIP = string(dAtA[iNdEx:postIndex])
+			if m.Connect == nil {
+				m.Connect = &ConnectRequest{}
+			}
+			if err := m.Connect.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ack", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -5229,23 +16849,27 @@ func (m *Peer) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeimdall
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			if m.Ack == nil {
+				m.Ack = &ConfigAck{}
+			}
+			if err := m.Ack.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -5269,7 +16893,7 @@ func (m *Peer) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PeersRequest) Unmarshal(dAtA []byte) error {
+func (m *ConfigAck) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5292,12 +16916,63 @@ func (m *PeersRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PeersRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConfigAck: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PeersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConfigAck: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeimdall(dAtA[iNdEx:])
@@ -5320,7 +16995,7 @@ func (m *PeersRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PeersResponse) Unmarshal(dAtA []byte) error {
+func (m *DesiredConfig) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5343,17 +17018,102 @@ func (m *PeersResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PeersResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: DesiredConfig: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PeersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DesiredConfig: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers, &Peer{})
+			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DNS", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -5363,25 +17123,23 @@ func (m *PeersResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeimdall
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Peers = append(m.Peers, &Peer{})
-			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.DNS = append(m.DNS, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -5405,7 +17163,7 @@ func (m *PeersResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Route) Unmarshal(dAtA []byte) error {
+func (m *PeerConfigStatus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5428,15 +17186,15 @@ func (m *Route) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Route: wiretype end group for non-group")
+			return fmt.Errorf("proto: PeerConfigStatus: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Route: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PeerConfigStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5464,11 +17222,11 @@ func (m *Route) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NodeID = string(dAtA[iNdEx:postIndex])
+			m.ID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Network", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5496,62 +17254,30 @@ func (m *Route) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Network = string(dAtA[iNdEx:postIndex])
+			m.NodeID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipHeimdall(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthHeimdall
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CreateRouteRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowHeimdall
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DesiredVersion", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.DesiredVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DesiredVersion |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CreateRouteRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateRouteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DesiredHash", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5579,13 +17305,13 @@ func (m *CreateRouteRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NodeID = string(dAtA[iNdEx:postIndex])
+			m.DesiredHash = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Network", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Desired", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeimdall
@@ -5595,78 +17321,80 @@ func (m *CreateRouteRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthHeimdall
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeimdall
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Network = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipHeimdall(dAtA[iNdEx:])
-			if err != nil {
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Desired, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthHeimdall
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppliedVersion", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			m.AppliedVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppliedVersion |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *DeleteRouteRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowHeimdall
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Applied", wireType)
 			}
-			if iNdEx >= l {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Applied, dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteRouteRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteRouteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Network", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5694,7 +17422,60 @@ func (m *DeleteRouteRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Network = string(dAtA[iNdEx:postIndex])
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connected", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Connected = bool(v != 0)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Disconnected", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeimdall
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeimdall
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Disconnected, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -5718,7 +17499,7 @@ func (m *DeleteRouteRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RoutesRequest) Unmarshal(dAtA []byte) error {
+func (m *PeerConfigStatusesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5741,10 +17522,10 @@ func (m *RoutesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RoutesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PeerConfigStatusesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RoutesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PeerConfigStatusesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -5769,7 +17550,7 @@ func (m *RoutesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RoutesResponse) Unmarshal(dAtA []byte) error {
+func (m *PeerConfigStatusesResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5792,15 +17573,15 @@ func (m *RoutesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RoutesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: PeerConfigStatusesResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RoutesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PeerConfigStatusesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Routes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Statuses", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5827,8 +17608,8 @@ func (m *RoutesResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Routes = append(m.Routes, &Route{})
-			if err := m.Routes[len(m.Routes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Statuses = append(m.Statuses, &PeerConfigStatus{})
+			if err := m.Statuses[len(m.Statuses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
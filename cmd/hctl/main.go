@@ -58,6 +58,8 @@ func main() {
 		nodesCommand,
 		peersCommand,
 		routesCommand,
+		policyCommand,
+		watchCommand,
 	}
 
 	if err := app.Run(os.Args); err != nil {
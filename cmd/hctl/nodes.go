@@ -3,7 +3,9 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
+	"strconv"
 	"text/tabwriter"
 
 	humanize "github.com/dustin/go-humanize"
@@ -16,6 +18,13 @@ var nodesCommand = cli.Command{
 	Usage: "node management",
 	Subcommands: []cli.Command{
 		listNodesCommand,
+		conflictsCommand,
+		promoteNodeCommand,
+		stepDownCommand,
+		removeNodeCommand,
+		restoreNodeCommand,
+		drainNodeCommand,
+		undrainNodeCommand,
 	},
 }
 
@@ -36,14 +45,216 @@ var listNodesCommand = cli.Command{
 			return err
 		}
 
+		if m := resp.Master; m != nil {
+			fmt.Printf("master: %s (term %d)\n", m.ID, m.Term)
+		}
+
 		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
-		fmt.Fprintf(w, "ID\tADDR\tENDPOINT\tGATEWAY\tUPDATED\tPUBLIC KEY\n")
+		fmt.Fprintf(w, "ID\tADDR\tENDPOINT\tGATEWAY\tSTATE\tHEALTH\tUPDATED\tHANDSHAKE\tPUBLIC KEY\n")
 		for _, n := range resp.Nodes {
-			ep := fmt.Sprintf("%s:%d", n.EndpointIP, n.EndpointPort)
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", n.ID, n.Addr, ep, n.GatewayIP, humanize.Time(n.Updated), n.KeyPair.PublicKey)
+			ep := net.JoinHostPort(n.EndpointIP, strconv.FormatUint(n.EndpointPort, 10))
+			gateway := n.GatewayIP
+			if n.GatewayIPV6 != "" {
+				gateway += ", " + n.GatewayIPV6
+			}
+			state := "active"
+			if n.Draining {
+				state = "draining"
+			}
+			if n.ExitNode {
+				state += ", exit"
+			}
+			health := "healthy"
+			if !n.Healthy {
+				health = fmt.Sprintf("unhealthy (%s)", n.HealthReason)
+			}
+			handshake := "-"
+			if !n.LastHandshake.IsZero() {
+				handshake = humanize.Time(n.LastHandshake)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", n.ID, n.Addr, ep, gateway, state, health, humanize.Time(n.Updated), handshake, n.PublicKey)
 		}
 		w.Flush()
 
 		return nil
 	},
 }
+
+var conflictsCommand = cli.Command{
+	Name:  "conflicts",
+	Usage: "show conflicts reconciled after a split brain",
+	Action: func(cx *cli.Context) error {
+		c, err := getClient(cx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx := context.Background()
+
+		resp, err := c.ReconcileReports(ctx, &v1.ReconcileReportsRequest{})
+		if err != nil {
+			return err
+		}
+
+		for _, r := range resp.Reports {
+			fmt.Printf("%s: demoted %s (term %d) in favor of %s (term %d); restored %d; deleted %d\n",
+				humanize.Time(r.Created), r.DemotedID, r.DemotedTerm, r.MasterID, r.MasterTerm, r.Restored, r.Deleted)
+			if len(r.Conflicts) == 0 {
+				continue
+			}
+			w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+			fmt.Fprintf(w, "KIND\tKEY\tLOCAL\tMASTER\tRESOLUTION\n")
+			for _, conflict := range r.Conflicts {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", conflict.Kind, conflict.Key, conflict.Local, conflict.Master, conflict.Resolution)
+			}
+			w.Flush()
+		}
+
+		return nil
+	},
+}
+
+var promoteNodeCommand = cli.Command{
+	Name:      "promote",
+	Usage:     "promote node to master",
+	ArgsUsage: "<id>",
+	Action: func(cx *cli.Context) error {
+		c, err := getClient(cx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx := context.Background()
+
+		id := cx.Args().First()
+		if id == "" {
+			return fmt.Errorf("ID cannot be empty")
+		}
+		if _, err := c.PromoteNode(ctx, &v1.PromoteNodeRequest{
+			ID: id,
+		}); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+var stepDownCommand = cli.Command{
+	Name:  "step-down",
+	Usage: "hand off the master role to an in sync replica",
+	Action: func(cx *cli.Context) error {
+		c, err := getClient(cx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx := context.Background()
+
+		if _, err := c.StepDown(ctx, &v1.StepDownRequest{}); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+var removeNodeCommand = cli.Command{
+	Name:      "remove",
+	Usage:     "remove node from the cluster",
+	ArgsUsage: "<id>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "reassign-routes",
+			Usage: "reassign the routes of the node to another node instead of deleting them",
+		},
+	},
+	Action: func(cx *cli.Context) error {
+		c, err := getClient(cx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx := context.Background()
+
+		id := cx.Args().First()
+		if id == "" {
+			return fmt.Errorf("ID cannot be empty")
+		}
+		if _, err := c.RemoveNode(ctx, &v1.RemoveNodeRequest{
+			ID:               id,
+			ReassignRoutesTo: cx.String("reassign-routes"),
+		}); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+var restoreNodeCommand = cli.Command{
+	Name:      "restore",
+	Usage:     "allow a removed node to rejoin the cluster",
+	ArgsUsage: "<id>",
+	Action: func(cx *cli.Context) error {
+		c, err := getClient(cx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx := context.Background()
+
+		id := cx.Args().First()
+		if id == "" {
+			return fmt.Errorf("ID cannot be empty")
+		}
+		if _, err := c.RestoreNode(ctx, &v1.RestoreNodeRequest{
+			ID: id,
+		}); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+var drainNodeCommand = cli.Command{
+	Name:      "drain",
+	Usage:     "stop advertising node as a dns server and gateway to peers",
+	ArgsUsage: "<id>",
+	Action: func(cx *cli.Context) error {
+		return drainNode(cx, false)
+	},
+}
+
+var undrainNodeCommand = cli.Command{
+	Name:      "undrain",
+	Usage:     "resume advertising a drained node to peers",
+	ArgsUsage: "<id>",
+	Action: func(cx *cli.Context) error {
+		return drainNode(cx, true)
+	},
+}
+
+func drainNode(cx *cli.Context, resume bool) error {
+	c, err := getClient(cx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+
+	id := cx.Args().First()
+	if id == "" {
+		return fmt.Errorf("ID cannot be empty")
+	}
+	if _, err := c.DrainNode(ctx, &v1.DrainNodeRequest{
+		ID:     id,
+		Resume: resume,
+	}); err != nil {
+		return err
+	}
+	return nil
+}
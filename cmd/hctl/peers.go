@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
+	humanize "github.com/dustin/go-humanize"
 	v1 "github.com/ehazlett/heimdall/api/v1"
 	"github.com/urfave/cli"
 )
@@ -18,12 +20,21 @@ var peersCommand = cli.Command{
 		authorizedPeersCommand,
 		authorizePeerCommand,
 		deauthorizePeerCommand,
+		checkPeerIPsCommand,
+		peerConfigStatusCommand,
+		peerNetworkUsageCommand,
 	},
 }
 
 var listPeersCommand = cli.Command{
 	Name:  "list",
 	Usage: "list peers",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "tag",
+			Usage: "only list peers with the tag",
+		},
+	},
 	Action: func(cx *cli.Context) error {
 		c, err := getClient(cx)
 		if err != nil {
@@ -33,15 +44,25 @@ var listPeersCommand = cli.Command{
 
 		ctx := context.Background()
 
-		resp, err := c.Peers(ctx, &v1.PeersRequest{})
+		resp, err := c.Peers(ctx, &v1.PeersRequest{
+			Tag: cx.String("tag"),
+		})
 		if err != nil {
 			return err
 		}
 
 		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
-		fmt.Fprintf(w, "ID\tPUBLIC KEY\tENDPOINT\tALLOWED\tPEER IP\n")
+		fmt.Fprintf(w, "ID\tPUBLIC KEY\tENDPOINT\tALLOWED\tPEER IP\tTAGS\tREMOTE\n")
 		for _, p := range resp.Peers {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p.ID, p.KeyPair.PublicKey, p.Endpoint, p.AllowedIPs, p.PeerIP)
+			peerIP := p.PeerIP
+			if p.PeerIPV6 != "" {
+				peerIP += ", " + p.PeerIPV6
+			}
+			endpoint := p.Endpoint
+			if endpoint == "" && p.ReflexiveEndpoint != "" {
+				endpoint = p.ReflexiveEndpoint + " (reflexive)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", p.ID, p.PublicKey, endpoint, p.AllowedIPs, peerIP, strings.Join(p.Tags, ","), p.RemoteAddress)
 		}
 		w.Flush()
 
@@ -78,6 +99,18 @@ var authorizedPeersCommand = cli.Command{
 var authorizePeerCommand = cli.Command{
 	Name:  "authorize",
 	Usage: "authorize peer to cluster",
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name:  "ip",
+			Usage: "static peer ip to reserve (one per address family)",
+			Value: &cli.StringSlice{},
+		},
+		cli.StringSliceFlag{
+			Name:  "tag",
+			Usage: "tag to assign to the peer (replaces existing tags)",
+			Value: &cli.StringSlice{},
+		},
+	},
 	Action: func(cx *cli.Context) error {
 		c, err := getClient(cx)
 		if err != nil {
@@ -92,7 +125,9 @@ var authorizePeerCommand = cli.Command{
 			return fmt.Errorf("ID cannot be empty")
 		}
 		if _, err := c.AuthorizePeer(ctx, &v1.AuthorizePeerRequest{
-			ID: id,
+			ID:   id,
+			IPs:  cx.StringSlice("ip"),
+			Tags: cx.StringSlice("tag"),
 		}); err != nil {
 			return err
 		}
@@ -124,3 +159,116 @@ var deauthorizePeerCommand = cli.Command{
 		return nil
 	},
 }
+
+var checkPeerIPsCommand = cli.Command{
+	Name:  "check",
+	Usage: "check peer ip allocations for duplicates",
+	Action: func(cx *cli.Context) error {
+		c, err := getClient(cx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx := context.Background()
+
+		resp, err := c.CheckPeerIPs(ctx, &v1.CheckPeerIPsRequest{})
+		if err != nil {
+			return err
+		}
+		if len(resp.Conflicts) == 0 {
+			fmt.Println("no duplicate peer ips found")
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "IP\tPEERS\n")
+		for _, conflict := range resp.Conflicts {
+			fmt.Fprintf(w, "%s\t%s\n", conflict.IP, strings.Join(conflict.PeerIDs, ","))
+		}
+		w.Flush()
+		return fmt.Errorf("found %d duplicate peer ips", len(resp.Conflicts))
+	},
+}
+
+var peerConfigStatusCommand = cli.Command{
+	Name:  "status",
+	Usage: "show the config versions applied by peers",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "lagging",
+			Usage: "only show peers that have not applied the desired config",
+		},
+	},
+	Action: func(cx *cli.Context) error {
+		c, err := getClient(cx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx := context.Background()
+
+		resp, err := c.PeerConfigStatuses(ctx, &v1.PeerConfigStatusesRequest{})
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "ID\tNODE\tDESIRED\tAPPLIED\tSTATE\tAPPLIED AT\n")
+		for _, s := range resp.Statuses {
+			lagging := s.AppliedVersion < s.DesiredVersion
+			if cx.Bool("lagging") && !lagging {
+				continue
+			}
+			state := "in sync"
+			switch {
+			case s.Error != "":
+				state = fmt.Sprintf("error: %s", s.Error)
+			case lagging:
+				state = "lagging"
+			}
+			if !s.Connected {
+				state += " (disconnected)"
+			}
+			applied := "-"
+			if !s.Applied.IsZero() {
+				applied = humanize.Time(s.Applied)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\n", s.ID, s.NodeID, s.DesiredVersion, s.AppliedVersion, state, applied)
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+var peerNetworkUsageCommand = cli.Command{
+	Name:  "usage",
+	Usage: "show peer network address utilization",
+	Action: func(cx *cli.Context) error {
+		c, err := getClient(cx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx := context.Background()
+
+		resp, err := c.PeerNetworkUsage(ctx, &v1.PeerNetworkUsageRequest{})
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "NETWORK\tTOTAL\tALLOCATED\tEXCLUDED\tAVAILABLE\tUTILIZATION\n")
+		for _, n := range resp.Networks {
+			utilization := 0.0
+			if n.Total > 0 {
+				utilization = float64(n.Allocated) / float64(n.Total) * 100
+			}
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%.1f%%\n", n.Network, n.Total, n.Allocated, n.Excluded, n.Available, utilization)
+		}
+		w.Flush()
+		return nil
+	},
+}
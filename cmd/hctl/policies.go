@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/urfave/cli"
+)
+
+var policyCommand = cli.Command{
+	Name:  "policy",
+	Usage: "access policy management",
+	Subcommands: []cli.Command{
+		listPoliciesCommand,
+		createPolicyCommand,
+		deletePolicyCommand,
+	},
+}
+
+var listPoliciesCommand = cli.Command{
+	Name:  "list",
+	Usage: "list access policies in evaluation order",
+	Action: func(cx *cli.Context) error {
+		c, err := getClient(cx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx := context.Background()
+
+		resp, err := c.Policies(ctx, &v1.PoliciesRequest{})
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "ID\tPRIORITY\tPEER\tDESTINATION\tPROTOCOL\tPORTS\tACTION\n")
+		for _, p := range resp.Policies {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n",
+				p.ID,
+				p.Priority,
+				policyPeers(p),
+				p.Destination,
+				valueOrAny(p.Protocol),
+				valueOrAny(p.Ports),
+				strings.ToLower(p.Action.String()),
+			)
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+var createPolicyCommand = cli.Command{
+	Name:      "create",
+	Usage:     "create a new access policy",
+	ArgsUsage: "<id>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "peer",
+			Usage: "peer id the policy applies to (default: all peers)",
+		},
+		cli.StringFlag{
+			Name:  "tag",
+			Usage: "apply the policy to the peers with the tag",
+		},
+		cli.StringFlag{
+			Name:  "destination",
+			Usage: "destination network (i.e. 10.100.0.0/24)",
+		},
+		cli.StringFlag{
+			Name:  "protocol",
+			Usage: "protocol (tcp, udp or icmp; default: all)",
+		},
+		cli.StringFlag{
+			Name:  "ports",
+			Usage: "tcp or udp destination port or range (i.e. 8000-8100)",
+		},
+		cli.BoolFlag{
+			Name:  "deny",
+			Usage: "deny matching traffic instead of allowing it",
+		},
+		cli.UintFlag{
+			Name:  "priority",
+			Usage: "evaluation priority; lower priorities are evaluated first",
+		},
+	},
+	Action: func(cx *cli.Context) error {
+		c, err := getClient(cx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		id := cx.Args().First()
+		destination := cx.String("destination")
+		if id == "" || destination == "" {
+			return fmt.Errorf("id and destination must be specified")
+		}
+
+		action := v1.Policy_ALLOW
+		if cx.Bool("deny") {
+			action = v1.Policy_DENY
+		}
+
+		ctx := context.Background()
+
+		if _, err := c.CreatePolicy(ctx, &v1.CreatePolicyRequest{
+			Policy: &v1.Policy{
+				ID:          id,
+				PeerID:      cx.String("peer"),
+				Tag:         cx.String("tag"),
+				Destination: destination,
+				Protocol:    cx.String("protocol"),
+				Ports:       cx.String("ports"),
+				Action:      action,
+				Priority:    uint32(cx.Uint("priority")),
+			},
+		}); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+var deletePolicyCommand = cli.Command{
+	Name:      "delete",
+	Usage:     "delete an access policy",
+	ArgsUsage: "<id>",
+	Action: func(cx *cli.Context) error {
+		c, err := getClient(cx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx := context.Background()
+
+		id := cx.Args().First()
+		if id == "" {
+			return fmt.Errorf("id must be specified")
+		}
+
+		if _, err := c.DeletePolicy(ctx, &v1.DeletePolicyRequest{
+			ID: id,
+		}); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+func policyPeers(p *v1.Policy) string {
+	if p.Tag != "" {
+		return "tag:" + p.Tag
+	}
+	return valueOrAny(p.PeerID)
+}
+
+func valueOrAny(v string) string {
+	if v == "" {
+		return "any"
+	}
+	return v
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	v1 "github.com/ehazlett/heimdall/api/v1"
@@ -16,6 +17,7 @@ var routesCommand = cli.Command{
 	Subcommands: []cli.Command{
 		listRoutesCommand,
 		createRouteCommand,
+		approveRouteCommand,
 		deleteRouteCommand,
 	},
 }
@@ -38,9 +40,26 @@ var listRoutesCommand = cli.Command{
 		}
 
 		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
-		fmt.Fprintf(w, "NODE\tNETWORK\n")
+		fmt.Fprintf(w, "NETWORK\tNODE\tPRIORITY\tSTATE\tTAGS\n")
 		for _, r := range resp.Routes {
-			fmt.Fprintf(w, "%s\t%s\n", r.NodeID, r.Network)
+			if r.PeerID != "" {
+				state := "active"
+				if r.Pending {
+					state = "pending"
+				}
+				fmt.Fprintf(w, "%s\tpeer %s\t-\t%s\t%s\n", r.Network, r.PeerID, state, strings.Join(r.Tags, ","))
+				continue
+			}
+			for _, n := range r.Nodes {
+				state := "standby"
+				switch {
+				case r.Stale:
+					state = "stale"
+				case n.NodeID == r.ActiveNodeID:
+					state = "active"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", r.Network, n.NodeID, n.Priority, state, strings.Join(r.Tags, ","))
+			}
 		}
 		w.Flush()
 
@@ -50,7 +69,7 @@ var listRoutesCommand = cli.Command{
 
 var createRouteCommand = cli.Command{
 	Name:  "create",
-	Usage: "create a new route",
+	Usage: "create a new route or add a candidate node to a route",
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "node-id",
@@ -60,6 +79,19 @@ var createRouteCommand = cli.Command{
 			Name:  "network",
 			Usage: "network for route (i.e. 10.100.0.0/24)",
 		},
+		cli.StringSliceFlag{
+			Name:  "tag",
+			Usage: "only advertise the route to peers with the tag",
+			Value: &cli.StringSlice{},
+		},
+		cli.UintFlag{
+			Name:  "priority",
+			Usage: "priority of the node for the route (lowest first)",
+		},
+		cli.BoolFlag{
+			Name:  "force",
+			Usage: "allow the route to overlap other routes and cluster networks",
+		},
 	},
 	Action: func(cx *cli.Context) error {
 		c, err := getClient(cx)
@@ -78,8 +110,57 @@ var createRouteCommand = cli.Command{
 		ctx := context.Background()
 
 		if _, err := c.CreateRoute(ctx, &v1.CreateRouteRequest{
-			NodeID:  nodeID,
+			NodeID:   nodeID,
+			Network:  network,
+			Tags:     cx.StringSlice("tag"),
+			Priority: uint32(cx.Uint("priority")),
+			Force:    cx.Bool("force"),
+		}); err != nil {
+			return err
+		}
+		return nil
+	},
+}
+
+var approveRouteCommand = cli.Command{
+	Name:      "approve",
+	Usage:     "approve a route advertised by a peer",
+	ArgsUsage: "<network>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "peer",
+			Usage: "id of the peer advertising the route",
+		},
+		cli.StringSliceFlag{
+			Name:  "tag",
+			Usage: "only advertise the route to peers with the tag",
+			Value: &cli.StringSlice{},
+		},
+		cli.BoolFlag{
+			Name:  "force",
+			Usage: "allow the route to overlap other routes and cluster networks",
+		},
+	},
+	Action: func(cx *cli.Context) error {
+		c, err := getClient(cx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		peerID := cx.String("peer")
+		network := cx.Args().First()
+		if peerID == "" || network == "" {
+			return fmt.Errorf("peer and network must be specified")
+		}
+
+		ctx := context.Background()
+
+		if _, err := c.ApproveRoute(ctx, &v1.ApproveRouteRequest{
+			PeerID:  peerID,
 			Network: network,
+			Tags:    cx.StringSlice("tag"),
+			Force:   cx.Bool("force"),
 		}); err != nil {
 			return err
 		}
@@ -88,8 +169,15 @@ var createRouteCommand = cli.Command{
 }
 
 var deleteRouteCommand = cli.Command{
-	Name:  "delete",
-	Usage: "delete a route",
+	Name:      "delete",
+	Usage:     "delete a route",
+	ArgsUsage: "<network>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "node-id",
+			Usage: "only remove the candidate node from the route",
+		},
+	},
 	Action: func(cx *cli.Context) error {
 		c, err := getClient(cx)
 		if err != nil {
@@ -106,6 +194,7 @@ var deleteRouteCommand = cli.Command{
 
 		if _, err := c.DeleteRoute(ctx, &v1.DeleteRouteRequest{
 			Network: network,
+			NodeID:  cx.String("node-id"),
 		}); err != nil {
 			return err
 		}
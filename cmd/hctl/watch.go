@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/urfave/cli"
+)
+
+var watchCommand = cli.Command{
+	Name:  "watch",
+	Usage: "watch cluster state changes",
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name:  "revision, r",
+			Usage: "resume after the revision",
+		},
+	},
+	Action: func(cx *cli.Context) error {
+		c, err := getClient(cx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		ctx := context.Background()
+
+		stream, err := c.Watch(ctx, &v1.WatchRequest{
+			Revision: cx.Uint64("revision"),
+		})
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprintf(w, "REVISION\tTYPE\tID\n")
+		w.Flush()
+		for {
+			ev, err := stream.Recv()
+			if err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", ev.Revision, ev.Type, ev.ID)
+			w.Flush()
+		}
+	},
+}
@@ -2,7 +2,10 @@ package main
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/ehazlett/heimdall"
 	"github.com/ehazlett/heimdall/version"
@@ -45,6 +48,12 @@ func main() {
 			Value:  "/var/lib/heimdall",
 			EnvVar: "HEIMDALL_DATA_DIR",
 		},
+		cli.StringFlag{
+			Name:   "store",
+			Usage:  "cluster state store backend (redis, embedded); embedded is limited to a single node",
+			Value:  "redis",
+			EnvVar: "HEIMDALL_STORE",
+		},
 		cli.IntFlag{
 			Name:   "redis-port",
 			Usage:  "port to use for the managed Redis server",
@@ -54,13 +63,13 @@ func main() {
 		cli.StringFlag{
 			Name:   "addr, a",
 			Usage:  "grpc address",
-			Value:  fmt.Sprintf("tcp://%s:%d", heimdall.GetIP(), defaultGRPCPort),
+			Value:  fmt.Sprintf("tcp://%s", net.JoinHostPort(heimdall.GetIP(), strconv.Itoa(defaultGRPCPort))),
 			EnvVar: "HEIMDALL_GRPC_ADDR",
 		},
 		cli.StringFlag{
 			Name:   "advertise-grpc-address",
 			Usage:  "public advertise grpc address",
-			Value:  fmt.Sprintf("tcp://%s:%d", heimdall.GetIP(), defaultGRPCPort),
+			Value:  fmt.Sprintf("tcp://%s", net.JoinHostPort(heimdall.GetIP(), strconv.Itoa(defaultGRPCPort))),
 			EnvVar: "HEIMDALL_ADVERTISE_GRPC_ADDR",
 		},
 		cli.StringFlag{
@@ -80,23 +89,76 @@ func main() {
 			Value:  "eth0",
 			EnvVar: "HEIMDALL_NODE_INTERFACE",
 		},
+		cli.StringFlag{
+			Name:   "firewall-driver",
+			Usage:  "firewall driver for node forwarding rules (auto, nftables, iptables, none)",
+			Value:  "auto",
+			EnvVar: "HEIMDALL_FIREWALL_DRIVER",
+		},
 		cli.StringFlag{
 			Name:   "node-network",
 			Usage:  "subnet to be used for nodes",
 			Value:  "10.10.0.0/16",
 			EnvVar: "HEIMDALL_NODE_NETWORK",
 		},
+		cli.StringFlag{
+			Name:   "node-network-v6",
+			Usage:  "optional IPv6 subnet to be used for nodes (dual-stack)",
+			EnvVar: "HEIMDALL_NODE_NETWORK_V6",
+		},
+		cli.IntFlag{
+			Name:   "node-subnet-size",
+			Usage:  "prefix length of the subnet allocated to each node from the node network (default: node network prefix)",
+			EnvVar: "HEIMDALL_NODE_SUBNET_SIZE",
+		},
+		cli.IntFlag{
+			Name:   "node-subnet-size-v6",
+			Usage:  "prefix length of the subnet allocated to each node from the IPv6 node network (default: node network prefix)",
+			EnvVar: "HEIMDALL_NODE_SUBNET_SIZE_V6",
+		},
 		cli.StringFlag{
 			Name:   "peer-network",
 			Usage:  "subnet to be used for peers",
 			Value:  "10.51.0.0/16",
 			EnvVar: "HEIMDALL_PEER_NETWORK",
 		},
+		cli.StringFlag{
+			Name:   "peer-network-v6",
+			Usage:  "optional IPv6 subnet to be used for peers (dual-stack)",
+			EnvVar: "HEIMDALL_PEER_NETWORK_V6",
+		},
+		cli.StringSliceFlag{
+			Name:  "peer-network-exclude",
+			Usage: "peer network address range (10.51.0.10-10.51.0.20 or 10.51.1.0/24) to exclude from allocation",
+			Value: &cli.StringSlice{},
+		},
 		cli.BoolFlag{
 			Name:   "allow-peer-to-peer",
 			Usage:  "allow peer to peer communication",
 			EnvVar: "HEIMDALL_ALLOW_PEER_TO_PEER",
 		},
+		cli.BoolFlag{
+			Name:   "exit-node",
+			Usage:  "allow peers to route their internet traffic through this node",
+			EnvVar: "HEIMDALL_EXIT_NODE",
+		},
+		cli.DurationFlag{
+			Name:   "node-health-timeout",
+			Usage:  "time since the last node heartbeat after which the node is unhealthy",
+			Value:  time.Second * 45,
+			EnvVar: "HEIMDALL_NODE_HEALTH_TIMEOUT",
+		},
+		cli.DurationFlag{
+			Name:   "route-stale-timeout",
+			Usage:  "time after which routes without a live node are deleted (0 to keep stale routes)",
+			EnvVar: "HEIMDALL_ROUTE_STALE_TIMEOUT",
+		},
+		cli.DurationFlag{
+			Name:   "node-handshake-timeout",
+			Usage:  "time since the last wireguard handshake with a node after which the node is unhealthy",
+			Value:  time.Minute * 5,
+			EnvVar: "HEIMDALL_NODE_HANDSHAKE_TIMEOUT",
+		},
 		cli.StringFlag{
 			Name:   "endpoint-ip",
 			Usage:  "IP used for peer communication",
@@ -112,7 +174,7 @@ func main() {
 		cli.StringFlag{
 			Name:  "dns-address",
 			Usage: "address for the DNS to listen",
-			Value: "0.0.0.0:53",
+			Value: ":53",
 		},
 		cli.StringFlag{
 			Name:  "dns-upstream-address",
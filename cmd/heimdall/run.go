@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/ehazlett/heimdall"
 	"github.com/ehazlett/heimdall/server"
@@ -16,6 +17,7 @@ import (
 	"github.com/urfave/cli"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 )
 
 func runServer(clix *cli.Context) error {
@@ -23,17 +25,28 @@ func runServer(clix *cli.Context) error {
 		ID:                    clix.String("id"),
 		Name:                  clix.String("name"),
 		DataDir:               clix.String("data-dir"),
+		StoreBackend:          clix.String("store"),
 		RedisPort:             clix.Int("redis-port"),
 		GRPCAddress:           clix.String("addr"),
 		AdvertiseGRPCAddress:  clix.String("advertise-grpc-address"),
 		GRPCPeerAddress:       clix.String("peer"),
 		ClusterKey:            clix.String("cluster-key"),
 		NodeNetwork:           clix.String("node-network"),
+		NodeNetworkV6:         clix.String("node-network-v6"),
+		NodeSubnetSize:        clix.Int("node-subnet-size"),
+		NodeSubnetSizeV6:      clix.Int("node-subnet-size-v6"),
 		NodeInterface:         clix.String("node-interface"),
+		FirewallDriver:        clix.String("firewall-driver"),
 		PeerNetwork:           clix.String("peer-network"),
+		PeerNetworkV6:         clix.String("peer-network-v6"),
+		PeerNetworkExcludes:   clix.StringSlice("peer-network-exclude"),
 		EndpointIP:            clix.String("endpoint-ip"),
 		EndpointPort:          clix.Int("endpoint-port"),
 		AllowPeerToPeer:       clix.Bool("allow-peer-to-peer"),
+		ExitNode:              clix.Bool("exit-node"),
+		NodeHealthTimeout:     clix.Duration("node-health-timeout"),
+		RouteStaleTimeout:     clix.Duration("route-stale-timeout"),
+		NodeHandshakeTimeout:  clix.Duration("node-handshake-timeout"),
 		DNSServerAddress:      clix.String("dns-address"),
 		DNSUpstreamAddress:    clix.String("dns-upstream-address"),
 		InterfaceName:         clix.String("interface-name"),
@@ -77,7 +90,7 @@ func runServer(clix *cli.Context) error {
 	logrus.WithField("addr", cfg.GRPCAddress).Debug("starting grpc server")
 	go grpcServer.Serve(l)
 
-	signals := make(chan os.Signal)
+	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGUSR1)
 	doneCh := make(chan bool, 1)
 	go func() {
@@ -146,7 +159,13 @@ func getGRPCEndpoint(addr string) (string, string, error) {
 }
 
 func getGRPCOptions(cfg *heimdall.Config) ([]grpc.ServerOption, error) {
-	grpcOpts := []grpc.ServerOption{}
+	grpcOpts := []grpc.ServerOption{
+		// allow peers to detect broken config sync streams
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             time.Second * 10,
+			PermitWithoutStream: true,
+		}),
+	}
 	if cfg.TLSServerCertificate != "" && cfg.TLSServerKey != "" {
 		logrus.WithFields(logrus.Fields{
 			"cert": cfg.TLSServerCertificate,
@@ -44,6 +44,12 @@ func main() {
 			Value:  "tcp://127.0.0.1:9000",
 			EnvVar: "HEIMDALL_ADDR",
 		},
+		cli.StringFlag{
+			Name:   "data-dir",
+			Usage:  "dir for local peer state",
+			Value:  "/var/lib/hpeer",
+			EnvVar: "HEIMDALL_DATA_DIR",
+		},
 		cli.DurationFlag{
 			Name:   "update-interval",
 			Usage:  "interval in which to update with the cluster",
@@ -56,6 +62,26 @@ func main() {
 			Value:  "darknet",
 			EnvVar: "HEIMDALL_INTERFACE_NAME",
 		},
+		cli.StringFlag{
+			Name:   "exit-node",
+			Usage:  "name or id of the node to route internet traffic through",
+			EnvVar: "HEIMDALL_EXIT_NODE",
+		},
+		cli.BoolFlag{
+			Name:   "mesh",
+			Usage:  "connect directly to the other mesh peers (requires peer to peer on the nodes)",
+			EnvVar: "HEIMDALL_MESH",
+		},
+		cli.IntFlag{
+			Name:   "listen-port",
+			Usage:  "wireguard listen port (defaults to 51820 with mesh and random otherwise)",
+			EnvVar: "HEIMDALL_LISTEN_PORT",
+		},
+		cli.StringSliceFlag{
+			Name:  "advertise-route",
+			Usage: "local network (i.e. 192.168.1.0/24) to route for the cluster once approved",
+			Value: &cli.StringSlice{},
+		},
 		cli.StringFlag{
 			Name:  "cert, c",
 			Usage: "heimdall client certificate",
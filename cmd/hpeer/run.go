@@ -25,8 +25,13 @@ func run(cx *cli.Context) error {
 		ID:                    cx.String("id"),
 		Name:                  cx.String("name"),
 		Address:               cx.String("addr"),
+		DataDir:               cx.String("data-dir"),
 		UpdateInterval:        cx.Duration("update-interval"),
 		InterfaceName:         cx.String("interface-name"),
+		ExitNode:              cx.String("exit-node"),
+		AdvertisedRoutes:      cx.StringSlice("advertise-route"),
+		Mesh:                  cx.Bool("mesh"),
+		ListenPort:            cx.Int("listen-port"),
 		TLSClientCertificate:  cx.String("cert"),
 		TLSClientKey:          cx.String("key"),
 		TLSInsecureSkipVerify: cx.Bool("skip-verify"),
@@ -62,7 +67,7 @@ func run(cx *cli.Context) error {
 
 	errCh := make(chan error, 1)
 
-	signals := make(chan os.Signal)
+	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGUSR1)
 	doneCh := make(chan bool, 1)
 	go func() {
@@ -99,6 +104,4 @@ func run(cx *cli.Context) error {
 	case err := <-errCh:
 		return err
 	}
-
-	return nil
 }
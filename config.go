@@ -8,6 +8,8 @@ type Config struct {
 	ID string
 	// Name is the name of the node
 	Name string
+	// StoreBackend is the backend used for cluster state (redis or embedded)
+	StoreBackend string
 	// RedisPort is the port to use for the managed Redis
 	RedisPort int
 	// DataDir is the directory for local node configuration
@@ -24,14 +26,38 @@ type Config struct {
 	DNSUpstreamAddress string
 	// AllowPeerToPeer enables peer to peer communication
 	AllowPeerToPeer bool
+	// ExitNode allows peers to route their internet traffic through the node
+	ExitNode bool
+	// NodeHealthTimeout is the heartbeat age after which a node is unhealthy
+	NodeHealthTimeout time.Duration
+	// NodeHandshakeTimeout is the Wireguard handshake age after which a node is unhealthy
+	NodeHandshakeTimeout time.Duration
+	// RouteStaleTimeout is how long a route without a live node is kept
+	// before it is deleted.  Stale routes are kept if zero.
+	RouteStaleTimeout time.Duration
 	// ClusterKey is a preshared key for cluster peers
 	ClusterKey string
 	// NodeNetwork is the network for the cluster nodes
 	NodeNetwork string
+	// NodeNetworkV6 is the optional IPv6 network for dual-stack cluster nodes
+	NodeNetworkV6 string
+	// NodeSubnetSize is the prefix length of the subnet allocated to each
+	// node from the node network.  It defaults to the node network prefix.
+	NodeSubnetSize int
+	// NodeSubnetSizeV6 is the prefix length of the subnet allocated to each
+	// node from the IPv6 node network
+	NodeSubnetSizeV6 int
 	// NodeInterface is the ethernet interface for node network
 	NodeInterface string
+	// FirewallDriver is the driver used to manage the node forwarding rules
+	FirewallDriver string
 	// PeerNetwork is the subnet that is used for cluster peers
 	PeerNetwork string
+	// PeerNetworkV6 is the optional IPv6 subnet for dual-stack cluster peers
+	PeerNetworkV6 string
+	// PeerNetworkExcludes are address ranges of the peer networks that are
+	// not allocated to peers
+	PeerNetworkExcludes []string
 	// EndpointIP is the IP used for peer communication
 	EndpointIP string
 	// GatewayPort is the port used for peer communication
@@ -60,10 +86,21 @@ type PeerConfig struct {
 	Name string
 	// Address is the GRPC address of the peer to join
 	Address string
+	// DataDir is the directory for local peer state
+	DataDir string
 	// UpdateInterval is the interval in which to update with the cluster
 	UpdateInterval time.Duration
 	// InterfaceName is the interface used for peer communication
 	InterfaceName string
+	// ExitNode is the name or id of the node to route internet traffic through
+	ExitNode string
+	// AdvertisedRoutes are the local networks the peer routes for the cluster
+	AdvertisedRoutes []string
+	// Mesh enables direct tunnels to the other mesh peers
+	Mesh bool
+	// ListenPort is the Wireguard port of the peer.  A random port is used
+	// if zero unless mesh is enabled.
+	ListenPort int
 	// TLSClientCertificate is the client certificate used for communication
 	TLSClientCertificate string
 	// TLSClientKey is the client key used for communication
@@ -0,0 +1,118 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DriverAuto uses nftables when available and falls back to iptables
+	DriverAuto = "auto"
+	// DriverNftables manages the rules in a dedicated nftables table
+	DriverNftables = "nftables"
+	// DriverIptables manages the rules in dedicated iptables chains
+	DriverIptables = "iptables"
+	// DriverNone does not manage any rules
+	DriverNone = "none"
+)
+
+// Rules are the forwarding rules of a node
+type Rules struct {
+	// Interface is the Wireguard interface of the node
+	Interface string
+	// NodeInterface is the interface that forwarded traffic is masqueraded on
+	NodeInterface string
+	// Filters are evaluated in order for traffic forwarded from the tunnel.
+	// Traffic that does not match a filter is accepted.
+	Filters []Filter
+	// ExitSources are the addresses of the peers using the node as exit
+	// node.  Their traffic is masqueraded on all interfaces but the tunnel.
+	ExitSources []string
+}
+
+// Filter matches traffic forwarded from the tunnel
+type Filter struct {
+	// Sources are the source addresses; empty matches all sources
+	Sources []string
+	// Destination is the destination network which also determines the
+	// address family of the filter
+	Destination string
+	// Protocol is tcp, udp or icmp; empty matches all protocols
+	Protocol string
+	// Ports is a destination port or range (i.e. 8000-8100)
+	Ports string
+	// Accept accepts matching traffic; otherwise it is dropped
+	Accept bool
+}
+
+// IPv6 returns true if the filter matches IPv6 traffic
+func (f Filter) IPv6() bool {
+	ip, _, err := net.ParseCIDR(f.Destination)
+	return err == nil && ip.To4() == nil
+}
+
+// exitSources returns the exit sources of the address family
+func (r *Rules) exitSources(ipv6 bool) []string {
+	var sources []string
+	for _, s := range r.ExitSources {
+		ip := net.ParseIP(s)
+		if ip != nil && (ip.To4() == nil) == ipv6 {
+			sources = append(sources, s)
+		}
+	}
+	return sources
+}
+
+// Driver applies the node forwarding rules to the host firewall
+type Driver interface {
+	// Apply atomically replaces the managed rules with the specified rules
+	Apply(ctx context.Context, rules *Rules) error
+	// Check returns false if the host firewall no longer matches the
+	// last applied rules
+	Check(ctx context.Context) (bool, error)
+}
+
+// NewDriver returns the named firewall driver
+func NewDriver(name string) (Driver, error) {
+	switch name {
+	case "", DriverAuto:
+		if _, err := exec.LookPath("nft"); err == nil {
+			return &nftablesDriver{}, nil
+		}
+		return &iptablesDriver{}, nil
+	case DriverNftables:
+		return &nftablesDriver{}, nil
+	case DriverIptables:
+		return &iptablesDriver{}, nil
+	case DriverNone:
+		return &noneDriver{}, nil
+	}
+	return nil, fmt.Errorf("unknown firewall driver %q", name)
+}
+
+// noneDriver leaves the host firewall to be managed externally
+type noneDriver struct{}
+
+func (d *noneDriver) Apply(ctx context.Context, rules *Rules) error {
+	return nil
+}
+
+func (d *noneDriver) Check(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+func run(ctx context.Context, stdin io.Reader, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = stdin
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return out, errors.Wrapf(err, "%s %s: %s", name, strings.Join(args, " "), strings.TrimSpace(string(out)))
+	}
+	return out, nil
+}
@@ -0,0 +1,82 @@
+package firewall
+
+import "testing"
+
+var testRules = &Rules{
+	Interface:     "darknet",
+	NodeInterface: "eth0",
+	Filters: []Filter{
+		{Sources: []string{"10.51.0.2"}, Destination: "10.100.0.0/24", Protocol: "tcp", Ports: "8000-8100", Accept: true},
+		{Sources: []string{"10.51.0.2", "10.51.0.3"}, Destination: "10.100.0.0/24"},
+		{Destination: "fd00:100::/64", Protocol: "icmp"},
+	},
+	ExitSources: []string{"10.51.0.2", "fd00:51::2", "10.51.0.3"},
+}
+
+func TestNftRuleset(t *testing.T) {
+	expected := `table inet heimdall
+delete table inet heimdall
+table inet heimdall {
+	chain forward {
+		type filter hook forward priority 0; policy accept;
+		iifname "darknet" ip saddr 10.51.0.2 ip daddr 10.100.0.0/24 tcp dport 8000-8100 accept
+		iifname "darknet" ip saddr { 10.51.0.2, 10.51.0.3 } ip daddr 10.100.0.0/24 drop
+		iifname "darknet" ip6 daddr fd00:100::/64 meta l4proto ipv6-icmp drop
+		iifname "darknet" accept
+	}
+	chain postrouting {
+		type nat hook postrouting priority 100; policy accept;
+		oifname "eth0" masquerade
+		ip saddr { 10.51.0.2, 10.51.0.3 } oifname != "darknet" masquerade
+		ip6 saddr { fd00:51::2 } oifname != "darknet" masquerade
+	}
+}
+`
+	if r := nftRuleset(testRules); r != expected {
+		t.Fatalf("ruleset does not match; expected \n %q \n received \n %q", expected, r)
+	}
+}
+
+func TestIptablesRestore(t *testing.T) {
+	expected := `*filter
+:HEIMDALL-FORWARD - [0:0]
+-A HEIMDALL-FORWARD -i darknet -s 10.51.0.2 -d 10.100.0.0/24 -p tcp --dport 8000:8100 -j ACCEPT
+-A HEIMDALL-FORWARD -i darknet -s 10.51.0.2,10.51.0.3 -d 10.100.0.0/24 -j DROP
+-A HEIMDALL-FORWARD -i darknet -j ACCEPT
+COMMIT
+*nat
+:HEIMDALL-POSTROUTING - [0:0]
+-A HEIMDALL-POSTROUTING -o eth0 -j MASQUERADE
+-A HEIMDALL-POSTROUTING -s 10.51.0.2,10.51.0.3 ! -o darknet -j MASQUERADE
+COMMIT
+`
+	if r := iptablesRestore(testRules, false); r != expected {
+		t.Fatalf("restore input does not match; expected \n %q \n received \n %q", expected, r)
+	}
+
+	expected6 := `*filter
+:HEIMDALL-FORWARD - [0:0]
+-A HEIMDALL-FORWARD -i darknet -d fd00:100::/64 -p ipv6-icmp -j DROP
+-A HEIMDALL-FORWARD -i darknet -j ACCEPT
+COMMIT
+*nat
+:HEIMDALL-POSTROUTING - [0:0]
+-A HEIMDALL-POSTROUTING -o eth0 -j MASQUERADE
+-A HEIMDALL-POSTROUTING -s fd00:51::2 ! -o darknet -j MASQUERADE
+COMMIT
+`
+	if r := iptablesRestore(testRules, true); r != expected6 {
+		t.Fatalf("ip6tables restore input does not match; expected \n %q \n received \n %q", expected6, r)
+	}
+}
+
+func TestNewDriver(t *testing.T) {
+	for _, name := range []string{"", DriverAuto, DriverNftables, DriverIptables, DriverNone} {
+		if _, err := NewDriver(name); err != nil {
+			t.Errorf("unexpected error for driver %q: %s", name, err)
+		}
+	}
+	if _, err := NewDriver("pf"); err == nil {
+		t.Error("expected error for unknown driver")
+	}
+}
@@ -0,0 +1,155 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+const (
+	iptablesForwardChain     = "HEIMDALL-FORWARD"
+	iptablesPostroutingChain = "HEIMDALL-POSTROUTING"
+)
+
+// iptablesDriver manages the rules in heimdall chains of the filter and nat
+// tables for both iptables and ip6tables.  The chains are replaced with
+// iptables-restore and jumped to from the builtin chains.
+type iptablesDriver struct {
+	mu      sync.Mutex
+	applied map[string]string
+}
+
+// iptablesJump is a jump from a builtin chain to a heimdall chain
+type iptablesJump struct {
+	table string
+	chain string
+	to    string
+}
+
+var iptablesJumps = []iptablesJump{
+	{table: "filter", chain: "FORWARD", to: iptablesForwardChain},
+	{table: "nat", chain: "POSTROUTING", to: iptablesPostroutingChain},
+}
+
+func (d *iptablesDriver) Apply(ctx context.Context, rules *Rules) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	applied := map[string]string{}
+	for _, bin := range iptablesBinaries() {
+		input := iptablesRestore(rules, bin == "ip6tables")
+		if _, err := run(ctx, strings.NewReader(input), bin+"-restore", "--noflush"); err != nil {
+			return err
+		}
+		for _, j := range iptablesJumps {
+			if _, err := run(ctx, nil, bin, "-t", j.table, "-C", j.chain, "-j", j.to); err == nil {
+				continue
+			}
+			if _, err := run(ctx, nil, bin, "-t", j.table, "-I", j.chain, "1", "-j", j.to); err != nil {
+				return err
+			}
+		}
+		listed, err := iptablesList(ctx, bin)
+		if err != nil {
+			return err
+		}
+		applied[bin] = listed
+	}
+	d.applied = applied
+	return nil
+}
+
+func (d *iptablesDriver) Check(ctx context.Context) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for bin, listed := range d.applied {
+		for _, j := range iptablesJumps {
+			if _, err := run(ctx, nil, bin, "-t", j.table, "-C", j.chain, "-j", j.to); err != nil {
+				return false, nil
+			}
+		}
+		current, err := iptablesList(ctx, bin)
+		if err != nil {
+			return false, nil
+		}
+		if current != listed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// iptablesList returns the rules of the heimdall chains
+func iptablesList(ctx context.Context, bin string) (string, error) {
+	var b strings.Builder
+	for _, j := range iptablesJumps {
+		out, err := run(ctx, nil, bin, "-t", j.table, "-S", j.to)
+		if err != nil {
+			return "", err
+		}
+		b.Write(out)
+	}
+	return b.String(), nil
+}
+
+// iptablesBinaries returns the available iptables binaries
+func iptablesBinaries() []string {
+	bins := []string{}
+	for _, bin := range []string{"iptables", "ip6tables"} {
+		if _, err := exec.LookPath(bin + "-restore"); err == nil {
+			bins = append(bins, bin)
+		}
+	}
+	return bins
+}
+
+// iptablesRestore returns the iptables-restore input that replaces the
+// heimdall chains with the filters of the address family.  Declaring a chain
+// with --noflush flushes it.
+func iptablesRestore(rules *Rules, ipv6 bool) string {
+	var b strings.Builder
+	b.WriteString("*filter\n")
+	fmt.Fprintf(&b, ":%s - [0:0]\n", iptablesForwardChain)
+	for _, f := range rules.Filters {
+		if f.IPv6() != ipv6 {
+			continue
+		}
+		fmt.Fprintf(&b, "-A %s -i %s %s\n", iptablesForwardChain, rules.Interface, iptablesFilter(f))
+	}
+	fmt.Fprintf(&b, "-A %s -i %s -j ACCEPT\n", iptablesForwardChain, rules.Interface)
+	b.WriteString("COMMIT\n")
+	b.WriteString("*nat\n")
+	fmt.Fprintf(&b, ":%s - [0:0]\n", iptablesPostroutingChain)
+	fmt.Fprintf(&b, "-A %s -o %s -j MASQUERADE\n", iptablesPostroutingChain, rules.NodeInterface)
+	if sources := rules.exitSources(ipv6); len(sources) > 0 {
+		fmt.Fprintf(&b, "-A %s -s %s ! -o %s -j MASQUERADE\n", iptablesPostroutingChain, strings.Join(sources, ","), rules.Interface)
+	}
+	b.WriteString("COMMIT\n")
+	return b.String()
+}
+
+// iptablesFilter returns the matches and target of the filter
+func iptablesFilter(f Filter) string {
+	var m []string
+	if len(f.Sources) > 0 {
+		m = append(m, "-s", strings.Join(f.Sources, ","))
+	}
+	m = append(m, "-d", f.Destination)
+	switch {
+	case f.Protocol == "icmp" && f.IPv6():
+		m = append(m, "-p", "ipv6-icmp")
+	case f.Protocol != "":
+		m = append(m, "-p", f.Protocol)
+	}
+	if f.Ports != "" {
+		m = append(m, "--dport", strings.Replace(f.Ports, "-", ":", 1))
+	}
+	target := "DROP"
+	if f.Accept {
+		target = "ACCEPT"
+	}
+	return strings.Join(append(m, "-j", target), " ")
+}
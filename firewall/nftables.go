@@ -0,0 +1,108 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const nftTable = "heimdall"
+
+// nftablesDriver manages the rules in the heimdall inet table.  The table is
+// replaced in a single transaction so rules never accumulate or go missing
+// between updates.
+type nftablesDriver struct {
+	mu      sync.Mutex
+	applied string
+}
+
+func (d *nftablesDriver) Apply(ctx context.Context, rules *Rules) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, err := run(ctx, strings.NewReader(nftRuleset(rules)), "nft", "-f", "-"); err != nil {
+		return err
+	}
+	out, err := run(ctx, nil, "nft", "list", "table", "inet", nftTable)
+	if err != nil {
+		return err
+	}
+	d.applied = string(out)
+	return nil
+}
+
+func (d *nftablesDriver) Check(ctx context.Context) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.applied == "" {
+		return true, nil
+	}
+	// a missing table is drift
+	out, err := run(ctx, nil, "nft", "list", "table", "inet", nftTable)
+	if err != nil {
+		return false, nil
+	}
+	return string(out) == d.applied, nil
+}
+
+// nftFilter returns the matches and verdict of the filter
+func nftFilter(f Filter) string {
+	family, icmp := "ip", "icmp"
+	if f.IPv6() {
+		family, icmp = "ip6", "ipv6-icmp"
+	}
+	var m []string
+	switch len(f.Sources) {
+	case 0:
+	case 1:
+		m = append(m, fmt.Sprintf("%s saddr %s", family, f.Sources[0]))
+	default:
+		m = append(m, fmt.Sprintf("%s saddr { %s }", family, strings.Join(f.Sources, ", ")))
+	}
+	m = append(m, fmt.Sprintf("%s daddr %s", family, f.Destination))
+	switch {
+	case f.Protocol == "icmp":
+		m = append(m, "meta l4proto "+icmp)
+	case f.Ports != "":
+		m = append(m, fmt.Sprintf("%s dport %s", f.Protocol, f.Ports))
+	case f.Protocol != "":
+		m = append(m, "meta l4proto "+f.Protocol)
+	}
+	verdict := "drop"
+	if f.Accept {
+		verdict = "accept"
+	}
+	return strings.Join(append(m, verdict), " ")
+}
+
+// nftRuleset returns the nft script that replaces the heimdall table.  The
+// table is declared before it is deleted so the script also succeeds when
+// the table does not exist yet.
+func nftRuleset(rules *Rules) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "table inet %s\n", nftTable)
+	fmt.Fprintf(&b, "delete table inet %s\n", nftTable)
+	fmt.Fprintf(&b, "table inet %s {\n", nftTable)
+	b.WriteString("\tchain forward {\n")
+	b.WriteString("\t\ttype filter hook forward priority 0; policy accept;\n")
+	for _, f := range rules.Filters {
+		fmt.Fprintf(&b, "\t\tiifname %q %s\n", rules.Interface, nftFilter(f))
+	}
+	fmt.Fprintf(&b, "\t\tiifname %q accept\n", rules.Interface)
+	b.WriteString("\t}\n")
+	b.WriteString("\tchain postrouting {\n")
+	b.WriteString("\t\ttype nat hook postrouting priority 100; policy accept;\n")
+	fmt.Fprintf(&b, "\t\toifname %q masquerade\n", rules.NodeInterface)
+	for _, family := range []string{"ip", "ip6"} {
+		sources := rules.exitSources(family == "ip6")
+		if len(sources) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\t\t%s saddr { %s } oifname != %q masquerade\n", family, strings.Join(sources, ", "), rules.Interface)
+	}
+	b.WriteString("\t}\n")
+	b.WriteString("}\n")
+	return b.String()
+}
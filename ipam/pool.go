@@ -0,0 +1,286 @@
+package ipam
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxPoolSize is the maximum number of addresses tracked for a subnet.
+// Addresses of larger (IPv6) subnets are only allocated from the start of
+// the subnet.
+const maxPoolSize = 1 << 24
+
+var (
+	// ErrFull is returned when there are no available addresses in the pool
+	ErrFull = errors.New("no available IPs")
+	// ErrAllocated is returned when reserving an address that is already allocated
+	ErrAllocated = errors.New("ip already allocated")
+	// ErrOutOfRange is returned when the address cannot be allocated from the pool
+	ErrOutOfRange = errors.New("ip not allocatable from network")
+)
+
+// Usage is the address utilization of a pool
+type Usage struct {
+	// Total is the number of allocatable addresses
+	Total uint64
+	// Allocated is the number of allocated addresses
+	Allocated uint64
+	// Excluded is the number of unallocated addresses excluded from allocation
+	Excluded uint64
+	// Available is the number of addresses free for allocation
+	Available uint64
+}
+
+// Pool allocates addresses of a subnet using a bitmap.  The network, first
+// host (gateway) and broadcast addresses are never allocated.  Pool is not
+// safe for concurrent use.
+type Pool struct {
+	subnet *net.IPNet
+	base   *big.Int
+	length int
+	size   uint64
+	// used is the bitmap of allocated addresses
+	used []uint64
+	// blocked is the bitmap of reserved and excluded addresses
+	blocked []uint64
+	// reserved are the network, gateway and broadcast offsets
+	reserved  []uint64
+	allocated uint64
+	// hint is the lowest word that may have a free address
+	hint int
+}
+
+// NewPool returns an empty pool for the subnet
+func NewPool(subnet *net.IPNet) *Pool {
+	ip := subnet.IP.Mask(subnet.Mask)
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	ones, total := subnet.Mask.Size()
+	hostBits := uint(total - ones)
+	size := uint64(maxPoolSize)
+	if hostBits < 24 {
+		size = 1 << hostBits
+	}
+	words := (size + 63) / 64
+	p := &Pool{
+		subnet:  &net.IPNet{IP: ip, Mask: subnet.Mask},
+		base:    new(big.Int).SetBytes(ip),
+		length:  len(ip),
+		size:    size,
+		used:    make([]uint64, words),
+		blocked: make([]uint64, words),
+	}
+	// block the bits past the end of the pool
+	for o := size; o < words*64; o++ {
+		setBit(p.blocked, o)
+	}
+	switch {
+	case len(ip) == net.IPv6len:
+		// subnet-router anycast and gateway
+		p.reserved = []uint64{0, 1}
+	case hostBits >= 2:
+		// network, gateway and broadcast
+		p.reserved = []uint64{0, 1, size - 1}
+	}
+	for _, o := range p.reserved {
+		if o < size {
+			setBit(p.blocked, o)
+		}
+	}
+	return p
+}
+
+// Subnet returns the subnet of the pool
+func (p *Pool) Subnet() *net.IPNet {
+	return p.subnet
+}
+
+// Contains returns true if the address is in the subnet of the pool
+func (p *Pool) Contains(ip net.IP) bool {
+	return p.subnet.Contains(ip)
+}
+
+// Exclude excludes the addresses from start to end inclusive from
+// allocation.  Excluded addresses can still be reserved.
+func (p *Pool) Exclude(start, end net.IP) error {
+	if !p.Contains(start) || !p.Contains(end) {
+		return errors.Wrapf(ErrOutOfRange, "range %s-%s", start, end)
+	}
+	s, ok := p.offset(start)
+	if !ok {
+		return nil
+	}
+	e, ok := p.offset(end)
+	if !ok {
+		e = p.size - 1
+	}
+	for o := s; o <= e; o++ {
+		setBit(p.blocked, o)
+	}
+	return nil
+}
+
+// Restrict excludes all addresses outside of start to end inclusive from
+// allocation
+func (p *Pool) Restrict(start, end net.IP) error {
+	s, ok := p.offset(start)
+	if !ok {
+		return errors.Wrapf(ErrOutOfRange, "range %s-%s", start, end)
+	}
+	e, ok := p.offset(end)
+	if !ok {
+		e = p.size - 1
+	}
+	for o := uint64(0); o < s; o++ {
+		setBit(p.blocked, o)
+	}
+	for o := e + 1; o < p.size; o++ {
+		setBit(p.blocked, o)
+	}
+	return nil
+}
+
+// Allocate allocates the lowest free address
+func (p *Pool) Allocate() (net.IP, error) {
+	for i := p.hint; i < len(p.used); i++ {
+		w := p.used[i] | p.blocked[i]
+		if w == ^uint64(0) {
+			continue
+		}
+		p.hint = i
+		o := uint64(i)*64 + uint64(bits.TrailingZeros64(^w))
+		setBit(p.used, o)
+		p.allocated++
+		return p.ip(o), nil
+	}
+	p.hint = len(p.used)
+	return nil, ErrFull
+}
+
+// Reserve allocates the specified address.  Excluded addresses can be
+// reserved but the network, gateway and broadcast addresses cannot.
+func (p *Pool) Reserve(ip net.IP) error {
+	o, ok := p.offset(ip)
+	if !ok || p.isReserved(o) {
+		return errors.Wrap(ErrOutOfRange, ip.String())
+	}
+	if isSet(p.used, o) {
+		return errors.Wrap(ErrAllocated, ip.String())
+	}
+	setBit(p.used, o)
+	p.allocated++
+	return nil
+}
+
+// Release frees the address for allocation
+func (p *Pool) Release(ip net.IP) {
+	o, ok := p.offset(ip)
+	if !ok || !isSet(p.used, o) {
+		return
+	}
+	p.used[o/64] &^= 1 << (o % 64)
+	p.allocated--
+	if i := int(o / 64); i < p.hint {
+		p.hint = i
+	}
+}
+
+// Usage returns the address utilization of the pool
+func (p *Pool) Usage() Usage {
+	var free, blocked uint64
+	for i := range p.used {
+		free += uint64(bits.OnesCount64(^(p.used[i] | p.blocked[i])))
+		blocked += uint64(bits.OnesCount64(p.blocked[i] &^ p.used[i]))
+	}
+	// the bits past the end of the pool are blocked
+	blocked -= uint64(len(p.used))*64 - p.size
+	var reserved uint64
+	for _, o := range p.reserved {
+		if o < p.size {
+			reserved++
+		}
+	}
+	return Usage{
+		Total:     p.size - reserved,
+		Allocated: p.allocated,
+		Excluded:  blocked - reserved,
+		Available: free,
+	}
+}
+
+func (p *Pool) isReserved(o uint64) bool {
+	for _, r := range p.reserved {
+		if o == r {
+			return true
+		}
+	}
+	return false
+}
+
+// offset returns the offset of the address in the pool
+func (p *Pool) offset(ip net.IP) (uint64, bool) {
+	if !p.Contains(ip) {
+		return 0, false
+	}
+	if v4 := ip.To4(); v4 != nil && p.length == net.IPv4len {
+		ip = v4
+	}
+	o := new(big.Int).Sub(new(big.Int).SetBytes(ip), p.base)
+	if !o.IsUint64() || o.Uint64() >= p.size {
+		return 0, false
+	}
+	return o.Uint64(), true
+}
+
+// ip returns the address at the offset in the pool
+func (p *Pool) ip(o uint64) net.IP {
+	v := new(big.Int).Add(p.base, new(big.Int).SetUint64(o)).Bytes()
+	ip := make(net.IP, p.length)
+	copy(ip[p.length-len(v):], v)
+	return ip
+}
+
+// ParseRange parses an address range in the format 10.0.0.10-10.0.0.20 or
+// a subnet such as 10.0.0.0/28 and returns the first and last address
+func ParseRange(r string) (net.IP, net.IP, error) {
+	if strings.Contains(r, "/") {
+		_, n, err := net.ParseCIDR(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		ones, total := n.Mask.Size()
+		last := new(big.Int).SetBytes(n.IP)
+		host := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(total-ones)), big.NewInt(1))
+		last.Or(last, host)
+		end := make(net.IP, len(n.IP))
+		v := last.Bytes()
+		copy(end[len(end)-len(v):], v)
+		return n.IP, end, nil
+	}
+	parts := strings.Split(r, "-")
+	if len(parts) != 2 {
+		return nil, nil, fmt.Errorf("invalid range %q; expected format 10.0.0.10-10.0.0.20 or 10.0.0.0/28", r)
+	}
+	start, end := net.ParseIP(parts[0]), net.ParseIP(parts[1])
+	if start == nil || end == nil {
+		return nil, nil, fmt.Errorf("invalid range %q", r)
+	}
+	if new(big.Int).SetBytes(start.To16()).Cmp(new(big.Int).SetBytes(end.To16())) > 0 {
+		return nil, nil, fmt.Errorf("invalid range %q; start is after end", r)
+	}
+	return start, end, nil
+}
+
+func setBit(b []uint64, o uint64) {
+	b[o/64] |= 1 << (o % 64)
+}
+
+func isSet(b []uint64, o uint64) bool {
+	return b[o/64]&(1<<(o%64)) != 0
+}
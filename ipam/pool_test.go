@@ -0,0 +1,153 @@
+package ipam
+
+import (
+	"net"
+	"testing"
+)
+
+func testPool(t *testing.T, cidr string) *Pool {
+	_, n, err := net.ParseCIDR(cidr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewPool(n)
+}
+
+func TestPoolAllocate(t *testing.T) {
+	p := testPool(t, "10.51.0.0/29")
+	var ips []string
+	for {
+		ip, err := p.Allocate()
+		if err == ErrFull {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		ips = append(ips, ip.String())
+	}
+	expected := []string{"10.51.0.2", "10.51.0.3", "10.51.0.4", "10.51.0.5", "10.51.0.6"}
+	if len(ips) != len(expected) {
+		t.Fatalf("expected %v; received %v", expected, ips)
+	}
+	for i := range ips {
+		if ips[i] != expected[i] {
+			t.Errorf("expected %s; received %s", expected[i], ips[i])
+		}
+	}
+
+	p.Release(net.ParseIP("10.51.0.4"))
+	ip, err := p.Allocate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip.String() != "10.51.0.4" {
+		t.Errorf("expected released ip 10.51.0.4; received %s", ip)
+	}
+}
+
+func TestPoolLargeSubnet(t *testing.T) {
+	p := testPool(t, "10.51.0.0/16")
+	if err := p.Exclude(net.ParseIP("10.51.0.2"), net.ParseIP("10.51.0.254")); err != nil {
+		t.Fatal(err)
+	}
+	// .255 and .0 are valid host addresses inside a /16
+	for _, expected := range []string{"10.51.0.255", "10.51.1.0"} {
+		ip, err := p.Allocate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ip.String() != expected {
+			t.Errorf("expected %s; received %s", expected, ip)
+		}
+	}
+	u := p.Usage()
+	if u.Total != 65533 || u.Allocated != 2 || u.Excluded != 253 || u.Available != 65278 {
+		t.Errorf("unexpected usage %+v", u)
+	}
+}
+
+func TestPoolReserve(t *testing.T) {
+	p := testPool(t, "10.51.0.0/24")
+	if err := p.Exclude(net.ParseIP("10.51.0.2"), net.ParseIP("10.51.0.9")); err != nil {
+		t.Fatal(err)
+	}
+	// excluded addresses can be reserved
+	if err := p.Reserve(net.ParseIP("10.51.0.5")); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Reserve(net.ParseIP("10.51.0.5")); err == nil {
+		t.Error("expected error reserving allocated ip")
+	}
+	for _, ip := range []string{"10.51.0.0", "10.51.0.1", "10.51.0.255", "10.52.0.2"} {
+		if err := p.Reserve(net.ParseIP(ip)); err == nil {
+			t.Errorf("expected error reserving %s", ip)
+		}
+	}
+	ip, err := p.Allocate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip.String() != "10.51.0.10" {
+		t.Errorf("expected 10.51.0.10; received %s", ip)
+	}
+	u := p.Usage()
+	if u.Total != 253 || u.Allocated != 2 || u.Excluded != 7 || u.Available != 244 {
+		t.Errorf("unexpected usage %+v", u)
+	}
+}
+
+func TestPoolRestrict(t *testing.T) {
+	p := testPool(t, "10.51.0.0/24")
+	if err := p.Restrict(net.ParseIP("10.51.0.100"), net.ParseIP("10.51.0.101")); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"10.51.0.100", "10.51.0.101"} {
+		ip, err := p.Allocate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ip.String() != expected {
+			t.Errorf("expected %s; received %s", expected, ip)
+		}
+	}
+	if _, err := p.Allocate(); err != ErrFull {
+		t.Errorf("expected ErrFull; received %v", err)
+	}
+}
+
+func TestPoolIPv6(t *testing.T) {
+	p := testPool(t, "fd00:51::/64")
+	ip, err := p.Allocate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip.String() != "fd00:51::2" {
+		t.Errorf("expected fd00:51::2; received %s", ip)
+	}
+	if err := p.Reserve(net.ParseIP("fd00:51::ffff")); err != nil {
+		t.Fatal(err)
+	}
+	if u := p.Usage(); u.Total != maxPoolSize-2 || u.Allocated != 2 {
+		t.Errorf("unexpected usage %+v", u)
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	for r, expected := range map[string][2]string{
+		"10.51.0.10-10.51.0.20": {"10.51.0.10", "10.51.0.20"},
+		"10.51.1.0/24":          {"10.51.1.0", "10.51.1.255"},
+		"fd00:51::/120":         {"fd00:51::", "fd00:51::ff"},
+	} {
+		start, end, err := ParseRange(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if start.String() != expected[0] || end.String() != expected[1] {
+			t.Errorf("expected %s-%s for %s; received %s-%s", expected[0], expected[1], r, start, end)
+		}
+	}
+	if _, _, err := ParseRange("10.51.0.20-10.51.0.10"); err == nil {
+		t.Error("expected error for reversed range")
+	}
+}
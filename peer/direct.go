@@ -0,0 +1,86 @@
+package peer
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// directHandshakeTimeout is how long a direct tunnel may go without a
+	// handshake before traffic falls back to the nodes
+	directHandshakeTimeout = time.Minute * 5
+	// directRetryInterval is how long traffic is relayed by the nodes before
+	// a failed direct tunnel is tried again
+	directRetryInterval = time.Minute * 15
+	// directCheckInterval is how often the direct tunnels are checked
+	directCheckInterval = time.Second * 30
+)
+
+// directState tracks the attempt of a direct tunnel to a peer
+type directState struct {
+	started time.Time
+	failed  time.Time
+}
+
+// directPeers returns the peers without the direct tunnels that have not
+// completed a handshake.  Traffic to those peers is then relayed by the nodes
+// that are allowed the peer network.
+func directPeers(peers []*v1.Peer, handshakes map[string]time.Time, states map[string]*directState, now time.Time) []*v1.Peer {
+	seen := map[string]struct{}{}
+	filtered := []*v1.Peer{}
+	for _, p := range peers {
+		if !p.Direct {
+			filtered = append(filtered, p)
+			continue
+		}
+		seen[p.PublicKey] = struct{}{}
+		st, ok := states[p.PublicKey]
+		if !ok {
+			st = &directState{started: now}
+			states[p.PublicKey] = st
+		}
+		if !st.failed.IsZero() {
+			if now.Sub(st.failed) < directRetryInterval {
+				continue
+			}
+			logrus.Debugf("retrying direct tunnel to %s", p.ID)
+			st.failed = time.Time{}
+			st.started = now
+		}
+		if now.Sub(st.started) > directHandshakeTimeout && now.Sub(handshakes[p.PublicKey]) > directHandshakeTimeout {
+			logrus.Warnf("no handshake with %s at %s; relaying through nodes", p.ID, p.Endpoint)
+			st.failed = now
+			continue
+		}
+		filtered = append(filtered, p)
+	}
+	for key := range states {
+		if _, ok := seen[key]; !ok {
+			delete(states, key)
+		}
+	}
+	return filtered
+}
+
+// directMonitor periodically re-applies the last config so that failed direct
+// tunnels fall back to the nodes and are retried
+func (p *Peer) directMonitor() {
+	t := time.NewTicker(directCheckInterval)
+	defer t.Stop()
+	for range t.C {
+		p.mu.Lock()
+		last := p.last
+		p.mu.Unlock()
+		if last == nil {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), directCheckInterval)
+		if err := p.apply(ctx, last.Address, last.Peers, last.DNS); err != nil {
+			logrus.WithError(err).Warn("error checking direct tunnels")
+		}
+		cancel()
+	}
+}
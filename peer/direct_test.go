@@ -0,0 +1,51 @@
+package peer
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+)
+
+func TestDirectPeers(t *testing.T) {
+	peers := []*v1.Peer{
+		{ID: "node", PublicKey: "node-key", Endpoint: "10.0.0.1:10100"},
+		{ID: "laptop", PublicKey: "laptop-key", Endpoint: "203.0.113.10:41641", Direct: true},
+	}
+	states := map[string]*directState{}
+	now := time.Now()
+
+	ids := func(peers []*v1.Peer) []string {
+		v := []string{}
+		for _, p := range peers {
+			v = append(v, p.ID)
+		}
+		return v
+	}
+
+	if v := ids(directPeers(peers, nil, states, now)); len(v) != 2 {
+		t.Fatalf("expected direct tunnel to be tried; received %v", v)
+	}
+	// the handshake keeps the direct tunnel
+	handshakes := map[string]time.Time{"laptop-key": now.Add(time.Minute * 5)}
+	if v := ids(directPeers(peers, handshakes, states, now.Add(time.Minute*6))); len(v) != 2 {
+		t.Errorf("expected direct tunnel to be kept; received %v", v)
+	}
+	// fall back to the node without a handshake
+	if v := ids(directPeers(peers, handshakes, states, now.Add(time.Minute*11))); len(v) != 1 || v[0] != "node" {
+		t.Errorf("expected fallback to the node; received %v", v)
+	}
+	if v := ids(directPeers(peers, handshakes, states, now.Add(time.Minute*20))); len(v) != 1 {
+		t.Errorf("expected fallback until the retry; received %v", v)
+	}
+	// retry the direct tunnel
+	if v := ids(directPeers(peers, handshakes, states, now.Add(time.Minute*27))); len(v) != 2 {
+		t.Errorf("expected direct tunnel to be retried; received %v", v)
+	}
+
+	// state is removed for peers that are no longer direct
+	directPeers(peers[:1], nil, states, now)
+	if len(states) != 0 {
+		t.Errorf("expected direct state to be removed; received %v", states)
+	}
+}
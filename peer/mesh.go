@@ -0,0 +1,98 @@
+package peer
+
+import (
+	"net"
+	"sort"
+	"strconv"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/gogo/protobuf/proto"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// meshListenPort is the default Wireguard port of mesh peers so that
+	// peers on the same network can reach each other
+	meshListenPort = 51820
+)
+
+// listenPort returns the Wireguard port of the peer
+func (p *Peer) listenPort() int {
+	if p.cfg.ListenPort == 0 && p.cfg.Mesh {
+		return meshListenPort
+	}
+	return p.cfg.ListenPort
+}
+
+// localAddresses returns the addresses with prefix of the local networks of
+// mesh peers.  The tunnel, loopback and link local addresses are skipped.
+func (p *Peer) localAddresses() []string {
+	if !p.cfg.Mesh {
+		return nil
+	}
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		logrus.WithError(err).Warn("unable to get local interfaces")
+		return nil
+	}
+	addresses := []string{}
+	for _, iface := range ifaces {
+		if iface.Name == p.cfg.InterfaceName || iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, a := range addrs {
+			n, ok := a.(*net.IPNet)
+			if !ok || n.IP.IsLinkLocalUnicast() || n.IP.IsLoopback() {
+				continue
+			}
+			addresses = append(addresses, n.String())
+		}
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+// localEndpoints replaces the endpoint of direct peers that share a local
+// network with the peer with their local address.  Peers behind the same NAT
+// have the same reflexive address which most routers do not hairpin.
+func localEndpoints(peers []*v1.Peer, local []string) []*v1.Peer {
+	var networks []*net.IPNet
+	for _, a := range local {
+		if _, n, err := net.ParseCIDR(a); err == nil {
+			networks = append(networks, n)
+		}
+	}
+	updated := make([]*v1.Peer, 0, len(peers))
+	for _, peer := range peers {
+		if endpoint := localEndpoint(peer, networks); endpoint != "" {
+			peer = proto.Clone(peer).(*v1.Peer)
+			peer.Endpoint = endpoint
+		}
+		updated = append(updated, peer)
+	}
+	return updated
+}
+
+// localEndpoint returns the local endpoint of the direct peer on one of the
+// networks
+func localEndpoint(peer *v1.Peer, networks []*net.IPNet) string {
+	if !peer.Direct || peer.ListenPort == 0 {
+		return ""
+	}
+	for _, a := range peer.LocalAddresses {
+		ip, _, err := net.ParseCIDR(a)
+		if err != nil {
+			continue
+		}
+		for _, n := range networks {
+			if n.Contains(ip) {
+				return net.JoinHostPort(ip.String(), strconv.FormatUint(peer.ListenPort, 10))
+			}
+		}
+	}
+	return ""
+}
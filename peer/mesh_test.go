@@ -0,0 +1,26 @@
+package peer
+
+import (
+	"testing"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+)
+
+func TestLocalEndpoints(t *testing.T) {
+	peers := []*v1.Peer{
+		{ID: "node", Endpoint: "10.0.0.1:10100", LocalAddresses: []string{"192.168.1.1/24"}, ListenPort: 10100},
+		{ID: "laptop-a", Endpoint: "203.0.113.10:51820", Direct: true, ListenPort: 51820, LocalAddresses: []string{"172.17.0.1/16", "192.168.1.20/24"}},
+		{ID: "laptop-b", Endpoint: "198.51.100.10:51820", Direct: true, ListenPort: 51820, LocalAddresses: []string{"10.1.0.5/24"}},
+	}
+
+	updated := localEndpoints(peers, []string{"192.168.1.30/24"})
+	expected := []string{"10.0.0.1:10100", "192.168.1.20:51820", "198.51.100.10:51820"}
+	for i, p := range updated {
+		if p.Endpoint != expected[i] {
+			t.Errorf("%s: expected endpoint %s; received %s", p.ID, expected[i], p.Endpoint)
+		}
+	}
+	if peers[1].Endpoint != "203.0.113.10:51820" {
+		t.Errorf("expected the received peer to not be modified; received %s", peers[1].Endpoint)
+	}
+}
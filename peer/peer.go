@@ -4,16 +4,29 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/ehazlett/heimdall"
+	v1 "github.com/ehazlett/heimdall/api/v1"
 	"github.com/ehazlett/heimdall/client"
 	"github.com/ehazlett/heimdall/version"
+	"github.com/ehazlett/heimdall/wg"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 )
 
 const (
+	wireguardKeyName = "wireguard.key"
+	// keepaliveInterval is how often the config sync stream is checked
+	keepaliveInterval = time.Second * 30
+)
+
+var (
+	// wireguardConfigDir is the directory of the generated tunnel config
 	wireguardConfigDir = "/etc/wireguard"
 )
 
@@ -21,52 +34,89 @@ const (
 type Peer struct {
 	cfg            *heimdall.PeerConfig
 	currentVersion string
+	privateKey     string
+	publicKey      string
+	wgDriver       wg.Driver
+
+	// mu protects the applied config and the direct tunnel state
+	mu     sync.Mutex
+	last   *v1.DesiredConfig
+	direct map[string]*directState
 }
 
 // NewPeer returns a new peer
 func NewPeer(cfg *heimdall.PeerConfig) (*Peer, error) {
 	return &Peer{
-		cfg: cfg,
+		cfg:    cfg,
+		direct: map[string]*directState{},
 	}, nil
 }
 
 // Run starts the peer
 func (p *Peer) Run() error {
-	// initial sync
-	logrus.Infof("connecting to peer %s", p.cfg.Address)
-	ctx, cancel := context.WithTimeout(context.Background(), p.cfg.UpdateInterval)
-	if err := p.sync(ctx); err != nil {
-		cancel()
+	// load or generate the local keypair; only the public key is sent to the cluster
+	privateKey, publicKey, err := wg.LoadOrGenerateKeys(context.Background(), filepath.Join(p.cfg.DataDir, wireguardKeyName))
+	if err != nil {
 		return err
 	}
-	cancel()
+	p.privateKey = privateKey
+	p.publicKey = publicKey
+	p.wgDriver = wg.NewDriver()
+	go p.directMonitor()
 
-	doneCh := make(chan bool)
-	errCh := make(chan error)
-
-	t := time.NewTicker(p.cfg.UpdateInterval)
+	// wait for the first config to be applied so that startup errors such as
+	// an unauthorized peer are returned
+	logrus.Infof("connecting to peer %s", p.cfg.Address)
+	applied := make(chan struct{})
+	var once sync.Once
+	onApply := func() {
+		once.Do(func() { close(applied) })
+	}
+	errCh := make(chan error, 1)
 	go func() {
-		for range t.C {
-			ctx, cancel := context.WithTimeout(context.Background(), p.cfg.UpdateInterval)
-			if err := p.sync(ctx); err != nil {
-				errCh <- err
-				cancel()
-				return
-			}
-			cancel()
-		}
+		errCh <- p.syncConfig(context.Background(), onApply)
 	}()
 	select {
-	case <-doneCh:
 	case err := <-errCh:
-		return err
+		if status.Code(err) != codes.Unimplemented {
+			return err
+		}
+		logrus.Warnf("%s does not support config sync; polling for config", p.cfg.Address)
+		return p.poll()
+	case <-applied:
+	}
+
+	for err := range errCh {
+		logrus.WithError(err).Warnf("config sync with %s closed; reconnecting in %s", p.cfg.Address, p.cfg.UpdateInterval)
+		time.Sleep(p.cfg.UpdateInterval)
+		go func() {
+			errCh <- p.syncConfig(context.Background(), onApply)
+		}()
 	}
 	return nil
 }
 
+// poll periodically requests the config from nodes that do not support
+// config sync
+func (p *Peer) poll() error {
+	t := time.NewTicker(p.cfg.UpdateInterval)
+	defer t.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), p.cfg.UpdateInterval)
+		err := p.sync(ctx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		<-t.C
+	}
+}
+
 // Stop stops the peer
 func (p *Peer) Stop() error {
-	// TODO
+	if p.wgDriver != nil {
+		return p.wgDriver.Close()
+	}
 	return nil
 }
 
@@ -92,6 +142,10 @@ func (p *Peer) getClient(addr string) (*client.Client, error) {
 	opts = append(opts,
 		grpc.WithBlock(),
 		grpc.WithUserAgent(fmt.Sprintf("%s/%s", version.Name, version.Version)),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    keepaliveInterval,
+			Timeout: keepaliveInterval,
+		}),
 	)
 
 	return client.NewClient(p.cfg.ID, addr, opts...)
@@ -3,6 +3,7 @@ package peer
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/ehazlett/heimdall"
 	v1 "github.com/ehazlett/heimdall/api/v1"
@@ -18,30 +19,112 @@ func (p *Peer) sync(ctx context.Context) error {
 	}
 	defer c.Close()
 
-	resp, err := c.Connect(ctx, &v1.ConnectRequest{
-		ID:   p.cfg.ID,
-		Name: p.cfg.Name,
-	})
+	resp, err := c.Connect(ctx, p.connectRequest())
 	if err != nil {
 		return err
 	}
 
+	return p.apply(ctx, resp.Address, resp.Peers, resp.DNS)
+}
+
+func (p *Peer) connectRequest() *v1.ConnectRequest {
+	return &v1.ConnectRequest{
+		ID:               p.cfg.ID,
+		Name:             p.cfg.Name,
+		PublicKey:        p.publicKey,
+		ExitNode:         p.cfg.ExitNode,
+		AdvertisedRoutes: p.cfg.AdvertisedRoutes,
+		Mesh:             p.cfg.Mesh,
+		ListenPort:       uint64(p.listenPort()),
+		LocalAddresses:   p.localAddresses(),
+	}
+}
+
+// syncConfig holds a config sync stream with the node and applies and
+// acknowledges each config version pushed by the node
+func (p *Peer) syncConfig(ctx context.Context, onApply func()) error {
+	logrus.Debugf("starting config sync with node %s", p.cfg.Address)
+	c, err := p.getClient(p.cfg.Address)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.SyncConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&v1.SyncConfigRequest{
+		Connect: p.connectRequest(),
+	}); err != nil {
+		return err
+	}
+
+	for {
+		cfg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		logrus.Debugf("received config version %d", cfg.Version)
+		ack := p.applyConfig(ctx, cfg)
+		if ack.Error == "" {
+			onApply()
+		}
+		if err := stream.Send(&v1.SyncConfigRequest{
+			Ack: ack,
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// applyConfig applies the config pushed by the node and returns the ack.  The
+// ack only reports success if the tunnel was updated to the config.
+func (p *Peer) applyConfig(ctx context.Context, cfg *v1.DesiredConfig) *v1.ConfigAck {
+	ack := &v1.ConfigAck{
+		Version: cfg.Version,
+	}
+	if err := p.apply(ctx, cfg.Address, cfg.Peers, cfg.DNS); err != nil {
+		logrus.WithError(err).Errorf("error applying config version %d", cfg.Version)
+		ack.Error = err.Error()
+	}
+	return ack
+}
+
+// apply updates the local tunnel with the config
+func (p *Peer) apply(ctx context.Context, address string, nodePeers []*v1.Peer, dns []string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.last = &v1.DesiredConfig{
+		Address: address,
+		Peers:   nodePeers,
+		DNS:     dns,
+	}
+
 	peers := []*v1.Peer{}
-	for _, peer := range resp.Peers {
+	for _, peer := range nodePeers {
 		// don't add self
 		if peer.ID == p.cfg.ID {
 			continue
 		}
 		peers = append(peers, peer)
 	}
+	peers = directPeers(peers, p.handshakes(ctx), p.direct, time.Now())
+	if p.cfg.Mesh {
+		peers = localEndpoints(peers, p.localAddresses())
+	}
 
 	// generate wireguard config
 	wireguardCfg := &wg.Config{
 		Interface:  p.cfg.InterfaceName,
-		Address:    resp.Address,
-		PrivateKey: resp.KeyPair.PrivateKey,
+		ListenPort: p.listenPort(),
+		Address:    address,
+		PrivateKey: p.privateKey,
 		Peers:      peers,
-		DNS:        resp.DNS,
+		DNS:        dns,
 	}
 
 	wireguardConfigPath := p.getWireguardConfigPath()
@@ -59,7 +142,6 @@ func (p *Peer) sync(ctx context.Context) error {
 	if h == p.currentVersion {
 		return nil
 	}
-	p.currentVersion = h
 
 	logrus.Debugf("updating peer config to version %s", h)
 	// update wireguard config
@@ -67,10 +149,22 @@ func (p *Peer) sync(ctx context.Context) error {
 		return err
 	}
 
-	// reload wireguard
-	if err := wg.RestartTunnel(ctx, p.getTunnelName()); err != nil {
+	// apply to the live interface
+	if err := p.wgDriver.Apply(ctx, wireguardCfg); err != nil {
 		return err
 	}
+	// only record the version once applied so a failed config is retried
+	p.currentVersion = h
 
 	return nil
 }
+
+// handshakes returns the latest handshake of each peer of the local tunnel
+func (p *Peer) handshakes(ctx context.Context) map[string]time.Time {
+	handshakes, err := p.wgDriver.Handshakes(ctx, p.cfg.InterfaceName)
+	if err != nil {
+		logrus.WithError(err).Debugf("unable to get handshakes for %s", p.cfg.InterfaceName)
+		return nil
+	}
+	return handshakes
+}
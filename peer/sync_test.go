@@ -0,0 +1,86 @@
+package peer
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ehazlett/heimdall"
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/ehazlett/heimdall/wg"
+)
+
+// testDriver records the applied configs and fails while err is set
+type testDriver struct {
+	err     error
+	applied int
+}
+
+func (d *testDriver) Apply(ctx context.Context, cfg *wg.Config) error {
+	if d.err != nil {
+		return d.err
+	}
+	d.applied++
+	return nil
+}
+
+func (d *testDriver) Handshakes(ctx context.Context, iface string) (map[string]time.Time, error) {
+	return nil, nil
+}
+
+func (d *testDriver) Endpoints(ctx context.Context, iface string) (map[string]string, error) {
+	return nil, nil
+}
+
+func (d *testDriver) Close() error {
+	return nil
+}
+
+func TestApplyConfigRetry(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	configDir := wireguardConfigDir
+	wireguardConfigDir = tmpDir
+	defer func() { wireguardConfigDir = configDir }()
+
+	d := &testDriver{}
+	p := &Peer{
+		cfg:      &heimdall.PeerConfig{ID: "peer-a", InterfaceName: "darknet"},
+		wgDriver: d,
+		direct:   map[string]*directState{},
+	}
+	ctx := context.Background()
+	cfg := func(version uint64, address string) *v1.DesiredConfig {
+		return &v1.DesiredConfig{
+			Version: version,
+			Address: address,
+			Peers: []*v1.Peer{
+				{ID: "node-a", PublicKey: "node-key", Endpoint: "10.0.0.1:10100", AllowedIPs: []string{"10.10.0.0/24"}},
+			},
+		}
+	}
+
+	if ack := p.applyConfig(ctx, cfg(1, "10.51.0.2/16")); ack.Error != "" || d.applied != 1 {
+		t.Fatalf("expected version 1 to be applied; received %+v", ack)
+	}
+
+	d.err = errors.New("device busy")
+	if ack := p.applyConfig(ctx, cfg(2, "10.51.0.3/16")); ack.Error == "" {
+		t.Fatal("expected failed apply to be acked with an error")
+	}
+	// the same version is pushed again and must be applied instead of
+	// acked as unchanged
+	if ack := p.applyConfig(ctx, cfg(2, "10.51.0.3/16")); ack.Error == "" {
+		t.Fatal("expected re-pushed config to be applied and fail again")
+	}
+	d.err = nil
+	if ack := p.applyConfig(ctx, cfg(2, "10.51.0.3/16")); ack.Error != "" || d.applied != 2 {
+		t.Fatalf("expected version 2 to be applied on retry; received %+v applied=%d", ack, d.applied)
+	}
+}
@@ -2,12 +2,12 @@ package server
 
 import (
 	"context"
-	"fmt"
-	"strings"
+	"sort"
 
 	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/ehazlett/heimdall/store"
+	"github.com/ehazlett/heimdall/wg"
 	ptypes "github.com/gogo/protobuf/types"
-	"github.com/gomodule/redigo/redis"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -15,10 +15,19 @@ import (
 var (
 	// ErrAccessDenied is returned when an unauthorized non-node peer attempts to join
 	ErrAccessDenied = errors.New("access denied")
+	// ErrInvalidPublicKey is returned when a missing or malformed public key is specified
+	ErrInvalidPublicKey = errors.New("invalid public key")
+	// ErrInvalidExitNode is returned when the requested exit node is not an exit node
+	ErrInvalidExitNode = errors.New("invalid exit node")
+	// ErrPublicKeyMismatch is returned when a peer connects with another key than it was first connected with
+	ErrPublicKeyMismatch = errors.New("public key does not match the peer; authorize the peer again to change its key")
+
+	// defaultRoutes are routed to the exit node of a peer
+	defaultRoutes = []string{"0.0.0.0/0", "::/0"}
 )
 
 func (s *Server) AuthorizedPeers(ctx context.Context, req *v1.AuthorizedPeersRequest) (*v1.AuthorizedPeersResponse, error) {
-	authorized, err := redis.Strings(s.local(ctx, "SMEMBERS", authorizedPeersKey))
+	authorized, err := s.store.AuthorizedPeers(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -30,9 +39,40 @@ func (s *Server) AuthorizedPeers(ctx context.Context, req *v1.AuthorizedPeersReq
 // AuthorizePeer authorizes a peer to the cluster
 func (s *Server) AuthorizePeer(ctx context.Context, req *v1.AuthorizePeerRequest) (*ptypes.Empty, error) {
 	logrus.Debugf("authorizing peer %s", req.ID)
-	if _, err := s.master(ctx, "SADD", authorizedPeersKey, req.ID); err != nil {
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.IPs) > 0 {
+		if err := s.reservePeerIPs(ctx, req.ID, req.IPs); err != nil {
+			return nil, err
+		}
+		logrus.Infof("reserved %v for peer %s", req.IPs, req.ID)
+	}
+	if err := s.store.AuthorizePeer(ctx, req.ID); err != nil {
 		return nil, err
 	}
+	// authorizing the peer again unpins its key so it can connect with a
+	// new key
+	if _, err := s.store.GetPeer(ctx, req.ID); err == nil {
+		logrus.Infof("unpinning public key of peer %s", req.ID)
+		if err := s.store.DeletePeer(ctx, req.ID); err != nil {
+			return nil, err
+		}
+	} else if err != store.ErrNotFound {
+		return nil, err
+	}
+	if len(tags) > 0 {
+		if err := s.store.SetPeerTags(ctx, req.ID, tags); err != nil {
+			return nil, err
+		}
+	}
+	if len(req.IPs) > 0 {
+		// notify nodes to update the peer config
+		if err := s.store.Publish(ctx, store.EventUpdateTunnel); err != nil {
+			return nil, err
+		}
+	}
 	logrus.Infof("authorized peer %s", req.ID)
 	return empty, nil
 }
@@ -40,14 +80,23 @@ func (s *Server) AuthorizePeer(ctx context.Context, req *v1.AuthorizePeerRequest
 // DeauthorizePeer deauthorizes a peer from the cluster
 func (s *Server) DeauthorizePeer(ctx context.Context, req *v1.DeauthorizePeerRequest) (*ptypes.Empty, error) {
 	logrus.Debugf("deauthorizing peer %s", req.ID)
-	if _, err := s.master(ctx, "SREM", authorizedPeersKey, req.ID); err != nil {
+	if err := s.store.DeauthorizePeer(ctx, req.ID); err != nil {
+		return nil, err
+	}
+	if err := s.store.DeletePeer(ctx, req.ID); err != nil {
 		return nil, err
 	}
-	if _, err := s.master(ctx, "DEL", s.getPeerKey(req.ID)); err != nil {
+	if err := s.store.DeletePeerConfigStatus(ctx, req.ID); err != nil {
 		return nil, err
 	}
-	// notify to restart tunnels
-	if _, err := s.master(ctx, "PUBLISH", nodeEventRestartTunnelKey, "1"); err != nil {
+	if err := s.store.SetPeerTags(ctx, req.ID, nil); err != nil {
+		return nil, err
+	}
+	if err := s.advertiseRoutes(ctx, req.ID, nil); err != nil {
+		return nil, err
+	}
+	// notify nodes to update tunnels
+	if err := s.store.Publish(ctx, store.EventUpdateTunnel); err != nil {
 		return nil, err
 	}
 	logrus.Infof("deauthorized peer %s", req.ID)
@@ -56,46 +105,273 @@ func (s *Server) DeauthorizePeer(ctx context.Context, req *v1.DeauthorizePeerReq
 
 // Connect is called when a non-node peer wants to connect to the cluster
 func (s *Server) Connect(ctx context.Context, req *v1.ConnectRequest) (*v1.ConnectResponse, error) {
-	authorized, err := redis.Bool(s.local(ctx, "SISMEMBER", authorizedPeersKey, req.ID))
+	if _, err := s.connectPeer(ctx, req); err != nil {
+		return nil, err
+	}
+	cfg, err := s.desiredConfig(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ConnectResponse{
+		Address: cfg.Address,
+		Peers:   cfg.Peers,
+		DNS:     cfg.DNS,
+	}, nil
+}
+
+// connectPeer registers the non-node peer when it connects.  The advertised
+// routes, peer addresses and peer info are written once per connect so that
+// computing the desired config does not write to the store.
+func (s *Server) connectPeer(ctx context.Context, req *v1.ConnectRequest) (*v1.Peer, error) {
+	if err := s.checkPeerAccess(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := s.advertiseRoutes(ctx, req.ID, req.AdvertisedRoutes); err != nil {
+		return nil, err
+	}
+	if _, err := s.getOrAllocatePeerIPs(ctx, req.ID); err != nil {
+		return nil, err
+	}
+	info, err := s.connectedPeerInfo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.savePeerInfo(ctx, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// connectedPeerInfo returns the peer info of the non-node peer as observed
+// by the local node
+func (s *Server) connectedPeerInfo(ctx context.Context, req *v1.ConnectRequest) (*v1.Peer, error) {
+	nodes, err := s.getNodes(ctx)
 	if err != nil {
 		return nil, err
 	}
+	exitNode, err := findExitNode(nodes, req.ExitNode)
+	if err != nil {
+		return nil, err
+	}
+	exitNodeID := ""
+	if exitNode != nil {
+		exitNodeID = exitNode.ID
+	}
+	return s.peerInfo(ctx, &v1.Peer{
+		ID:                req.ID,
+		Name:              req.Name,
+		PublicKey:         req.PublicKey,
+		ExitNode:          exitNodeID,
+		ReflexiveEndpoint: s.reflexiveEndpoint(ctx, req.PublicKey),
+		RemoteAddress:     remoteAddress(ctx),
+		Mesh:              req.Mesh,
+		ListenPort:        req.ListenPort,
+		LocalAddresses:    req.LocalAddresses,
+	})
+}
+
+// checkPeerAccess returns an error if the non-node peer is not authorized or
+// connects with an invalid key
+func (s *Server) checkPeerAccess(ctx context.Context, req *v1.ConnectRequest) error {
+	authorized, err := s.store.IsAuthorized(ctx, req.ID)
+	if err != nil {
+		return err
+	}
 	if !authorized {
 		logrus.Warnf("unauthorized request attempt from %s", req.ID)
-		return nil, ErrAccessDenied
+		return ErrAccessDenied
 	}
-	keyPair, err := s.getOrCreateKeyPair(ctx, req.ID)
-	if err != nil {
+	if !wg.ValidKey(req.PublicKey) {
+		return ErrInvalidPublicKey
+	}
+	return s.checkPeerKey(ctx, req.ID, req.PublicKey)
+}
+
+// desiredConfig returns the tunnel config for the non-node peer.  Peers and
+// addresses are sorted so that the config can be compared by hash.  The
+// peer must have been registered with connectPeer and the store is only
+// read.
+func (s *Server) desiredConfig(ctx context.Context, req *v1.ConnectRequest) (*v1.DesiredConfig, error) {
+	if err := s.checkPeerAccess(ctx, req); err != nil {
 		return nil, err
 	}
 	nodes, err := s.getNodes(ctx)
 	if err != nil {
 		return nil, err
 	}
+	exitNode, err := findExitNode(nodes, req.ExitNode)
+	if err != nil {
+		return nil, err
+	}
 	dnsAddrs := []string{}
+	excluded := map[string]struct{}{}
 	for _, n := range nodes {
+		// draining and unhealthy nodes are not advertised to peers
+		if n.Draining || !n.Healthy {
+			excluded[n.ID] = struct{}{}
+			continue
+		}
 		dnsAddrs = append(dnsAddrs, n.GatewayIP)
+		if n.GatewayIPV6 != "" {
+			dnsAddrs = append(dnsAddrs, n.GatewayIPV6)
+		}
+	}
+	sort.Strings(dnsAddrs)
+
+	allPeers, err := s.getPeers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hidden, err := s.hiddenRoutes(ctx, req.ID, allPeers)
+	if err != nil {
+		return nil, err
+	}
+	peers := []*v1.Peer{}
+	for _, p := range allPeers {
+		if _, ok := excluded[p.ID]; ok {
+			continue
+		}
+		allowedIPs := []string{}
+		for _, a := range p.AllowedIPs {
+			if _, ok := hidden[a]; !ok {
+				allowedIPs = append(allowedIPs, a)
+			}
+		}
+		// route all traffic not destined to the cluster through the exit node
+		if exitNode != nil && p.ID == exitNode.ID {
+			allowedIPs = append(allowedIPs, defaultRoutes...)
+		}
+		p.AllowedIPs = allowedIPs
+		sort.Strings(p.AllowedIPs)
+		peers = append(peers, p)
 	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].ID < peers[j].ID })
 
-	peers, err := s.getPeers(ctx)
+	routes, err := s.getRoutes(ctx)
 	if err != nil {
 		return nil, err
 	}
-	ip, _, err := s.getOrAllocatePeerIP(ctx, req.ID)
+	relayPeerRoutes(req.ID, peers, routes, hidden)
+	policies, err := s.store.GetPolicies(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if err := s.updatePeerInfo(ctx, req.ID, req.Name); err != nil {
+	peers = directPeers(req.ID, peers, policies, s.cfg.AllowPeerToPeer, req.Mesh)
+
+	addrs, err := s.getPeerAddrs(ctx, req.ID)
+	if err != nil {
 		return nil, err
 	}
+	if exitNode != nil {
+		if _, ok := excluded[exitNode.ID]; ok {
+			logrus.Warnf("exit node %s of peer %s is unavailable", exitNode.ID, req.ID)
+		}
+	}
 
-	subnetParts := strings.Split(s.cfg.PeerNetwork, "/")
-	subnetCIDR := subnetParts[1]
-	// save peer
-	return &v1.ConnectResponse{
-		KeyPair: keyPair,
-		Address: fmt.Sprintf("%s/%s", ip.String(), subnetCIDR),
+	return &v1.DesiredConfig{
+		Address: joinAddresses(addrs),
 		Peers:   peers,
 		DNS:     dnsAddrs,
 	}, nil
 }
+
+// checkPeerKey returns ErrPublicKeyMismatch if the peer connects with another
+// key than the key pinned on its first connect.  The key stays pinned until
+// the peer is authorized again.
+func (s *Server) checkPeerKey(ctx context.Context, id, publicKey string) error {
+	p, err := s.store.GetPeer(ctx, id)
+	if err != nil {
+		if err == store.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+	if p.PublicKey != "" && p.PublicKey != publicKey {
+		logrus.Warnf("rejecting connect from %s with a different public key", id)
+		return ErrPublicKeyMismatch
+	}
+	return nil
+}
+
+// hiddenRoutes returns the networks of the routes that are limited to tags
+// the peer does not have
+func (s *Server) hiddenRoutes(ctx context.Context, id string, peers []*v1.Peer) (map[string]struct{}, error) {
+	routes, err := s.getRoutes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for _, p := range peers {
+		if p.ID == id {
+			tags = p.Tags
+		}
+	}
+	hidden := map[string]struct{}{}
+	for _, r := range routes {
+		if len(r.Tags) > 0 && !hasAnyTag(tags, r.Tags) {
+			hidden[r.Network] = struct{}{}
+		}
+	}
+	return hidden, nil
+}
+
+// relayPeerRoutes moves the networks of routes advertised by other peers to
+// the first node of the peers.  Peers only have tunnels to nodes so the node
+// relays the traffic to the advertising peer.  Routes are only relayed while
+// the advertising peer is active for them.
+func relayPeerRoutes(id string, peers []*v1.Peer, routes []*v1.Route, hidden map[string]struct{}) {
+	active := map[string]string{}
+	for _, p := range peers {
+		for _, a := range p.AllowedIPs {
+			active[a] = p.ID
+		}
+	}
+	networks := map[string]struct{}{}
+	relayed := []string{}
+	for _, r := range routes {
+		if r.PeerID == "" {
+			continue
+		}
+		networks[r.Network] = struct{}{}
+		if _, ok := hidden[r.Network]; ok || r.PeerID == id || active[r.Network] != r.PeerID {
+			continue
+		}
+		relayed = append(relayed, r.Network)
+	}
+	if len(networks) == 0 {
+		return
+	}
+	relay := false
+	for _, p := range peers {
+		allowedIPs := []string{}
+		for _, a := range p.AllowedIPs {
+			if _, ok := networks[a]; !ok {
+				allowedIPs = append(allowedIPs, a)
+			}
+		}
+		if !relay && p.Endpoint != "" {
+			relay = true
+			allowedIPs = append(allowedIPs, relayed...)
+			sort.Strings(allowedIPs)
+		}
+		p.AllowedIPs = allowedIPs
+	}
+}
+
+// findExitNode returns the exit node by name or id.  No node is returned if
+// name is empty.
+func findExitNode(nodes []*v1.Node, name string) (*v1.Node, error) {
+	if name == "" {
+		return nil, nil
+	}
+	for _, n := range nodes {
+		if n.ID != name && n.Name != name {
+			continue
+		}
+		if !n.ExitNode {
+			return nil, errors.Wrapf(ErrInvalidExitNode, "%s is not an exit node", name)
+		}
+		return n, nil
+	}
+	return nil, errors.Wrapf(ErrInvalidExitNode, "node %s not found", name)
+}
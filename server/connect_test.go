@@ -0,0 +1,117 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ehazlett/heimdall"
+	v1 "github.com/ehazlett/heimdall/api/v1"
+)
+
+func TestPeerKeyPinned(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	s, err := NewServer(&heimdall.Config{
+		ID:           "test",
+		NodeNetwork:  testNodeNetwork,
+		PeerNetwork:  testPeerNetwork,
+		DataDir:      tmpDir,
+		StoreBackend: StoreBackendEmbedded,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	key := func(b byte) string {
+		return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+	}
+	if _, err := s.AuthorizePeer(ctx, &v1.AuthorizePeerRequest{ID: "peer-a"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Connect(ctx, &v1.ConnectRequest{ID: "peer-a", PublicKey: key(1)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Connect(ctx, &v1.ConnectRequest{ID: "peer-a", PublicKey: key(2)}); err != ErrPublicKeyMismatch {
+		t.Fatalf("expected ErrPublicKeyMismatch; received %v", err)
+	}
+	p, err := s.store.GetPeer(ctx, "peer-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.PublicKey != key(1) {
+		t.Errorf("expected pinned key to be kept; received %s", p.PublicKey)
+	}
+
+	// authorizing the peer again allows a new key
+	if _, err := s.AuthorizePeer(ctx, &v1.AuthorizePeerRequest{ID: "peer-a"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Connect(ctx, &v1.ConnectRequest{ID: "peer-a", PublicKey: key(2)}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDesiredConfigReadOnly(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	s, err := NewServer(&heimdall.Config{
+		ID:           "test",
+		NodeNetwork:  testNodeNetwork,
+		PeerNetwork:  testPeerNetwork,
+		DataDir:      tmpDir,
+		StoreBackend: StoreBackendEmbedded,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	req := &v1.ConnectRequest{
+		ID:               "peer-a",
+		PublicKey:        base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32)),
+		AdvertisedRoutes: []string{"192.168.1.0/24"},
+	}
+	if _, err := s.AuthorizePeer(ctx, &v1.AuthorizePeerRequest{ID: req.ID}); err != nil {
+		t.Fatal(err)
+	}
+	// the config of a peer that has not connected cannot be computed
+	if _, err := s.desiredConfig(ctx, req); err == nil {
+		t.Fatal("expected error computing the config of a peer without an address")
+	}
+	if _, err := s.Connect(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	revision, err := s.store.Revision(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		cfg, err := s.desiredConfig(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Address == "" {
+			t.Error("expected peer address")
+		}
+	}
+	v, err := s.store.Revision(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != revision {
+		t.Errorf("expected no writes computing the desired config; revision %d -> %d", revision, v)
+	}
+}
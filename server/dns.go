@@ -12,7 +12,7 @@ import (
 func (s *Server) startDNSServer() error {
 	dns.HandleFunc(".", s.dnsQueryHandler)
 
-	for _, proto := range []string{"tcp4", "udp4"} {
+	for _, proto := range []string{"tcp", "udp"} {
 		srv := &dns.Server{
 			Addr: s.cfg.DNSServerAddress,
 			Net:  proto,
@@ -43,7 +43,7 @@ func (s *Server) dnsQueryHandler(w dns.ResponseWriter, r *dns.Msg) {
 	// resolve by node first then peers
 	logrus.Debugf("dns: looking up %s", name)
 	var (
-		gatewayIP net.IP
+		found     bool
 		recordIPs []net.IP
 	)
 
@@ -54,14 +54,15 @@ func (s *Server) dnsQueryHandler(w dns.ResponseWriter, r *dns.Msg) {
 		return
 	}
 	for _, n := range nodes {
-		if n.Name == name {
+		if n.Name == name && n.Healthy {
 			logrus.Debugf("gateway node: %+v", n)
-			gatewayIP = net.ParseIP(n.GatewayIP)
+			found = true
+			recordIPs = append(recordIPs, recordIP(queryType, n.GatewayIP, n.GatewayIPV6)...)
 			break
 		}
 	}
 
-	if gatewayIP == nil {
+	if !found {
 		peers, err := s.getPeers(ctx)
 		if err != nil {
 			logrus.WithError(err).Error("error getting nodes")
@@ -70,13 +71,24 @@ func (s *Server) dnsQueryHandler(w dns.ResponseWriter, r *dns.Msg) {
 		}
 		for _, p := range peers {
 			if p.Name == name {
-				recordIPs = append(recordIPs, net.ParseIP(p.PeerIP))
+				found = true
+				recordIPs = append(recordIPs, recordIP(queryType, p.PeerIP, p.PeerIPV6)...)
+			}
+		}
+		// resolve tags to all peers with the tag
+		if !found {
+			tag := strings.ToLower(name)
+			for _, p := range peers {
+				if hasTag(p.Tags, tag) {
+					found = true
+					recordIPs = append(recordIPs, recordIP(queryType, p.PeerIP, p.PeerIPV6)...)
+				}
 			}
 		}
 	}
 
 	// forward if empty
-	if gatewayIP == nil && len(recordIPs) == 0 {
+	if !found {
 		x, err := dns.Exchange(r, s.cfg.DNSUpstreamAddress)
 		if err != nil {
 			logrus.Errorf("dns: error forwarding lookup: %+v", err)
@@ -95,19 +107,19 @@ func (s *Server) dnsQueryHandler(w dns.ResponseWriter, r *dns.Msg) {
 	m.Extra = []dns.RR{}
 
 	records := []dns.RR{}
-	if gatewayIP != nil {
-		records = append(records, &dns.A{
-			Hdr: dns.RR_Header{
-				Name:   fqdn(name),
-				Rrtype: dns.TypeA,
-				Class:  dns.ClassINET,
-				Ttl:    10,
-			},
-			A: gatewayIP,
-		})
-	}
-
 	for _, r := range recordIPs {
+		if queryType == dns.TypeAAAA {
+			records = append(records, &dns.AAAA{
+				Hdr: dns.RR_Header{
+					Name:   fqdn(name),
+					Rrtype: dns.TypeAAAA,
+					Class:  dns.ClassINET,
+					Ttl:    10,
+				},
+				AAAA: r,
+			})
+			continue
+		}
 		records = append(records, &dns.A{
 			Hdr: dns.RR_Header{
 				Name:   fqdn(name),
@@ -122,6 +134,22 @@ func (s *Server) dnsQueryHandler(w dns.ResponseWriter, r *dns.Msg) {
 	m.Answer = records
 }
 
+// recordIP returns the IPv6 addresses for AAAA queries and the IPv4
+// addresses for all others
+func recordIP(queryType uint16, ips ...string) []net.IP {
+	var records []net.IP
+	for _, ip := range ips {
+		v := net.ParseIP(ip)
+		if v == nil {
+			continue
+		}
+		if (v.To4() == nil) == (queryType == dns.TypeAAAA) {
+			records = append(records, v)
+		}
+	}
+	return records
+}
+
 func getName(query string, queryType uint16) string {
 	// adjust lookup for srv
 	if queryType == dns.TypeSRV {
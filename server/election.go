@@ -0,0 +1,248 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/ehazlett/heimdall/store"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// masterLeaseTTL is how long the master lease is held without renewal
+	masterLeaseTTL = masterHeartbeatInterval * 3
+	// voteRequestTimeout is the timeout for requesting a vote from a node
+	voteRequestTimeout = time.Second * 2
+
+	// ErrNoMasterFound is returned when no node reports an active master
+	ErrNoMasterFound = errors.New("no active master found")
+)
+
+// RequestVote is called by a candidate to request a vote for the term.  A
+// node votes for at most one candidate per term and only when it does not
+// see an active master lease.
+func (s *Server) RequestVote(ctx context.Context, req *v1.RequestVoteRequest) (*v1.RequestVoteResponse, error) {
+	key, err := s.getClusterKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.ClusterKey != key {
+		return nil, ErrInvalidAuth
+	}
+	term, err := s.store.GetTerm(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.electionMu.Lock()
+	defer s.electionMu.Unlock()
+
+	if s.votedTerm > term {
+		term = s.votedTerm
+	}
+	resp := &v1.RequestVoteResponse{
+		Term: term,
+	}
+	if req.Term < term || (req.Term == term && s.votedFor != req.CandidateID) {
+		logrus.Debugf("rejecting vote for %s in term %d: current term %d", req.CandidateID, req.Term, term)
+		return resp, nil
+	}
+	if m, err := s.store.GetMaster(ctx); err == nil {
+		logrus.Debugf("rejecting vote for %s in term %d: master %s is active", req.CandidateID, req.Term, m.ID)
+		return resp, nil
+	} else if err != store.ErrNotFound {
+		return nil, err
+	}
+
+	s.votedTerm = req.Term
+	s.votedFor = req.CandidateID
+	logrus.Infof("voted for %s in term %d", req.CandidateID, req.Term)
+
+	resp.Granted = true
+	resp.Term = req.Term
+	return resp, nil
+}
+
+// currentTerm returns the master term known to the local node
+func (s *Server) currentTerm() uint64 {
+	s.electionMu.Lock()
+	defer s.electionMu.Unlock()
+	return s.term
+}
+
+// setTerm updates the master term used to fence writes to the store
+func (s *Server) setTerm(term uint64) {
+	s.electionMu.Lock()
+	defer s.electionMu.Unlock()
+	if s.term != term {
+		logrus.Infof("master term updated: %d -> %d", s.term, term)
+	}
+	s.term = term
+	if s.redis != nil {
+		s.redis.SetTerm(term)
+	}
+}
+
+// nextTerm returns the next term that has not been voted for
+func (s *Server) nextTerm(ctx context.Context) (uint64, error) {
+	term, err := s.store.GetTerm(ctx)
+	if err != nil {
+		return 0, err
+	}
+	s.electionMu.Lock()
+	defer s.electionMu.Unlock()
+	if s.votedTerm > term {
+		term = s.votedTerm
+	}
+	return term + 1, nil
+}
+
+// acquireMaster acquires the master lease for the local node
+func (s *Server) acquireMaster(ctx context.Context, term uint64) error {
+	m, err := s.masterInfo(ctx)
+	if err != nil {
+		return err
+	}
+	m.Term = term
+	if err := s.store.AcquireMaster(ctx, m, masterLeaseTTL); err != nil {
+		return err
+	}
+	s.setTerm(term)
+	logrus.Infof("acquired master lease id=%s term=%d", m.ID, term)
+	return nil
+}
+
+// elect requests votes from the remaining nodes for the next term and
+// returns the term and whether a majority voted for the local node
+func (s *Server) elect(ctx context.Context, previous string) (uint64, bool, error) {
+	term, err := s.nextTerm(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	nodes, err := s.electorate(ctx, previous)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// vote for self
+	s.electionMu.Lock()
+	s.votedTerm = term
+	s.votedFor = s.cfg.ID
+	s.electionMu.Unlock()
+
+	logrus.Infof("starting election for term %d", term)
+	votes := 1
+	for _, n := range nodes {
+		if n.ID == s.cfg.ID {
+			continue
+		}
+		resp, err := s.requestVote(ctx, n, term)
+		if err != nil {
+			logrus.WithError(err).Warnf("error requesting vote from %s", n.ID)
+			continue
+		}
+		if resp.Granted {
+			votes++
+			continue
+		}
+		if resp.Term > term {
+			// skip ahead so the next election uses a newer term
+			s.electionMu.Lock()
+			if resp.Term > s.votedTerm {
+				s.votedTerm = resp.Term
+				s.votedFor = ""
+			}
+			s.electionMu.Unlock()
+		}
+	}
+
+	quorum := len(nodes)/2 + 1
+	elected := votes >= quorum
+	logrus.Infof("election for term %d: votes=%d quorum=%d elected=%t", term, votes, quorum, elected)
+	return term, elected, nil
+}
+
+func (s *Server) requestVote(ctx context.Context, n *v1.Node, term uint64) (*v1.RequestVoteResponse, error) {
+	c, err := s.getClient(n.Addr)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, voteRequestTimeout)
+	defer cancel()
+	return c.RequestVote(ctx, &v1.RequestVoteRequest{
+		Term:        term,
+		CandidateID: s.cfg.ID,
+		ClusterKey:  s.cfg.ClusterKey,
+	})
+}
+
+// electorate returns the nodes that vote in an election which excludes the
+// previous master
+func (s *Server) electorate(ctx context.Context, previous string) ([]*v1.Node, error) {
+	nodes, err := s.getNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	electorate := []*v1.Node{}
+	self := false
+	for _, n := range nodes {
+		if n.ID == previous && n.ID != s.cfg.ID {
+			continue
+		}
+		if n.ID == s.cfg.ID {
+			self = true
+		}
+		electorate = append(electorate, n)
+	}
+	if !self {
+		electorate = append(electorate, &v1.Node{ID: s.cfg.ID})
+	}
+	sort.Slice(electorate, func(i, j int) bool { return electorate[i].ID < electorate[j].ID })
+	return electorate, nil
+}
+
+// candidateRank returns the position of the local node in the electorate
+// which is used to stagger candidates
+func (s *Server) candidateRank(ctx context.Context, previous string) (int, error) {
+	nodes, err := s.electorate(ctx, previous)
+	if err != nil {
+		return 0, err
+	}
+	for i, n := range nodes {
+		if n.ID == s.cfg.ID {
+			return i, nil
+		}
+	}
+	return len(nodes), nil
+}
+
+// findMaster asks the other nodes for an active master with a newer term
+// than the local node
+func (s *Server) findMaster(ctx context.Context, previous string) (*v1.Master, error) {
+	nodes, err := s.getNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		if n.ID == s.cfg.ID || n.ID == previous {
+			continue
+		}
+		m, err := s.remoteMaster(ctx, n.Addr)
+		if err != nil {
+			logrus.Debugf("error getting master from node %s: %s", n.ID, err)
+			continue
+		}
+		if m == nil {
+			continue
+		}
+		if m.Term > s.currentTerm() {
+			return m, nil
+		}
+	}
+	return nil, ErrNoMasterFound
+}
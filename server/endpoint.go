@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"net"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/sirupsen/logrus"
+	grpcpeer "google.golang.org/grpc/peer"
+)
+
+// reflexiveEndpoint returns the endpoint of the peer as seen by the local
+// tunnel.  Behind NAT this is the public address and port of the peer.
+func (s *Server) reflexiveEndpoint(ctx context.Context, publicKey string) string {
+	if s.wgDriver == nil {
+		return ""
+	}
+	endpoints, err := s.wgDriver.Endpoints(ctx, s.cfg.InterfaceName)
+	if err != nil {
+		logrus.WithError(err).Debugf("unable to get endpoints for %s", s.cfg.InterfaceName)
+		return ""
+	}
+	return endpoints[publicKey]
+}
+
+// remoteAddress returns the source host of the grpc request.  The port is
+// dropped as it changes with every connection.
+func remoteAddress(ctx context.Context) string {
+	p, ok := grpcpeer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	addr := p.Addr.String()
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// directPeers returns the nodes and, if peer to peer is allowed, the peers
+// with a reflexive endpoint as direct tunnels.  Mesh peers get all other mesh
+// peers even without a known endpoint as the other peer can initiate the
+// tunnel.  Direct peers are only allowed their own addresses so all other
+// traffic is still relayed by the nodes.  Policies are enforced by the nodes
+// so peers with traffic between them restricted by a policy are relayed as
+// well.
+func directPeers(id string, peers []*v1.Peer, policies []*v1.Policy, allowPeerToPeer, mesh bool) []*v1.Peer {
+	var self *v1.Peer
+	for _, p := range peers {
+		if p.ID == id {
+			self = p
+		}
+	}
+	direct := []*v1.Peer{}
+	for _, p := range peers {
+		if p.Endpoint != "" {
+			direct = append(direct, p)
+			continue
+		}
+		if !allowPeerToPeer || self == nil || p.ID == id {
+			continue
+		}
+		if p.ReflexiveEndpoint == "" && !(mesh && p.Mesh) {
+			continue
+		}
+		if policyRestricts(policies, self, p) || policyRestricts(policies, p, self) {
+			continue
+		}
+		allowedIPs := []string{}
+		for _, ip := range []string{p.PeerIP, p.PeerIPV6} {
+			if v := net.ParseIP(ip); v != nil {
+				allowedIPs = append(allowedIPs, hostPrefix(v))
+			}
+		}
+		p.AllowedIPs = allowedIPs
+		p.Endpoint = p.ReflexiveEndpoint
+		p.Direct = true
+		direct = append(direct, p)
+	}
+	return direct
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	grpcpeer "google.golang.org/grpc/peer"
+)
+
+func TestRemoteAddress(t *testing.T) {
+	for addr, expected := range map[string]string{
+		"203.0.113.10:41000":  "203.0.113.10",
+		"[2001:db8::1]:41000": "2001:db8::1",
+	} {
+		tcpAddr, err := net.ResolveTCPAddr("tcp", addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx := grpcpeer.NewContext(context.Background(), &grpcpeer.Peer{Addr: tcpAddr})
+		if v := remoteAddress(ctx); v != expected {
+			t.Errorf("expected %s; received %s", expected, v)
+		}
+	}
+}
+
+func TestDirectPeers(t *testing.T) {
+	peers := func() []*v1.Peer {
+		return []*v1.Peer{
+			{ID: "node-a", Endpoint: "10.0.0.1:10100", AllowedIPs: []string{"10.10.0.0/24", "10.51.0.0/16"}},
+			{ID: "peer-a", PeerIP: "10.51.0.2", AllowedIPs: []string{"10.51.0.2/32"}, Mesh: true},
+			{ID: "peer-b", PeerIP: "10.51.0.3", PeerIPV6: "fd51::3", ReflexiveEndpoint: "203.0.113.10:41641", AllowedIPs: []string{"10.51.0.3/32", "192.168.1.0/24"}},
+			{ID: "peer-c", PeerIP: "10.51.0.4", ReflexiveEndpoint: "203.0.113.11:41641"},
+		}
+	}
+
+	if direct := directPeers("peer-c", peers(), nil, false, false); len(direct) != 1 || direct[0].ID != "node-a" {
+		t.Errorf("expected only nodes without peer to peer; received %v", direct)
+	}
+
+	direct := directPeers("peer-c", peers(), nil, true, false)
+	if len(direct) != 2 {
+		t.Fatalf("expected node-a and peer-b; received %v", direct)
+	}
+	p := direct[1]
+	if p.ID != "peer-b" || !p.Direct || p.Endpoint != "203.0.113.10:41641" {
+		t.Errorf("expected direct tunnel to peer-b; received %v", p)
+	}
+	if expected := []string{"10.51.0.3/32", "fd51::3/128"}; !reflect.DeepEqual(p.AllowedIPs, expected) {
+		t.Errorf("expected %v; received %v", expected, p.AllowedIPs)
+	}
+
+	// mesh peers get each other without a known endpoint
+	direct = directPeers("peer-c", peers(), nil, true, true)
+	if len(direct) != 3 || direct[1].ID != "peer-a" || !direct[1].Direct || direct[1].Endpoint != "" {
+		t.Errorf("expected direct tunnel to mesh peer-a; received %v", direct)
+	}
+
+	// peers restricted by a policy are relayed through the nodes
+	policies := []*v1.Policy{
+		{ID: "deny-b", PeerID: "peer-b", Destination: "10.51.0.4/32", Action: v1.Policy_DENY},
+	}
+	direct = directPeers("peer-c", peers(), policies, true, true)
+	if len(direct) != 2 || direct[1].ID != "peer-a" {
+		t.Errorf("expected no direct tunnel to restricted peer-b; received %v", direct)
+	}
+	if direct := directPeers("peer-d", peers(), nil, true, true); len(direct) != 1 {
+		t.Errorf("expected only nodes for an unknown peer; received %v", direct)
+	}
+}
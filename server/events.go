@@ -4,18 +4,17 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/ehazlett/heimdall/wg"
-	"github.com/gomodule/redigo/redis"
+	"github.com/ehazlett/heimdall/store"
 )
 
-func (s *Server) eventHandler(ctx context.Context, m redis.Message) error {
-	switch m.Channel {
-	case nodeEventRestartTunnelKey:
-		if err := wg.RestartTunnel(ctx, s.getTunnelName()); err != nil {
+func (s *Server) eventHandler(ctx context.Context, event string) error {
+	switch event {
+	case store.EventUpdateTunnel, store.EventWatch:
+		if err := s.updateTunnel(ctx); err != nil {
 			return err
 		}
 	default:
-		return fmt.Errorf("unknown event on channel %s", m.Channel)
+		return fmt.Errorf("unknown event %s", event)
 	}
 
 	return nil
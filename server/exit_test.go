@@ -0,0 +1,51 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/pkg/errors"
+)
+
+func TestFindExitNode(t *testing.T) {
+	nodes := []*v1.Node{
+		{ID: "node-a", Name: "a"},
+		{ID: "node-b", Name: "b", ExitNode: true},
+	}
+
+	if n, err := findExitNode(nodes, ""); err != nil || n != nil {
+		t.Errorf("expected no exit node; received %v %v", n, err)
+	}
+	for _, name := range []string{"b", "node-b"} {
+		n, err := findExitNode(nodes, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n.ID != "node-b" {
+			t.Errorf("expected node-b for %q; received %s", name, n.ID)
+		}
+	}
+	for _, name := range []string{"a", "missing"} {
+		if _, err := findExitNode(nodes, name); errors.Cause(err) != ErrInvalidExitNode {
+			t.Errorf("expected ErrInvalidExitNode for %q; received %v", name, err)
+		}
+	}
+}
+
+func TestExitSources(t *testing.T) {
+	peers := []*v1.Peer{
+		{ID: "peer-a", PeerIP: "10.254.0.3", PeerIPV6: "fd00::3", ExitNode: "node-b"},
+		{ID: "peer-b", PeerIP: "10.254.0.2", ExitNode: "node-b"},
+		{ID: "peer-c", PeerIP: "10.254.0.4"},
+		{ID: "peer-d", PeerIP: "10.254.0.5", ExitNode: "node-a"},
+	}
+
+	expected := []string{"10.254.0.2", "10.254.0.3", "fd00::3"}
+	if sources := exitSources("node-b", peers); !reflect.DeepEqual(sources, expected) {
+		t.Errorf("expected %v; received %v", expected, sources)
+	}
+	if sources := exitSources("node-c", peers); sources != nil {
+		t.Errorf("expected no sources; received %v", sources)
+	}
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/ehazlett/heimdall/firewall"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// updateFirewall applies the node forwarding rules when they have changed
+func (s *Server) updateFirewall(ctx context.Context, rules *firewall.Rules) error {
+	if s.fwDriver == nil {
+		return nil
+	}
+	s.fwMu.Lock()
+	defer s.fwMu.Unlock()
+
+	if reflect.DeepEqual(s.fwRules, rules) {
+		return nil
+	}
+	logrus.Debugf("applying firewall rules for %s", rules.Interface)
+	if err := s.fwDriver.Apply(ctx, rules); err != nil {
+		return errors.Wrap(err, "error applying firewall rules")
+	}
+	s.fwRules = rules
+	return nil
+}
+
+// firewallReconciler periodically re-applies the node forwarding rules if
+// they were changed or removed outside of heimdall
+func (s *Server) firewallReconciler(ctx context.Context) {
+	logrus.Debugf("starting firewall reconciler: ttl=%s", firewallReconcileInterval)
+	t := time.NewTicker(firewallReconcileInterval)
+	for range t.C {
+		rctx, cancel := context.WithTimeout(ctx, firewallReconcileInterval)
+		if err := s.reconcileFirewall(rctx); err != nil {
+			logrus.WithError(err).Error("error reconciling firewall rules")
+		}
+		cancel()
+	}
+}
+
+func (s *Server) reconcileFirewall(ctx context.Context) error {
+	s.fwMu.Lock()
+	defer s.fwMu.Unlock()
+
+	if s.fwDriver == nil || s.fwRules == nil {
+		return nil
+	}
+	ok, err := s.fwDriver.Check(ctx)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+	logrus.Warn("firewall rules have drifted; re-applying")
+	return s.fwDriver.Apply(ctx, s.fwRules)
+}
@@ -0,0 +1,290 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/ehazlett/heimdall/client"
+	"github.com/ehazlett/heimdall/store"
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// handoffTimeout is how long a handoff waits for the target to sync
+	handoffTimeout = time.Second * 30
+
+	// ErrNotMaster is returned when a master operation is requested on a replica
+	ErrNotMaster = errors.New("node is not the master")
+	// ErrNoHandoffTarget is returned when there is no replica to hand off to
+	ErrNoHandoffTarget = errors.New("no in sync replica available")
+	// ErrAlreadyMaster is returned when the master is asked to take over the master role
+	ErrAlreadyMaster = errors.New("node is already the master")
+)
+
+// StepDown hands off the master role to an in sync replica
+func (s *Server) StepDown(ctx context.Context, req *v1.StepDownRequest) (*ptypes.Empty, error) {
+	if s.redis == nil {
+		return nil, ErrClusteringUnsupported
+	}
+	if s.currentMaster() != s.cfg.ID {
+		c, err := s.masterClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer c.Close()
+		return c.StepDown(ctx, req)
+	}
+
+	target, err := s.handoffTarget(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.handoff(ctx, target); err != nil {
+		return nil, err
+	}
+	return empty, nil
+}
+
+// PromoteNode hands off the master role to the specified node
+func (s *Server) PromoteNode(ctx context.Context, req *v1.PromoteNodeRequest) (*ptypes.Empty, error) {
+	if s.redis == nil {
+		return nil, ErrClusteringUnsupported
+	}
+	if s.currentMaster() != s.cfg.ID {
+		c, err := s.masterClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer c.Close()
+		return c.PromoteNode(ctx, req)
+	}
+	if req.ID == s.cfg.ID {
+		logrus.Infof("node %s is already master", req.ID)
+		return empty, nil
+	}
+
+	target, err := s.getNode(ctx, req.ID)
+	if err != nil {
+		if err == store.ErrNotFound {
+			return nil, ErrNodeDoesNotExist
+		}
+		return nil, err
+	}
+	if err := s.handoff(ctx, target); err != nil {
+		return nil, err
+	}
+	return empty, nil
+}
+
+// TakeMaster is called by the current master to promote the local node once
+// it has replicated all writes up to the offset
+func (s *Server) TakeMaster(ctx context.Context, req *v1.TakeMasterRequest) (*v1.TakeMasterResponse, error) {
+	if s.redis == nil {
+		return nil, ErrClusteringUnsupported
+	}
+	key, err := s.getClusterKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.ClusterKey != key {
+		return nil, ErrInvalidAuth
+	}
+
+	// a retried request waits for the previous one and is refused once the
+	// node has been promoted
+	s.takeMasterMu.Lock()
+	defer s.takeMasterMu.Unlock()
+	if s.isMaster() {
+		return nil, ErrAlreadyMaster
+	}
+	// stop the replica monitor so it does not run an election while the
+	// node is promoted
+	s.stopReplicaMonitor()
+
+	logrus.Infof("taking over master for term %d at offset %d", req.Term, req.Offset)
+	if err := s.waitForReplicationOffset(ctx, req.Offset); err != nil {
+		return nil, err
+	}
+	if err := s.promoteRedis(ctx); err != nil {
+		return nil, err
+	}
+	// the lease was transferred by the previous master so it is renewed
+	// instead of acquired
+	s.setTerm(req.Term)
+	if err := s.updateMasterInfo(ctx); err != nil {
+		return nil, errors.Wrapf(err, "error renewing master lease for term %d", req.Term)
+	}
+	if err := s.startMaster(ctx); err != nil {
+		return nil, err
+	}
+
+	m, err := s.masterInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.TakeMasterResponse{
+		Master: m,
+	}, nil
+}
+
+// handoff transfers the master role to the target node.  Writes are fenced
+// with the next term before the target is promoted so that no writes are
+// lost.
+func (s *Server) handoff(ctx context.Context, target *v1.Node) error {
+	term := s.currentTerm() + 1
+	logrus.Infof("handing off master to %s for term %d", target.ID, term)
+
+	s.stopMaster()
+	if err := s.store.HandoffMaster(ctx, s.cfg.ID, target.ID, term, masterLeaseTTL); err != nil {
+		s.resumeMaster(ctx)
+		return errors.Wrap(err, "error transferring master lease")
+	}
+
+	// the local node follows the new master or if the handoff fails the lease
+	// expires and a new master is elected
+	defer s.startReplicaMonitor()
+
+	info, err := s.replicationInfo(ctx)
+	if err != nil {
+		return err
+	}
+	offset, err := strconv.ParseInt(info["master_repl_offset"], 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "error parsing replication offset")
+	}
+
+	c, err := s.getClient(target.Addr)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	tctx, cancel := context.WithTimeout(ctx, handoffTimeout)
+	defer cancel()
+	resp, err := c.TakeMaster(tctx, &v1.TakeMasterRequest{
+		Term:       term,
+		Offset:     offset,
+		ClusterKey: s.cfg.ClusterKey,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error promoting %s", target.ID)
+	}
+
+	if err := s.joinMaster(resp.Master); err != nil {
+		return err
+	}
+	logrus.Infof("waiting for redis sync with %s", resp.Master.ID)
+	if err := s.waitForRedisSync(ctx); err != nil {
+		return err
+	}
+	logrus.Infof("handed off master to %s term=%d", resp.Master.ID, resp.Master.Term)
+	return nil
+}
+
+// resumeMaster restarts the master heartbeat after a failed handoff
+func (s *Server) resumeMaster(ctx context.Context) {
+	mctx, cancel := context.WithCancel(context.Background())
+	s.electionMu.Lock()
+	s.masterCancel = cancel
+	s.electionMu.Unlock()
+
+	go s.masterHeartbeat(mctx)
+	go s.splitBrainMonitor(mctx)
+}
+
+// handoffTarget returns the first node that is replicating from the local node
+func (s *Server) handoffTarget(ctx context.Context) (*v1.Node, error) {
+	nodes, err := s.getNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	for _, n := range nodes {
+		if n.ID == s.cfg.ID {
+			continue
+		}
+		m, err := s.remoteMaster(ctx, n.Addr)
+		if err != nil {
+			logrus.Debugf("skipping handoff target %s: %s", n.ID, err)
+			continue
+		}
+		if m == nil || m.ID != s.cfg.ID {
+			continue
+		}
+		return n, nil
+	}
+	return nil, ErrNoHandoffTarget
+}
+
+// masterClient returns a client for the current master
+func (s *Server) masterClient(ctx context.Context) (*client.Client, error) {
+	m, err := s.store.GetMaster(ctx)
+	if err != nil {
+		if err == store.ErrNotFound {
+			return nil, ErrNoMaster
+		}
+		return nil, err
+	}
+	if m.ID == s.cfg.ID {
+		return nil, ErrNotMaster
+	}
+	return s.getClient(m.GRPCAddress)
+}
+
+// waitForReplicationOffset waits until the local replica is connected and
+// has replicated up to the offset
+func (s *Server) waitForReplicationOffset(ctx context.Context, offset int64) error {
+	ctx, cancel := context.WithTimeout(ctx, handoffTimeout)
+	defer cancel()
+
+	t := time.NewTicker(time.Millisecond * 100)
+	defer t.Stop()
+	for {
+		info, err := s.replicationInfo(ctx)
+		if err != nil {
+			return err
+		}
+		if info["master_link_status"] == "up" {
+			current, err := strconv.ParseInt(info["slave_repl_offset"], 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "error parsing replication offset")
+			}
+			if current >= offset {
+				return nil
+			}
+			logrus.Debugf("waiting for replication offset %d: current=%d", offset, current)
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "timeout waiting for replication offset %d", offset)
+		case <-t.C:
+		}
+	}
+}
+
+// replicationInfo returns the replication info of the local redis
+func (s *Server) replicationInfo(ctx context.Context) (map[string]string, error) {
+	info, err := redis.String(s.redis.Local(ctx, "INFO", "REPLICATION"))
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewBufferString(info))
+	for scanner.Scan() {
+		parts := strings.SplitN(strings.TrimSpace(scanner.Text()), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		values[parts[0]] = parts[1]
+	}
+	return values, nil
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	defaultNodeHealthTimeout    = nodeHeartbeatInterval * 3
+	defaultNodeHandshakeTimeout = time.Minute * 5
+)
+
+// setNodeHealth sets the liveness of the nodes from the age of their last
+// heartbeat and the latest Wireguard handshake with the local node
+func (s *Server) setNodeHealth(ctx context.Context, nodes []*v1.Node) {
+	handshakes := s.handshakes(ctx)
+	now := time.Now()
+	for _, n := range nodes {
+		n.Healthy, n.HealthReason = true, ""
+		if t, ok := handshakes[n.PublicKey]; ok && n.ID != s.cfg.ID {
+			n.LastHandshake = t
+		}
+
+		if age := now.Sub(n.Updated); age > s.nodeHealthTimeout() {
+			n.Healthy = false
+			n.HealthReason = fmt.Sprintf("no heartbeat for %s", age.Round(time.Second))
+			continue
+		}
+		// nodes that have not completed a handshake yet are not penalized
+		if n.LastHandshake.IsZero() {
+			continue
+		}
+		if age := now.Sub(n.LastHandshake); age > s.nodeHandshakeTimeout() {
+			n.Healthy = false
+			n.HealthReason = fmt.Sprintf("no handshake for %s", age.Round(time.Second))
+		}
+	}
+}
+
+// handshakes returns the latest handshake of each peer of the local tunnel
+func (s *Server) handshakes(ctx context.Context) map[string]time.Time {
+	if s.wgDriver == nil {
+		return nil
+	}
+	handshakes, err := s.wgDriver.Handshakes(ctx, s.cfg.InterfaceName)
+	if err != nil {
+		logrus.WithError(err).Debugf("unable to get handshakes for %s", s.cfg.InterfaceName)
+		return nil
+	}
+	return handshakes
+}
+
+func (s *Server) nodeHealthTimeout() time.Duration {
+	if s.cfg.NodeHealthTimeout > 0 {
+		return s.cfg.NodeHealthTimeout
+	}
+	return defaultNodeHealthTimeout
+}
+
+func (s *Server) nodeHandshakeTimeout() time.Duration {
+	if s.cfg.NodeHandshakeTimeout > 0 {
+		return s.cfg.NodeHandshakeTimeout
+	}
+	return defaultNodeHandshakeTimeout
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 
 	v1 "github.com/ehazlett/heimdall/api/v1"
-	"github.com/gogo/protobuf/proto"
-	"github.com/gomodule/redigo/redis"
+	"github.com/ehazlett/heimdall/store"
+	"github.com/ehazlett/heimdall/wg"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -15,11 +15,16 @@ var (
 	ErrInvalidAuth = errors.New("invalid cluster key specified")
 	// ErrNoMaster is returned if there is no configured master yet
 	ErrNoMaster = errors.New("no configured master")
+	// ErrNodeSubnetSizeMismatch is returned when a joining node uses a different node subnet size than the cluster
+	ErrNodeSubnetSizeMismatch = errors.New("node subnet size does not match the cluster")
 )
 
 // Join is called when a peer wants to join the cluster
 func (s *Server) Join(ctx context.Context, req *v1.JoinRequest) (*v1.JoinResponse, error) {
 	logrus.Debugf("join request from %s", req.ID)
+	if s.redis == nil {
+		return nil, ErrClusteringUnsupported
+	}
 	key, err := s.getClusterKey(ctx)
 	if err != nil {
 		return nil, err
@@ -27,16 +32,25 @@ func (s *Server) Join(ctx context.Context, req *v1.JoinRequest) (*v1.JoinRespons
 	if req.ClusterKey != key {
 		return nil, ErrInvalidAuth
 	}
-	data, err := redis.Bytes(s.local(ctx, "GET", masterKey))
+	if !wg.ValidKey(req.PublicKey) {
+		return nil, ErrInvalidPublicKey
+	}
+	if err := s.checkNodeSubnetSize(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := s.checkNodeRemoved(ctx, req.ID); err != nil {
+		return nil, err
+	}
+	master, err := s.store.GetMaster(ctx)
 	if err != nil {
-		if err == redis.ErrNil {
+		if err == store.ErrNotFound {
 			return nil, ErrNoMaster
 		}
-		return nil, err
+		return nil, errors.Wrap(err, "error getting master info")
 	}
-	var master v1.Master
-	if err := proto.Unmarshal(data, &master); err != nil {
-		return nil, errors.Wrap(err, "error unmarshalling master info")
+	// the master has not been assigned a node network yet
+	if master.GatewayIP == "" {
+		return nil, ErrNoMaster
 	}
 
 	peers, err := s.getPeers(ctx)
@@ -50,15 +64,15 @@ func (s *Server) Join(ctx context.Context, req *v1.JoinRequest) (*v1.JoinRespons
 
 	node, err := s.getNode(ctx, req.ID)
 	if err != nil {
-		if err != redis.ErrNil {
-			return nil, errors.Wrapf(err, "error getting node info from redis for %s", req.ID)
+		if err != store.ErrNotFound {
+			return nil, errors.Wrapf(err, "error getting node info for %s", req.ID)
 		}
 		n, err := s.createNode(ctx, req)
 		if err != nil {
 			return nil, errors.Wrap(err, "error creating node")
 		}
 
-		if err := s.updatePeerInfo(ctx, req.ID, req.Name); err != nil {
+		if err := s.updatePeerInfo(ctx, &v1.Peer{ID: req.ID, Name: req.Name, PublicKey: req.PublicKey}); err != nil {
 			return nil, errors.Wrap(err, "error updating peer info")
 		}
 
@@ -66,8 +80,47 @@ func (s *Server) Join(ctx context.Context, req *v1.JoinRequest) (*v1.JoinRespons
 	}
 
 	return &v1.JoinResponse{
-		Master: &master,
+		Master: master,
 		Node:   node,
 		Peers:  peers,
 	}, nil
 }
+
+// joinRequest returns the request to join the cluster as the local node
+func (s *Server) joinRequest() *v1.JoinRequest {
+	v4, v6 := s.nodeSubnetSizes()
+	return &v1.JoinRequest{
+		ID:               s.cfg.ID,
+		Name:             s.cfg.Name,
+		ClusterKey:       s.cfg.ClusterKey,
+		GRPCAddress:      s.cfg.GRPCAddress,
+		EndpointIP:       s.cfg.EndpointIP,
+		EndpointPort:     uint64(s.cfg.EndpointPort),
+		InterfaceName:    s.cfg.InterfaceName,
+		PublicKey:        s.publicKey,
+		NodeSubnetSize:   v4,
+		NodeSubnetSizeV6: v6,
+	}
+}
+
+// checkNodeSubnetSize returns ErrNodeSubnetSizeMismatch if the joining node
+// would allocate node subnets of a different size than the cluster.  The
+// sizes of the cluster are stored from the config of the node that creates
+// the cluster.
+func (s *Server) checkNodeSubnetSize(ctx context.Context, req *v1.JoinRequest) error {
+	local, local6 := s.nodeSubnetSizes()
+	v4, v6, err := s.store.InitNodeSubnetSize(ctx, local, local6)
+	if err != nil {
+		return errors.Wrap(err, "error getting cluster node subnet size")
+	}
+	size, size6 := req.NodeSubnetSize, req.NodeSubnetSizeV6
+	// nodes of previous versions do not send the subnet size and always use
+	// the node network prefix
+	if size == 0 && size6 == 0 {
+		size, size6 = s.nodeNetworkPrefixes()
+	}
+	if size != v4 || size6 != v6 {
+		return errors.Wrapf(ErrNodeSubnetSizeMismatch, "node %s uses /%d and /%d (IPv6); cluster uses /%d and /%d (IPv6)", req.ID, size, size6, v4, v6)
+	}
+	return nil
+}
@@ -5,170 +5,524 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"sort"
 	"strings"
+	"time"
 
-	"github.com/gomodule/redigo/redis"
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/ehazlett/heimdall/ipam"
+	"github.com/ehazlett/heimdall/store"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
+var (
+	// ErrInvalidPeerIP is returned when a static peer IP cannot be assigned from the peer networks
+	ErrInvalidPeerIP = errors.New("invalid peer ip")
+	// ErrPeerIPAllocated is returned when a static peer IP is allocated to another peer
+	ErrPeerIPAllocated = errors.New("peer ip already allocated")
+)
+
 type subnetRange struct {
 	Start  net.IP
 	End    net.IP
 	Subnet *net.IPNet
 }
 
-func (s *Server) updateNodeNetwork(ctx context.Context, id string, subnet string) error {
-	logrus.Debugf("updating node network: id=%s subnet=%s", id, subnet)
-	if _, err := s.master(ctx, "SET", s.getNodeNetworkKey(id), subnet); err != nil {
-		return err
+// reserveNodeNetwork reserves the subnet on the master and returns the subnet
+// allocated to the node
+func (s *Server) reserveNodeNetwork(ctx context.Context, id string, subnet string) (string, error) {
+	logrus.Debugf("reserving node network: id=%s subnet=%s", id, subnet)
+	return s.store.ReserveNodeNetwork(ctx, id, subnet)
+}
+
+// releaseNodeNetwork releases the subnet allocated to the node
+func (s *Server) releaseNodeNetwork(ctx context.Context, id string) error {
+	logrus.Debugf("releasing node network: id=%s", id)
+	return s.store.DeleteNodeNetwork(ctx, id)
+}
+
+// nodeNetworks returns the configured node networks with the primary first
+func (s *Server) nodeNetworks() []string {
+	networks := []string{s.cfg.NodeNetwork}
+	if s.cfg.NodeNetworkV6 != "" {
+		networks = append(networks, s.cfg.NodeNetworkV6)
 	}
-	return nil
+	return networks
 }
 
-func (s *Server) getOrAllocatePeerIP(ctx context.Context, id string) (net.IP, *net.IPNet, error) {
-	r, err := parseSubnetRange(s.cfg.PeerNetwork)
+// nodeSubnetSize returns the prefix length of the subnets allocated to nodes
+// from the node network
+func (s *Server) nodeSubnetSize(nodeNetwork string) (int, error) {
+	r, err := parseSubnetRange(nodeNetwork)
 	if err != nil {
-		return nil, nil, err
+		return 0, err
 	}
+	prefix, bits := r.Subnet.Mask.Size()
+	size := s.cfg.NodeSubnetSize
+	if r.Subnet.IP.To4() == nil {
+		size = s.cfg.NodeSubnetSizeV6
+	}
+	if size == 0 {
+		return prefix, nil
+	}
+	// leave room for the gateway and at least one host
+	if size < prefix || size > bits-2 {
+		return 0, fmt.Errorf("invalid node subnet size /%d for node network %s", size, nodeNetwork)
+	}
+	return size, nil
+}
 
-	ip, err := s.getPeerIP(ctx, id)
-	if err != nil {
-		return nil, nil, err
+// nodeSubnetSizes returns the IPv4 and IPv6 node subnet sizes.  The sizes
+// are validated when the server is created.
+func (s *Server) nodeSubnetSizes() (uint32, uint32) {
+	var v4, v6 uint32
+	for _, nodeNetwork := range s.nodeNetworks() {
+		size, _ := s.nodeSubnetSize(nodeNetwork)
+		if store.IsIPv6(nodeNetwork) {
+			v6 = uint32(size)
+			continue
+		}
+		v4 = uint32(size)
 	}
+	return v4, v6
+}
 
-	if ip != nil {
-		return ip, r.Subnet, nil
+// nodeNetworkPrefixes returns the IPv4 and IPv6 node network prefix lengths
+func (s *Server) nodeNetworkPrefixes() (uint32, uint32) {
+	var v4, v6 uint32
+	for _, nodeNetwork := range s.nodeNetworks() {
+		r, err := parseSubnetRange(nodeNetwork)
+		if err != nil {
+			continue
+		}
+		prefix, _ := r.Subnet.Mask.Size()
+		if store.IsIPv6(nodeNetwork) {
+			v6 = uint32(prefix)
+			continue
+		}
+		v4 = uint32(prefix)
 	}
+	return v4, v6
+}
+
+// peerNetworks returns the configured peer networks with the primary first
+func (s *Server) peerNetworks() []string {
+	networks := []string{s.cfg.PeerNetwork}
+	if s.cfg.PeerNetworkV6 != "" {
+		networks = append(networks, s.cfg.PeerNetworkV6)
+	}
+	return networks
+}
 
-	ip, err = s.allocatePeerIP(ctx, id, r)
+func (s *Server) getOrAllocatePeerIP(ctx context.Context, id string) (net.IP, *net.IPNet, error) {
+	addrs, err := s.getOrAllocatePeerIPs(ctx, id)
 	if err != nil {
 		return nil, nil, err
 	}
+	return addrs[0].IP, subnetOf(addrs[0]), nil
+}
 
-	return ip, r.Subnet, nil
+// getOrAllocatePeerIPs returns the address of the peer in each peer network
+// with the primary first.  Missing addresses are allocated.
+func (s *Server) getOrAllocatePeerIPs(ctx context.Context, id string) ([]*net.IPNet, error) {
+	return s.peerAddrs(ctx, id, true)
 }
 
-func (s *Server) getNodeIP(ctx context.Context, id string) (net.IP, *net.IPNet, error) {
-	subnet, err := redis.String(s.local(ctx, "GET", s.getNodeNetworkKey(id)))
+// getPeerAddrs returns the address of the peer in each peer network with the
+// primary first without allocating missing addresses
+func (s *Server) getPeerAddrs(ctx context.Context, id string) ([]*net.IPNet, error) {
+	return s.peerAddrs(ctx, id, false)
+}
+
+func (s *Server) peerAddrs(ctx context.Context, id string, allocate bool) ([]*net.IPNet, error) {
+	existing, err := s.getPeerIP(ctx, id)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	r, err := parseSubnetRange(subnet)
+
+	var addrs []*net.IPNet
+	for i, network := range s.peerNetworks() {
+		r, err := parseSubnetRange(network)
+		if err != nil {
+			return nil, err
+		}
+		ip := sameFamily(existing, r.Subnet.IP)
+		if ip == nil {
+			if !allocate {
+				return nil, errors.Wrapf(store.ErrNotFound, "peer %s has no address in %s", id, network)
+			}
+			if ip, err = s.allocatePeerIP(ctx, id, i); err != nil {
+				return nil, err
+			}
+		}
+		addrs = append(addrs, &net.IPNet{IP: ip, Mask: r.Subnet.Mask})
+	}
+	return addrs, nil
+}
+
+func (s *Server) getNodeIP(ctx context.Context, id string) (net.IP, *net.IPNet, error) {
+	addrs, err := s.getNodeIPs(ctx, id)
 	if err != nil {
 		return nil, nil, err
 	}
+	return addrs[0].IP, subnetOf(addrs[0]), nil
+}
 
-	ip := r.Start
-	// assign .1 for router
-	ip[len(ip)-1] = 1
-
-	return ip, r.Subnet, nil
+// getNodeIPs returns the gateway address of the node in each of its subnets
+// with the primary first
+func (s *Server) getNodeIPs(ctx context.Context, id string) ([]*net.IPNet, error) {
+	subnets, err := s.store.GetNodeNetwork(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	var addrs []*net.IPNet
+	for _, subnet := range subnets {
+		r, err := parseSubnetRange(subnet)
+		if err != nil {
+			return nil, err
+		}
+		// assign the first address for router
+		addrs = append(addrs, &net.IPNet{
+			IP:   inc(r.Subnet.IP),
+			Mask: r.Subnet.Mask,
+		})
+	}
+	return addrs, nil
 }
 
-func (s *Server) getPeerIPs(ctx context.Context) (map[string]net.IP, error) {
-	values, err := redis.StringMap(s.local(ctx, "HGETALL", peerIPsKey))
+func (s *Server) getPeerIPs(ctx context.Context) (map[string][]net.IP, error) {
+	values, err := s.store.GetPeerIPs(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	ips := make(map[string]net.IP, len(values))
-	for id, val := range values {
-		ip := net.ParseIP(string(val))
-		ips[id] = ip
+	ips := make(map[string][]net.IP, len(values))
+	for id, vals := range values {
+		for _, val := range vals {
+			ips[id] = append(ips[id], net.ParseIP(val))
+		}
 	}
 	return ips, nil
 }
 
-func (s *Server) getNodeIPs(ctx context.Context) (map[string]net.IP, error) {
-	values, err := redis.StringMap(s.local(ctx, "HGETALL", nodeIPsKey))
+// getPeerIP returns the IPs allocated to the peer with IPv4 first
+func (s *Server) getPeerIP(ctx context.Context, id string) ([]net.IP, error) {
+	values, err := s.store.GetPeerIP(ctx, id)
 	if err != nil {
+		if err == store.ErrNotFound {
+			return nil, nil
+		}
 		return nil, err
 	}
-
-	ips := make(map[string]net.IP, len(values))
-	for id, val := range values {
-		ip := net.ParseIP(string(val))
-		ips[id] = ip
+	ips := make([]net.IP, len(values))
+	for i, val := range values {
+		ips[i] = net.ParseIP(val)
 	}
 	return ips, nil
 }
 
-func (s *Server) getPeerIP(ctx context.Context, id string) (net.IP, error) {
-	allIPs, err := s.getPeerIPs(ctx)
+// newPeerPools returns empty address pools for the peer networks with the
+// configured ranges excluded
+func (s *Server) newPeerPools() ([]*ipam.Pool, error) {
+	var pools []*ipam.Pool
+	for _, network := range s.peerNetworks() {
+		r, err := parseSubnetRange(network)
+		if err != nil {
+			return nil, err
+		}
+		pool := ipam.NewPool(r.Subnet)
+		if err := pool.Restrict(r.Start, r.End); err != nil {
+			return nil, err
+		}
+		pools = append(pools, pool)
+	}
+	for _, exclude := range s.cfg.PeerNetworkExcludes {
+		start, end, err := ipam.ParseRange(exclude)
+		if err != nil {
+			return nil, err
+		}
+		pool := findPool(pools, start)
+		if pool == nil {
+			return nil, fmt.Errorf("excluded range %s is not in a peer network", exclude)
+		}
+		if err := pool.Exclude(start, end); err != nil {
+			return nil, err
+		}
+	}
+	return pools, nil
+}
+
+// loadPeerPools rebuilds the peer network address pools from the
+// allocations in the store.  ipamMu must be held.
+func (s *Server) loadPeerPools(ctx context.Context) error {
+	pools, err := s.newPeerPools()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	allocations, err := s.getPeerIPs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, ips := range allocations {
+		for _, ip := range ips {
+			if pool := findPool(pools, ip); pool != nil {
+				// duplicate allocations are reported by checkPeerIPs
+				pool.Reserve(ip)
+			}
+		}
+	}
+	s.peerPools = pools
+	s.peerPoolsLoaded = time.Now()
+	return nil
+}
+
+// allocatePeerIP allocates a free address of the peer network for the peer
+func (s *Server) allocatePeerIP(ctx context.Context, id string, network int) (net.IP, error) {
+	s.ipamMu.Lock()
+	defer s.ipamMu.Unlock()
 
-	if ip, exists := allIPs[id]; exists {
-		return ip, nil
+	// the pools are a cache of the store allocations that is refreshed to
+	// pick up addresses released by other nodes
+	if s.peerPools == nil || time.Since(s.peerPoolsLoaded) > peerPoolRefreshInterval {
+		if err := s.loadPeerPools(ctx); err != nil {
+			return nil, err
+		}
+	}
+	reloaded := false
+	for {
+		pool := s.peerPools[network]
+		ip, err := pool.Allocate()
+		if err != nil {
+			if err == ipam.ErrFull && !reloaded {
+				if err := s.loadPeerPools(ctx); err != nil {
+					return nil, err
+				}
+				reloaded = true
+				continue
+			}
+			return nil, err
+		}
+
+		// reserve on the master as the local pool may be stale
+		reserved, err := s.store.ReservePeerIP(ctx, id, ip.String())
+		if err != nil {
+			if err == store.ErrExists {
+				// keep the address marked as allocated
+				continue
+			}
+			pool.Release(ip)
+			return nil, err
+		}
+		if reserved != ip.String() {
+			// the peer was allocated an address by another node
+			pool.Release(ip)
+			pool.Reserve(net.ParseIP(reserved))
+		}
+		return net.ParseIP(reserved), nil
 	}
-	return nil, nil
 }
 
-func (s *Server) allocatePeerIP(ctx context.Context, id string, r *subnetRange) (net.IP, error) {
-	reservedIPs, err := s.getPeerIPs(ctx)
+// reservePeerIPs statically assigns the IPs to the peer replacing any
+// existing allocation in the same address family
+func (s *Server) reservePeerIPs(ctx context.Context, id string, values []string) error {
+	pools, err := s.newPeerPools()
 	if err != nil {
-		return nil, err
+		return err
+	}
+	var ips []net.IP
+	for _, v := range values {
+		ip := net.ParseIP(v)
+		if ip == nil {
+			return errors.Wrap(ErrInvalidPeerIP, v)
+		}
+		pool := findPool(pools, ip)
+		if pool == nil || sameFamily(ips, ip) != nil {
+			return errors.Wrap(ErrInvalidPeerIP, v)
+		}
+		if err := pool.Reserve(ip); err != nil {
+			return errors.Wrap(ErrInvalidPeerIP, err.Error())
+		}
+		ips = append(ips, ip)
 	}
 
-	if ip, exists := reservedIPs[id]; exists {
-		return ip, nil
+	allocations, err := s.getPeerIPs(ctx)
+	if err != nil {
+		return err
+	}
+	for peerID, allocated := range allocations {
+		for _, ip := range ips {
+			if peerID != id && sameFamily(allocated, ip).Equal(ip) {
+				return errors.Wrapf(ErrPeerIPAllocated, "%s is allocated to %s", ip, peerID)
+			}
+		}
+	}
+	// keep the existing allocations of the other address families
+	for _, existing := range allocations[id] {
+		if sameFamily(ips, existing) == nil {
+			ips = append(ips, existing)
+		}
 	}
 
-	lookup := map[string]string{}
-	for id, ip := range reservedIPs {
-		lookup[ip.String()] = id
+	s.ipamMu.Lock()
+	defer s.ipamMu.Unlock()
+	// the pools are reloaded on the next allocation
+	s.peerPools = nil
+
+	// the allocations are swapped in a single write so the peer keeps its
+	// existing IPs if any of the new IPs was allocated in the meantime
+	var reserved []string
+	for _, ip := range ips {
+		reserved = append(reserved, ip.String())
 	}
-	for ip := r.Start; !ip.Equal(r.End); s.nextIP(ip) {
-		// filter out network, gateway and broadcast
-		if !s.validIP(ip) {
-			continue
+	if err := s.store.ReplacePeerIP(ctx, id, reserved); err != nil {
+		if err == store.ErrExists {
+			return errors.Wrapf(ErrPeerIPAllocated, "%v", reserved)
 		}
-		if _, exists := lookup[ip.String()]; exists {
-			// ip already reserved
-			continue
+		return err
+	}
+	return nil
+}
+
+// findPool returns the pool of the network containing the IP
+func findPool(pools []*ipam.Pool, ip net.IP) *ipam.Pool {
+	for _, pool := range pools {
+		if pool.Contains(ip) {
+			return pool
 		}
+	}
+	return nil
+}
 
-		// save
-		if _, err := s.master(ctx, "HSET", peerIPsKey, id, ip.String()); err != nil {
-			return nil, err
+// checkPeerIPs returns all IPs that are allocated to more than one peer
+func (s *Server) checkPeerIPs(ctx context.Context) ([]*v1.PeerIPConflict, error) {
+	values, err := s.store.GetPeerIPs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allocations := map[string][]string{}
+	for id, ips := range values {
+		for _, ip := range ips {
+			allocations[ip] = append(allocations[ip], id)
 		}
-		return ip, nil
 	}
 
-	return nil, fmt.Errorf("no available IPs")
+	conflicts := []*v1.PeerIPConflict{}
+	for ip, ids := range allocations {
+		if len(ids) < 2 {
+			continue
+		}
+		sort.Strings(ids)
+		conflicts = append(conflicts, &v1.PeerIPConflict{
+			IP:      ip,
+			PeerIDs: ids,
+		})
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].IP < conflicts[j].IP })
+	return conflicts, nil
 }
 
 func (s *Server) releasePeerIP(ctx context.Context, id string) error {
-	ip, err := s.getPeerIP(ctx, id)
+	ips, err := s.getPeerIP(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	if ip != nil {
-		if _, err := s.master(ctx, "HDEL", peerIPsKey, id); err != nil {
+	if len(ips) > 0 {
+		if err := s.store.DeletePeerIP(ctx, id); err != nil {
 			return err
 		}
 	}
+
+	s.ipamMu.Lock()
+	defer s.ipamMu.Unlock()
+	for _, ip := range ips {
+		if pool := findPool(s.peerPools, ip); pool != nil {
+			pool.Release(ip)
+		}
+	}
 	return nil
 }
 
-func (s *Server) nextIP(ip net.IP) {
-	for j := len(ip) - 1; j >= 0; j-- {
-		ip[j]++
-		if ip[j] > 0 {
-			break
+// sameFamily returns the first IP in the address family of ref
+func sameFamily(ips []net.IP, ref net.IP) net.IP {
+	for _, ip := range ips {
+		if (ip.To4() == nil) == (ref.To4() == nil) {
+			return ip
+		}
+	}
+	return nil
+}
+
+// splitAddresses returns the primary address and the IPv6 address of a
+// dual-stack allocation.  Single stack allocations only have a primary
+// address.
+func splitAddresses(ips []net.IP) (string, string) {
+	switch len(ips) {
+	case 0:
+		return "", ""
+	case 1:
+		return ips[0].String(), ""
+	}
+	var primary, v6 string
+	for _, ip := range ips {
+		if ip.To4() == nil {
+			v6 = ip.String()
+			continue
 		}
+		primary = ip.String()
+	}
+	return primary, v6
+}
+
+// subnetOf returns the subnet of the address
+func subnetOf(addr *net.IPNet) *net.IPNet {
+	return &net.IPNet{
+		IP:   addr.IP.Mask(addr.Mask),
+		Mask: addr.Mask,
 	}
 }
 
-func (s *Server) validIP(ip net.IP) bool {
-	v := ip[len(ip)-1]
-	switch v {
-	case 0, 1, 255:
-		return false
+// joinAddresses returns the addresses in the comma separated format of the
+// Wireguard Address setting
+func joinAddresses(addrs []*net.IPNet) string {
+	values := make([]string, len(addrs))
+	for i, addr := range addrs {
+		values[i] = addr.String()
 	}
-	return true
+	return strings.Join(values, ", ")
+}
+
+// hostPrefix returns the single address prefix of the IP
+func hostPrefix(ip net.IP) string {
+	if ip.To4() == nil {
+		return ip.String() + "/128"
+	}
+	return ip.String() + "/32"
+}
+
+// PeerNetworkUsage returns the address utilization of the peer networks
+func (s *Server) PeerNetworkUsage(ctx context.Context, req *v1.PeerNetworkUsageRequest) (*v1.PeerNetworkUsageResponse, error) {
+	s.ipamMu.Lock()
+	defer s.ipamMu.Unlock()
+	if err := s.loadPeerPools(ctx); err != nil {
+		return nil, err
+	}
+	networks := []*v1.NetworkUsage{}
+	for _, pool := range s.peerPools {
+		u := pool.Usage()
+		networks = append(networks, &v1.NetworkUsage{
+			Network:   pool.Subnet().String(),
+			Total:     u.Total,
+			Allocated: u.Allocated,
+			Excluded:  u.Excluded,
+			Available: u.Available,
+		})
+	}
+	return &v1.PeerNetworkUsageResponse{
+		Networks: networks,
+	}, nil
 }
 
 // parseSubnetRange parses the subnet range
@@ -181,9 +535,8 @@ func parseSubnetRange(subnet string) (*subnetRange, error) {
 			return nil, err
 		}
 
-		end := make(net.IP, len(ip))
-		copy(end, ip)
-		end[len(end)-1] = 254
+		// the last address is excluded from the range
+		_, end := addressRange(sub)
 		return &subnetRange{
 			Start:  ip,
 			End:    end,
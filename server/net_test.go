@@ -2,37 +2,51 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
 	"testing"
 
 	"github.com/ehazlett/heimdall"
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/ehazlett/heimdall/store"
+	"github.com/pkg/errors"
 )
 
 const (
 	testPeerNetwork = "10.51.0.0/16"
 	testNodeNetwork = "10.10.0.0/16"
+
+	testPeerNetworkV6 = "fd00:51::/64"
+	testNodeNetworkV6 = "fd00:10::/48"
 )
 
 func TestNetSuite(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
 	cfg := &heimdall.Config{
-		ID:          "test",
-		NodeNetwork: testNodeNetwork,
-		PeerNetwork: testPeerNetwork,
-		DataDir:     "/tmp/heimdall-test",
+		ID:           "test",
+		NodeNetwork:  testNodeNetwork,
+		PeerNetwork:  testPeerNetwork,
+		DataDir:      tmpDir,
+		StoreBackend: StoreBackendEmbedded,
 	}
 
 	srv, err := NewServer(cfg)
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer func() {
-		ctx := context.Background()
-		if _, err := srv.master(ctx, "FLUSHDB"); err != nil {
-			t.Errorf("error tearing down: %s", err)
-		}
-	}()
 
 	// run tests
 	t.Run("AllocatePeerIP", testNetAllocatePeerIP(srv))
+	t.Run("AllocatePeerIPConcurrent", testNetAllocatePeerIPConcurrent(srv))
+	t.Run("NodeNetworkReservation", testNetNodeNetworkReservation(srv))
 }
 
 func testNetAllocatePeerIP(s *Server) func(t *testing.T) {
@@ -54,3 +68,286 @@ func testNetAllocatePeerIP(s *Server) func(t *testing.T) {
 		}
 	}
 }
+
+func testNetAllocatePeerIPConcurrent(s *Server) func(t *testing.T) {
+	return func(t *testing.T) {
+		ctx := context.Background()
+		n := 32
+		ips := make([]net.IP, n)
+		errs := make([]error, n)
+		wg := &sync.WaitGroup{}
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				ips[i], _, errs[i] = s.getOrAllocatePeerIP(ctx, fmt.Sprintf("test-peer-%d", i))
+			}(i)
+		}
+		wg.Wait()
+
+		seen := map[string]int{}
+		for i, ip := range ips {
+			if errs[i] != nil {
+				t.Fatal(errs[i])
+			}
+			if j, ok := seen[ip.String()]; ok {
+				t.Errorf("ip %s allocated to test-peer-%d and test-peer-%d", ip, j, i)
+			}
+			seen[ip.String()] = i
+		}
+
+		conflicts, err := s.checkPeerIPs(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(conflicts) != 0 {
+			t.Errorf("expected no conflicts; received %v", conflicts)
+		}
+	}
+}
+
+func testNetNodeNetworkReservation(s *Server) func(t *testing.T) {
+	return func(t *testing.T) {
+		ctx := context.Background()
+		ids := []string{"test-node-a", "test-node-b"}
+		errs := make([]error, len(ids))
+		wg := &sync.WaitGroup{}
+		for i, id := range ids {
+			wg.Add(1)
+			go func(i int, id string) {
+				defer wg.Done()
+				errs[i] = s.ensureNetworkSubnet(ctx, id)
+			}(i, id)
+		}
+		wg.Wait()
+		for _, err := range errs {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		networks, err := s.store.GetNodeNetworks(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(networks["test-node-a"]) != 1 || len(networks["test-node-b"]) != 1 {
+			t.Fatalf("expected a node network per node; received %v", networks)
+		}
+		a, b := networks["test-node-a"][0], networks["test-node-b"][0]
+		if a == b {
+			t.Fatalf("expected unique node networks; received %q and %q", a, b)
+		}
+
+		if err := s.releaseNodeNetwork(ctx, "test-node-a"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.store.ReserveNodeNetwork(ctx, "test-node-c", a); err != nil {
+			t.Errorf("expected released network %s to be available: %s", a, err)
+		}
+		if _, err := s.store.ReserveNodeNetwork(ctx, "test-node-d", b); err != store.ErrExists {
+			t.Errorf("expected ErrExists reserving %s; received %v", b, err)
+		}
+	}
+}
+
+func TestNetDualStack(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	srv, err := NewServer(&heimdall.Config{
+		ID:            "test",
+		NodeNetwork:   testNodeNetwork,
+		NodeNetworkV6: testNodeNetworkV6,
+		PeerNetwork:   testPeerNetwork,
+		PeerNetworkV6: testPeerNetworkV6,
+		DataDir:       tmpDir,
+		StoreBackend:  StoreBackendEmbedded,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for i, expected := range []string{"10.51.0.2/16, fd00:51::2/64", "10.51.0.3/16, fd00:51::3/64"} {
+		addrs, err := srv.getOrAllocatePeerIPs(ctx, fmt.Sprintf("test-peer-%d", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v := joinAddresses(addrs); v != expected {
+			t.Errorf("expected peer addresses %s; received %s", expected, v)
+		}
+	}
+	// existing allocations are kept
+	addrs, err := srv.getOrAllocatePeerIPs(ctx, "test-peer-0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := joinAddresses(addrs); v != "10.51.0.2/16, fd00:51::2/64" {
+		t.Errorf("expected existing peer addresses; received %s", v)
+	}
+
+	if err := srv.ensureNetworkSubnet(ctx, "test-node"); err != nil {
+		t.Fatal(err)
+	}
+	gateways, err := srv.getNodeIPs(ctx, "test-node")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := joinAddresses(gateways); v != "10.11.0.1/16, fd00:10:1::1/48" {
+		t.Errorf("expected node addresses 10.11.0.1/16, fd00:10:1::1/48; received %s", v)
+	}
+
+	if err := srv.releasePeerIP(ctx, "test-peer-0"); err != nil {
+		t.Fatal(err)
+	}
+	ips, err := srv.getPeerIP(ctx, "test-peer-0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ips) != 0 {
+		t.Errorf("expected all peer ips to be released; received %v", ips)
+	}
+}
+
+func TestNetStaticPeerIP(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	srv, err := NewServer(&heimdall.Config{
+		ID:                  "test",
+		NodeNetwork:         testNodeNetwork,
+		PeerNetwork:         testPeerNetwork,
+		PeerNetworkExcludes: []string{"10.51.0.2-10.51.0.9"},
+		DataDir:             tmpDir,
+		StoreBackend:        StoreBackendEmbedded,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	ip, _, err := srv.getOrAllocatePeerIP(ctx, "peer-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip.String() != "10.51.0.10" {
+		t.Errorf("expected first ip after excluded range 10.51.0.10; received %s", ip)
+	}
+
+	// static reservations can be made in excluded ranges
+	if err := srv.reservePeerIPs(ctx, "peer-b", []string{"10.51.0.5"}); err != nil {
+		t.Fatal(err)
+	}
+	ip, _, err = srv.getOrAllocatePeerIP(ctx, "peer-b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip.String() != "10.51.0.5" {
+		t.Errorf("expected static ip 10.51.0.5; received %s", ip)
+	}
+	if err := srv.reservePeerIPs(ctx, "peer-c", []string{"10.51.0.10"}); errors.Cause(err) != ErrPeerIPAllocated {
+		t.Errorf("expected ErrPeerIPAllocated; received %v", err)
+	}
+	for _, invalid := range []string{"10.51.0.1", "10.52.0.2", "invalid"} {
+		if err := srv.reservePeerIPs(ctx, "peer-c", []string{invalid}); errors.Cause(err) != ErrInvalidPeerIP {
+			t.Errorf("expected ErrInvalidPeerIP for %s; received %v", invalid, err)
+		}
+	}
+	// reserving replaces the existing allocation
+	if err := srv.reservePeerIPs(ctx, "peer-a", []string{"10.51.0.100"}); err != nil {
+		t.Fatal(err)
+	}
+	ip, _, err = srv.getOrAllocatePeerIP(ctx, "peer-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip.String() != "10.51.0.100" {
+		t.Errorf("expected static ip 10.51.0.100; received %s", ip)
+	}
+
+	resp, err := srv.PeerNetworkUsage(ctx, &v1.PeerNetworkUsageRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Networks) != 1 {
+		t.Fatalf("expected usage of 1 network; received %+v", resp.Networks)
+	}
+	if u := resp.Networks[0]; u.Total != 65533 || u.Allocated != 2 || u.Excluded != 7 || u.Available != 65524 {
+		t.Errorf("unexpected usage %+v", u)
+	}
+}
+
+func TestNetNodeSubnetSize(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	cfg := &heimdall.Config{
+		ID:             "test",
+		NodeNetwork:    "10.0.0.0/22",
+		NodeSubnetSize: 24,
+		PeerNetwork:    testPeerNetwork,
+		DataDir:        tmpDir,
+		StoreBackend:   StoreBackendEmbedded,
+	}
+	srv, err := NewServer(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		id := fmt.Sprintf("node-%d", i)
+		if err := srv.ensureNetworkSubnet(ctx, id); err != nil {
+			t.Fatal(err)
+		}
+		gateways, err := srv.getNodeIPs(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := fmt.Sprintf("10.0.%d.1/24", i)
+		if v := joinAddresses(gateways); v != expected {
+			t.Errorf("expected node address %s; received %s", expected, v)
+		}
+	}
+	if err := srv.ensureNetworkSubnet(ctx, "node-4"); err == nil {
+		t.Error("expected error when the node network is exhausted")
+	}
+
+	if err := srv.checkNodeSubnetSize(ctx, srv.joinRequest()); err != nil {
+		t.Error(err)
+	}
+	req := srv.joinRequest()
+	req.NodeSubnetSize = 16
+	if err := srv.checkNodeSubnetSize(ctx, req); errors.Cause(err) != ErrNodeSubnetSizeMismatch {
+		t.Errorf("expected ErrNodeSubnetSizeMismatch; received %v", err)
+	}
+
+	cfg.NodeSubnetSize = 20
+	if _, err := NewServer(cfg); err == nil {
+		t.Error("expected error for node subnet size larger than the node network")
+	}
+
+	// the size stored when the cluster was created is checked instead of
+	// the local config of the node
+	cfg.NodeSubnetSize = 23
+	restarted, err := NewServer(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := restarted.checkNodeSubnetSize(ctx, restarted.joinRequest()); errors.Cause(err) != ErrNodeSubnetSizeMismatch {
+		t.Errorf("expected ErrNodeSubnetSizeMismatch for the local node; received %v", err)
+	}
+	req.NodeSubnetSize = 24
+	if err := restarted.checkNodeSubnetSize(ctx, req); err != nil {
+		t.Errorf("expected join with the cluster size to be accepted; received %v", err)
+	}
+}
@@ -3,14 +3,14 @@ package server
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/url"
-	"sort"
-	"strings"
+	"strconv"
 	"time"
 
 	v1 "github.com/ehazlett/heimdall/api/v1"
-	"github.com/gogo/protobuf/proto"
-	"github.com/gomodule/redigo/redis"
+	"github.com/ehazlett/heimdall/store"
+	ptypes "github.com/gogo/protobuf/types"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -21,47 +21,208 @@ func (s *Server) Nodes(ctx context.Context, req *v1.NodesRequest) (*v1.NodesResp
 	if err != nil {
 		return nil, err
 	}
+	master, err := s.store.GetMaster(ctx)
+	if err != nil && err != store.ErrNotFound {
+		return nil, err
+	}
 	return &v1.NodesResponse{
-		Nodes: nodes,
+		Nodes:  nodes,
+		Master: master,
 	}, nil
 }
 
-func (s *Server) getNodes(ctx context.Context) ([]*v1.Node, error) {
-	nodeKeys, err := redis.Strings(s.local(ctx, "KEYS", s.getNodeKey("*")))
+// RemoveNode removes the node and releases its resources
+func (s *Server) RemoveNode(ctx context.Context, req *v1.RemoveNodeRequest) (*ptypes.Empty, error) {
+	if req.ID == "" {
+		return nil, ErrNodeDoesNotExist
+	}
+	if req.ID == s.currentMaster() {
+		return nil, ErrRemoveMaster
+	}
+	if _, err := s.getNode(ctx, req.ID); err != nil {
+		if err != store.ErrNotFound {
+			return nil, err
+		}
+		// the node record may have expired while its network remains
+		if _, err := s.store.GetNodeNetwork(ctx, req.ID); err != nil {
+			if err == store.ErrNotFound {
+				return nil, errors.Wrap(ErrNodeDoesNotExist, req.ID)
+			}
+			return nil, err
+		}
+	}
+	if req.ReassignRoutesTo != "" {
+		if req.ReassignRoutesTo == req.ID {
+			return nil, errors.Wrap(ErrInvalidReassign, req.ID)
+		}
+		if _, err := s.getNode(ctx, req.ReassignRoutesTo); err != nil {
+			if err == store.ErrNotFound {
+				return nil, errors.Wrap(ErrNodeDoesNotExist, req.ReassignRoutesTo)
+			}
+			return nil, err
+		}
+	}
+
+	logrus.Infof("removing node %s", req.ID)
+	if err := s.store.MarkNodeRemoved(ctx, req.ID, nodeRemovalExpiry); err != nil {
+		return nil, err
+	}
+	routes, err := s.getRoutes(ctx)
 	if err != nil {
 		return nil, err
 	}
-	var nodes []*v1.Node
-	for _, nodeKey := range nodeKeys {
-		data, err := redis.Bytes(s.local(ctx, "GET", nodeKey))
+	for _, r := range routes {
+		nodes := routeNodes(r)
+		candidate := false
+		reassigned := false
+		for _, n := range nodes {
+			candidate = candidate || n.NodeID == req.ID
+			reassigned = reassigned || n.NodeID == req.ReassignRoutesTo
+		}
+		if !candidate {
+			continue
+		}
+		// remove the node from the route if there is no new node or it is
+		// already a candidate
+		if req.ReassignRoutesTo == "" || reassigned {
+			logrus.Infof("removing node %s from route %s", req.ID, r.Network)
+			if err := s.removeRouteNode(ctx, r, req.ID); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		logrus.Infof("reassigning route %s from %s to %s", r.Network, req.ID, req.ReassignRoutesTo)
+		for _, n := range nodes {
+			if n.NodeID == req.ID {
+				n.NodeID = req.ReassignRoutesTo
+			}
+		}
+		r.Nodes = sortRouteNodes(nodes)
+		r.NodeID = r.Nodes[0].NodeID
+		if err := s.store.SaveRoute(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.store.DeleteNode(ctx, req.ID); err != nil {
+		return nil, err
+	}
+	if err := s.releaseNodeNetwork(ctx, req.ID); err != nil {
+		return nil, err
+	}
+	if err := s.store.DeletePeer(ctx, req.ID); err != nil {
+		return nil, err
+	}
+	if err := s.releasePeerIP(ctx, req.ID); err != nil {
+		return nil, err
+	}
+	if err := s.store.UndrainNode(ctx, req.ID); err != nil {
+		return nil, err
+	}
+
+	// notify nodes to update tunnels
+	if err := s.store.Publish(ctx, store.EventUpdateTunnel); err != nil {
+		return nil, err
+	}
+	logrus.Infof("removed node %s", req.ID)
+	return empty, nil
+}
+
+// RestoreNode allows a removed node to rejoin the cluster before the removal
+// expires
+func (s *Server) RestoreNode(ctx context.Context, req *v1.RestoreNodeRequest) (*ptypes.Empty, error) {
+	if err := s.checkNodeRemoved(ctx, req.ID); err != ErrNodeRemoved {
 		if err != nil {
 			return nil, err
 		}
+		return nil, errors.Wrap(ErrNodeNotRemoved, req.ID)
+	}
+	logrus.Infof("restoring node %s", req.ID)
+	if err := s.store.ClearNodeRemoved(ctx, req.ID); err != nil {
+		return nil, err
+	}
+	return empty, nil
+}
 
-		var node v1.Node
-		if err := proto.Unmarshal(data, &node); err != nil {
+// DrainNode stops advertising the node as a DNS server and gateway to peers
+func (s *Server) DrainNode(ctx context.Context, req *v1.DrainNodeRequest) (*ptypes.Empty, error) {
+	if _, err := s.getNode(ctx, req.ID); err != nil {
+		if err == store.ErrNotFound {
+			return nil, errors.Wrap(ErrNodeDoesNotExist, req.ID)
+		}
+		return nil, err
+	}
+	if req.Resume {
+		logrus.Infof("resuming node %s", req.ID)
+		if err := s.store.UndrainNode(ctx, req.ID); err != nil {
 			return nil, err
 		}
-		nodes = append(nodes, &node)
+	} else {
+		logrus.Infof("draining node %s", req.ID)
+		if err := s.store.DrainNode(ctx, req.ID); err != nil {
+			return nil, err
+		}
+	}
+	// notify nodes to update tunnels
+	if err := s.store.Publish(ctx, store.EventUpdateTunnel); err != nil {
+		return nil, err
 	}
+	return empty, nil
+}
+
+func (s *Server) getNodes(ctx context.Context) ([]*v1.Node, error) {
+	nodes, err := s.store.GetNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	draining, err := s.drainingNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		_, n.Draining = draining[n.ID]
+	}
+	s.setNodeHealth(ctx, nodes)
 	return nodes, nil
 }
 
-func (s *Server) getNode(ctx context.Context, id string) (*v1.Node, error) {
-	data, err := redis.Bytes(s.local(ctx, "GET", s.getNodeKey(id)))
+func (s *Server) drainingNodes(ctx context.Context) (map[string]struct{}, error) {
+	ids, err := s.store.DrainingNodes(ctx)
 	if err != nil {
 		return nil, err
 	}
+	draining := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		draining[id] = struct{}{}
+	}
+	return draining, nil
+}
 
-	var node v1.Node
-	if err := proto.Unmarshal(data, &node); err != nil {
+func (s *Server) getNode(ctx context.Context, id string) (*v1.Node, error) {
+	node, err := s.store.GetNode(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	draining, err := s.drainingNodes(ctx)
+	if err != nil {
 		return nil, err
 	}
-	return &node, nil
+	_, node.Draining = draining[id]
+	s.setNodeHealth(ctx, []*v1.Node{node})
+	return node, nil
 }
 
 func (s *Server) configureNode() error {
 	ctx := context.Background()
+	// the embedded store does not replicate so the node is always the master
+	if s.redis == nil {
+		term, err := s.nextTerm(ctx)
+		if err != nil {
+			return err
+		}
+		return s.becomeMaster(ctx, term)
+	}
+
 	nodes, err := s.getNodes(ctx)
 	if err != nil {
 		return err
@@ -80,15 +241,7 @@ func (s *Server) configureNode() error {
 				logrus.Warn(err)
 				continue
 			}
-			r, err := c.Join(ctx, &v1.JoinRequest{
-				ID:            s.cfg.ID,
-				Name:          s.cfg.Name,
-				ClusterKey:    s.cfg.ClusterKey,
-				GRPCAddress:   s.cfg.GRPCAddress,
-				EndpointIP:    s.cfg.EndpointIP,
-				EndpointPort:  uint64(s.cfg.EndpointPort),
-				InterfaceName: s.cfg.InterfaceName,
-			})
+			r, err := c.Join(ctx, s.joinRequest())
 			if err != nil {
 				c.Close()
 				logrus.Warn(err)
@@ -121,144 +274,315 @@ func (s *Server) configureNode() error {
 				return err
 			}
 
-			go s.replicaMonitor()
+			s.startReplicaMonitor()
 
 			return nil
 		}
 	}
 
 	// no peer passed; start as master
-	data, err := redis.Bytes(s.local(ctx, "GET", masterKey))
+	master, err := s.store.GetMaster(ctx)
 	if err != nil {
-		if err != redis.ErrNil {
+		if err != store.ErrNotFound {
 			return err
 		}
-		logrus.Infof("starting as master id=%s name=%s", s.cfg.ID, s.cfg.Name)
-		if _, err := s.local(ctx, "REPLICAOF", "NO", "ONE"); err != nil {
+		term, err := s.nextTerm(ctx)
+		if err != nil {
 			return err
 		}
+		return s.becomeMaster(ctx, term)
+	}
 
-		// start server heartbeat
-		logrus.Debug("starting master heartbeat")
-		go s.masterHeartbeat()
+	logrus.Debug("cluster master found; joining existing")
 
-		// reset replica settings when promoting to master
-		logrus.Debug("disabling replica status")
-		s.disableReplica()
+	// join existing master
+	logrus.Debugf("joining cluster master %+v", master)
 
-		return nil
+	if err := s.joinMaster(master); err != nil {
+		return err
+	}
+	// reconfigure local redis on private IP
+	if err := s.reconfigureRedis(ctx, master.GatewayIP, master.RedisURL); err != nil {
+		return err
 	}
 
-	logrus.Debug("cluster master found; joining existing")
+	s.startReplicaMonitor()
 
-	// join existing master
-	var master v1.Master
-	if err := proto.Unmarshal(data, &master); err != nil {
+	return nil
+}
+
+// becomeMaster promotes the local node to master for the term
+func (s *Server) becomeMaster(ctx context.Context, term uint64) error {
+	logrus.Infof("starting as master id=%s name=%s term=%d", s.cfg.ID, s.cfg.Name, term)
+	if err := s.promoteRedis(ctx); err != nil {
 		return err
 	}
 
-	logrus.Debugf("joining cluster master %+v", master)
+	if err := s.acquireMaster(ctx, term); err != nil {
+		return errors.Wrapf(err, "error acquiring master lease for term %d", term)
+	}
 
-	if err := s.joinMaster(&master); err != nil {
+	return s.startMaster(ctx)
+}
+
+// promoteRedis configures the local redis as the master
+func (s *Server) promoteRedis(ctx context.Context) error {
+	if s.redis == nil {
+		return nil
+	}
+	if _, err := s.redis.Local(ctx, "REPLICAOF", "NO", "ONE"); err != nil {
 		return err
 	}
-	// reconfigure local redis on private IP
-	if err := s.reconfigureRedis(ctx, master.GatewayIP, master.RedisURL); err != nil {
+	// the revision replicated before the promotion marks where writes by
+	// this master begin if the cluster later splits
+	revision, err := s.store.Revision(ctx)
+	if err != nil {
 		return err
 	}
+	s.electionMu.Lock()
+	s.promotedRevision = revision
+	s.electionMu.Unlock()
+
+	// reset replica settings when promoting to master
+	logrus.Debug("disabling replica status")
+	return s.disableReplica()
+}
+
+// startMaster starts the master heartbeat and monitors once the master
+// lease is held
+func (s *Server) startMaster(ctx context.Context) error {
+	if s.redis != nil {
+		if err := s.redis.RemoveLegacyKeyPairs(ctx); err != nil {
+			logrus.WithError(err).Warn("error removing legacy keypairs")
+		}
+
+		if err := s.redis.IndexPeerIPs(ctx); err != nil {
+			logrus.WithError(err).Warn("error indexing peer ips")
+		}
+
+		if err := s.redis.IndexNodeNetworks(ctx); err != nil {
+			logrus.WithError(err).Warn("error indexing node networks")
+		}
+	}
+
+	mctx, cancel := context.WithCancel(context.Background())
+	s.electionMu.Lock()
+	s.master = s.cfg.ID
+	s.masterCancel = cancel
+	s.electionMu.Unlock()
+
+	// start server heartbeat
+	logrus.Debug("starting master heartbeat")
+	go s.masterHeartbeat(mctx)
+
+	if s.redis != nil {
+		go s.splitBrainMonitor(mctx)
+	}
 
-	go s.replicaMonitor()
+	// the master does not follow another master
+	s.stopReplicaMonitor()
 
 	return nil
 }
 
+// stopMaster stops the master heartbeat and monitors
+func (s *Server) stopMaster() {
+	s.electionMu.Lock()
+	defer s.electionMu.Unlock()
+	if s.masterCancel != nil {
+		s.masterCancel()
+		s.masterCancel = nil
+	}
+}
+
+// currentPromotedRevision returns the store revision when the local node
+// was promoted to master
+func (s *Server) currentPromotedRevision() uint64 {
+	s.electionMu.Lock()
+	defer s.electionMu.Unlock()
+	return s.promotedRevision
+}
+
+// currentMaster returns the id of the master the local node follows
+func (s *Server) currentMaster() string {
+	s.electionMu.Lock()
+	defer s.electionMu.Unlock()
+	return s.master
+}
+
 func (s *Server) disableReplica() error {
-	p, err := getPool(s.redisURL)
+	p, err := store.NewPool(s.redisURL)
 	if err != nil {
 		return err
 	}
-	s.wpool = p
-
-	// signal replica monitor to stop if started as a peer
-	close(s.replicaCh)
+	s.redis.SetMasterPool(p)
 
 	// unset peer
 	s.cfg.GRPCPeerAddress = ""
 	return nil
 }
 
-func (s *Server) replicaMonitor() {
+// startReplicaMonitor starts the replica monitor and stops the previous one
+func (s *Server) startReplicaMonitor() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.electionMu.Lock()
+	if s.replicaCancel != nil {
+		s.replicaCancel()
+	}
+	s.replicaCancel = cancel
+	s.electionMu.Unlock()
+
+	go s.replicaMonitor(ctx)
+}
+
+// stopReplicaMonitor stops the replica monitor if it is running
+func (s *Server) stopReplicaMonitor() {
+	s.electionMu.Lock()
+	defer s.electionMu.Unlock()
+	if s.replicaCancel != nil {
+		s.replicaCancel()
+		s.replicaCancel = nil
+	}
+}
+
+// isMaster returns true if the local node runs as master
+func (s *Server) isMaster() bool {
+	s.electionMu.Lock()
+	defer s.electionMu.Unlock()
+	return s.masterCancel != nil
+}
+
+// replicaMonitor follows the master until the context is canceled or the
+// local node is elected
+func (s *Server) replicaMonitor(ctx context.Context) {
 	logrus.Debugf("starting replica monitor: ttl=%s", masterHeartbeatInterval)
-	s.replicaCh = make(chan struct{}, 1)
 	t := time.NewTicker(masterHeartbeatInterval)
-	go func() {
-		for range t.C {
-			if _, err := redis.Bytes(s.local(context.Background(), "GET", masterKey)); err != nil {
-				if err == redis.ErrNil {
-					// skip configure until new leader election
-					n, err := s.getNextMaster(context.Background())
-					if err != nil {
-						logrus.Error(err)
-						continue
-					}
-					logrus.Debugf("replica monitor: node=%s", n)
-					if n == nil || n.ID != s.cfg.ID {
-						logrus.Debug("waiting for new master to initialize")
-						continue
-					}
-					logrus.Debugf("replica monitor: configuring node with master %+v", n)
-					if err := s.configureNode(); err != nil {
-						logrus.Error(err)
-						continue
-					}
-					return
-				}
-				logrus.Error(err)
+	defer t.Stop()
+
+	// previous is the last known master which is excluded from elections
+	previous := ""
+	missed := 0
+	for {
+		select {
+		case <-ctx.Done():
+			logrus.Debug("stopping replica monitor")
+			return
+		case <-t.C:
+		}
+		cctx, cancel := context.WithTimeout(ctx, masterHeartbeatInterval)
+		elected, err := s.checkMaster(cctx, &previous, &missed)
+		cancel()
+		if err != nil {
+			logrus.Error(err)
+			continue
+		}
+		if elected {
+			return
+		}
+	}
+}
+
+// checkMaster tracks the current master and runs an election once the
+// master lease expires.  It returns true if the local node was promoted.
+func (s *Server) checkMaster(ctx context.Context, previous *string, missed *int) (bool, error) {
+	m, err := s.store.GetMaster(ctx)
+	if err == nil {
+		*previous = m.ID
+		*missed = 0
+		// follow the new master if the previous master was demoted
+		if m.ID != s.cfg.ID && m.ID != s.currentMaster() {
+			logrus.Infof("master changed to %s term=%d", m.ID, m.Term)
+			if err := s.joinMaster(m); err != nil {
+				return false, err
 			}
+			return false, nil
+		}
+		if m.Term != s.currentTerm() {
+			s.setTerm(m.Term)
 		}
-	}()
+		return false, nil
+	}
+	if err != store.ErrNotFound {
+		return false, err
+	}
 
-	<-s.replicaCh
-	logrus.Debug("stopping replica monitor")
-	t.Stop()
-}
+	*missed++
+	logrus.Warnf("master lease expired for %q", *previous)
 
-func (s *Server) getNextMaster(ctx context.Context) (*v1.Node, error) {
-	nodes, err := s.getNodes(ctx)
+	// the master may have already been elected and this replica is still
+	// following the previous master
+	if m, err := s.findMaster(ctx, *previous); err == nil {
+		logrus.Infof("following master %s term=%d", m.ID, m.Term)
+		if err := s.joinMaster(m); err != nil {
+			return false, err
+		}
+		*previous = m.ID
+		*missed = 0
+		return false, nil
+	}
+
+	// stagger candidates to avoid split votes
+	rank, err := s.candidateRank(ctx, *previous)
 	if err != nil {
-		return nil, err
+		return false, err
+	}
+	if *missed <= rank {
+		logrus.Debugf("waiting for candidates: rank=%d missed=%d", rank, *missed)
+		return false, nil
+	}
+
+	term, elected, err := s.elect(ctx, *previous)
+	if err != nil {
+		return false, err
 	}
-	if len(nodes) == 0 {
-		return nil, nil
+	if !elected {
+		return false, nil
 	}
-	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].Updated.Before(nodes[j].Updated) })
-	return nodes[len(nodes)-1], nil
+	if err := s.becomeMaster(ctx, term); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
-func (s *Server) masterHeartbeat() {
+func (s *Server) masterHeartbeat(ctx context.Context) {
 	logrus.Debugf("starting master heartbeat: ttl=%s", masterHeartbeatInterval)
-	// initial update
+	logrus.Infof("cluster master key=%s", s.cfg.ClusterKey)
+	t := time.NewTicker(masterHeartbeatInterval)
+	defer t.Stop()
+	for {
+		if err := s.masterUpdate(); err != nil {
+			if err == store.ErrFenced {
+				logrus.Errorf("master lease lost for term %d; stopping master heartbeat", s.currentTerm())
+				return
+			}
+			logrus.Error(err)
+		}
+		select {
+		case <-ctx.Done():
+			logrus.Debug("stopping master heartbeat")
+			return
+		case <-t.C:
+		}
+	}
+}
+
+func (s *Server) masterUpdate() error {
 	ctx, cancel := context.WithTimeout(context.Background(), masterHeartbeatInterval)
 	defer cancel()
 
-	logrus.Infof("cluster master key=%s", s.cfg.ClusterKey)
 	if err := s.updateMasterInfo(ctx); err != nil {
-		logrus.Error(err)
+		return err
 	}
-
-	t := time.NewTicker(masterHeartbeatInterval)
-	for range t.C {
-		if err := s.updateMasterInfo(ctx); err != nil {
-			logrus.Error(err)
-			continue
-		}
+	if err := s.deleteStaleRoutes(ctx); err != nil {
+		logrus.WithError(err).Warn("error deleting stale routes")
 	}
+	return nil
 }
 
 func (s *Server) joinMaster(m *v1.Master) error {
 	// configure replica
 	logrus.Infof("configuring node as replica of %+v", m.ID)
-	pool, err := getPool(s.redisURL)
+	pool, err := store.NewPool(s.redisURL)
 	if err != nil {
 		return err
 	}
@@ -271,57 +595,69 @@ func (s *Server) joinMaster(m *v1.Master) error {
 	if err != nil {
 		return errors.Wrap(err, "error parsing master redis url")
 	}
-	hostPort := strings.SplitN(u.Host, ":", 2)
-	host := hostPort[0]
-	port := hostPort[1]
+	host := u.Hostname()
+	port := u.Port()
 	logrus.Debugf("setting replica to %s:%s", host, port)
 	if _, err := conn.Do("REPLICAOF", host, port); err != nil {
 		return errors.Wrapf(err, "error setting replica to %s:%s", host, port)
 	}
 
-	logrus.Debugf("updating wpool to master on %s", m.RedisURL)
-	s.wpool, err = getPool(m.RedisURL)
+	logrus.Debugf("updating master pool to %s", m.RedisURL)
+	wpool, err := store.NewPool(m.RedisURL)
 	if err != nil {
 		return err
 	}
+	s.redis.SetMasterPool(wpool)
+	s.setTerm(m.Term)
+
+	s.electionMu.Lock()
+	s.master = m.ID
+	s.electionMu.Unlock()
 	return nil
 }
 
 func (s *Server) updateMasterInfo(ctx context.Context) error {
-	// update master info
-	if _, err := s.master(ctx, "SET", clusterKey, s.cfg.ClusterKey); err != nil {
-		logrus.Error("updateMasterInfo.setClusterKey")
+	m, err := s.masterInfo(ctx)
+	if err != nil {
 		return err
 	}
-	// build redis url with gateway ip
-	gatewayIP, _, err := s.getNodeIP(ctx, s.cfg.ID)
-	if err != nil {
-		if err == redis.ErrNil {
-			logrus.Warnf("node does not have an IP assigned yet")
-			return nil
+	// renew the lease before any other writes to detect a lost lease
+	if err := s.store.SetMaster(ctx, m, masterLeaseTTL); err != nil {
+		if err == store.ErrFenced {
+			return err
 		}
+		return errors.Wrap(err, "error setting master info")
+	}
+	// update master info
+	if err := s.store.SetClusterKey(ctx, s.cfg.ClusterKey); err != nil {
+		logrus.Error("updateMasterInfo.setClusterKey")
 		return err
 	}
+	return nil
+}
+
+// masterInfo returns the master info for the local node
+func (s *Server) masterInfo(ctx context.Context) (*v1.Master, error) {
 	m := &v1.Master{
 		ID:          s.cfg.ID,
 		GRPCAddress: s.cfg.AdvertiseGRPCAddress,
-		RedisURL:    fmt.Sprintf("redis://%s:%d", gatewayIP.String(), s.cfg.RedisPort),
-		GatewayIP:   gatewayIP.String(),
+		Term:        s.currentTerm(),
+		Revision:    s.currentPromotedRevision(),
 	}
-	data, err := proto.Marshal(m)
+	// build redis url with gateway ip
+	gatewayIP, _, err := s.getNodeIP(ctx, s.cfg.ID)
 	if err != nil {
-		return errors.Wrap(err, "error marshalling master info")
-	}
-
-	if _, err := s.master(ctx, "SET", masterKey, data); err != nil {
-		return errors.Wrap(err, "error setting master info")
+		if err == store.ErrNotFound {
+			logrus.Warnf("node does not have an IP assigned yet")
+			return m, nil
+		}
+		return nil, err
 	}
-
-	if _, err := s.master(ctx, "EXPIRE", masterKey, int(masterHeartbeatInterval.Seconds())); err != nil {
-		return errors.Wrap(err, "error setting expire for master info")
+	m.GatewayIP = gatewayIP.String()
+	if s.redis != nil {
+		m.RedisURL = fmt.Sprintf("redis://%s", net.JoinHostPort(gatewayIP.String(), strconv.Itoa(s.cfg.RedisPort)))
 	}
-
-	return nil
+	return m, nil
 }
 
 func (s *Server) updateNodeInfo(ctx context.Context) {
@@ -329,6 +665,11 @@ func (s *Server) updateNodeInfo(ctx context.Context) {
 	t := time.NewTicker(nodeHeartbeatInterval)
 	for range t.C {
 		if err := s.updateLocalNodeInfo(ctx); err != nil {
+			if err == ErrNodeRemoved {
+				logrus.Errorf("stopping node heartbeat: %s", err)
+				t.Stop()
+				return
+			}
 			logrus.Error(err)
 			continue
 		}
@@ -336,12 +677,10 @@ func (s *Server) updateNodeInfo(ctx context.Context) {
 }
 
 func (s *Server) updateLocalNodeInfo(ctx context.Context) error {
-	key := s.getNodeKey(s.cfg.ID)
-	keyPair, err := s.getOrCreateKeyPair(ctx, s.cfg.ID)
-	if err != nil {
-		return errors.Wrapf(err, "error getting keypair for %s", s.cfg.ID)
+	if err := s.checkNodeRemoved(ctx, s.cfg.ID); err != nil {
+		return err
 	}
-	nodeIP, _, err := s.getNodeIP(ctx, s.cfg.ID)
+	nodeIPs, err := s.getNodeIPs(ctx, s.cfg.ID)
 	if err != nil {
 		return errors.Wrapf(err, "error getting node IP for %s", s.cfg.ID)
 	}
@@ -350,38 +689,37 @@ func (s *Server) updateLocalNodeInfo(ctx context.Context) error {
 		ID:            s.cfg.ID,
 		Name:          s.cfg.Name,
 		Addr:          s.cfg.AdvertiseGRPCAddress,
-		KeyPair:       keyPair,
+		PublicKey:     s.publicKey,
 		EndpointIP:    s.cfg.EndpointIP,
 		EndpointPort:  uint64(s.cfg.EndpointPort),
-		GatewayIP:     nodeIP.String(),
 		InterfaceName: s.cfg.InterfaceName,
+		ExitNode:      s.cfg.ExitNode,
 	}
+	setGatewayIPs(node, nodeIPs)
 
 	logrus.Debugf("local node info: %+v", node)
 
-	data, err := proto.Marshal(node)
-	if err != nil {
-		return errors.Wrap(err, "error marshalling local node info")
+	if err := s.store.SaveNode(ctx, node, nodeHeartbeatExpiry); err != nil {
+		return errors.Wrap(err, "error saving local node info")
 	}
 
-	if _, err := s.master(ctx, "SET", key, data); err != nil {
-		return err
-	}
+	return nil
+}
 
-	if _, err := s.master(ctx, "EXPIRE", key, nodeHeartbeatExpiry); err != nil {
+// checkNodeRemoved returns ErrNodeRemoved if the node has been removed
+func (s *Server) checkNodeRemoved(ctx context.Context, id string) error {
+	removed, err := s.store.IsNodeRemoved(ctx, id)
+	if err != nil {
 		return err
 	}
-
+	if removed {
+		return ErrNodeRemoved
+	}
 	return nil
 }
 
 func (s *Server) createNode(ctx context.Context, req *v1.JoinRequest) (*v1.Node, error) {
-	key := s.getNodeKey(req.ID)
-	keyPair, err := s.getOrCreateKeyPair(ctx, req.ID)
-	if err != nil {
-		return nil, errors.Wrapf(err, "error getting/creating keypair for %s", req.ID)
-	}
-	nodeIP, _, err := s.getNodeIP(ctx, req.ID)
+	nodeIPs, err := s.getNodeIPs(ctx, req.ID)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error getting node ip for %s", req.ID)
 	}
@@ -389,25 +727,25 @@ func (s *Server) createNode(ctx context.Context, req *v1.JoinRequest) (*v1.Node,
 		Updated:       time.Now(),
 		ID:            req.ID,
 		Addr:          req.GRPCAddress,
-		KeyPair:       keyPair,
+		PublicKey:     req.PublicKey,
 		EndpointIP:    req.EndpointIP,
 		EndpointPort:  uint64(req.EndpointPort),
-		GatewayIP:     nodeIP.String(),
 		InterfaceName: req.InterfaceName,
 	}
+	setGatewayIPs(node, nodeIPs)
 
-	data, err := proto.Marshal(node)
-	if err != nil {
+	if err := s.store.SaveNode(ctx, node, nodeHeartbeatExpiry); err != nil {
 		return nil, err
 	}
 
-	if _, err := s.master(ctx, "SET", key, data); err != nil {
-		return nil, err
-	}
+	return node, nil
+}
 
-	if _, err := s.master(ctx, "EXPIRE", key, nodeHeartbeatExpiry); err != nil {
-		return nil, err
+// setGatewayIPs sets the gateway IPs of the node
+func setGatewayIPs(node *v1.Node, gateways []*net.IPNet) {
+	ips := make([]net.IP, len(gateways))
+	for i, gateway := range gateways {
+		ips[i] = gateway.IP
 	}
-
-	return node, nil
+	node.GatewayIP, node.GatewayIPV6 = splitAddresses(ips)
 }
@@ -0,0 +1,224 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ehazlett/heimdall"
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/ehazlett/heimdall/store"
+	"github.com/pkg/errors"
+)
+
+func TestRemoveNode(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	s, err := NewServer(&heimdall.Config{
+		ID:           "test",
+		NodeNetwork:  testNodeNetwork,
+		PeerNetwork:  testPeerNetwork,
+		DataDir:      tmpDir,
+		StoreBackend: StoreBackendEmbedded,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for _, id := range []string{"node-a", "node-b"} {
+		if err := s.store.SaveNode(ctx, &v1.Node{ID: id}, 0); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.store.SavePeer(ctx, &v1.Peer{ID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.store.ReserveNodeNetwork(ctx, "node-a", "10.10.1.0/24"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.store.ReservePeerIP(ctx, "node-a", "10.51.0.2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.store.SaveRoute(ctx, &v1.Route{NodeID: "node-a", Network: "10.100.0.0/24"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.DrainNode(ctx, &v1.DrainNodeRequest{ID: "node-a"}); err != nil {
+		t.Fatal(err)
+	}
+	node, err := s.getNode(ctx, "node-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !node.Draining {
+		t.Error("expected node-a to be draining")
+	}
+
+	if _, err := s.RemoveNode(ctx, &v1.RemoveNodeRequest{ID: "node-a", ReassignRoutesTo: "node-a"}); err == nil {
+		t.Fatal("expected error reassigning routes to the removed node")
+	}
+	if _, err := s.RemoveNode(ctx, &v1.RemoveNodeRequest{ID: "node-a", ReassignRoutesTo: "node-b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.store.GetNode(ctx, "node-a"); err != store.ErrNotFound {
+		t.Errorf("expected node to be deleted; received %v", err)
+	}
+	if _, err := s.store.GetNodeNetwork(ctx, "node-a"); err != store.ErrNotFound {
+		t.Errorf("expected node network to be released; received %v", err)
+	}
+	if _, err := s.store.GetPeer(ctx, "node-a"); err != store.ErrNotFound {
+		t.Errorf("expected peer to be deleted; received %v", err)
+	}
+	ips, err := s.store.GetPeerIPs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ips["node-a"]; ok {
+		t.Error("expected peer ip to be released")
+	}
+	route, err := s.store.GetRoute(ctx, "10.100.0.0/24")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route.NodeID != "node-b" {
+		t.Errorf("expected route to be reassigned to node-b; received %s", route.NodeID)
+	}
+	draining, err := s.store.DrainingNodes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(draining) != 0 {
+		t.Errorf("expected no draining nodes; received %v", draining)
+	}
+	// a removed node that is still running must not register itself again
+	if err := s.updatePeerInfo(ctx, &v1.Peer{ID: "node-a"}); err != ErrNodeRemoved {
+		t.Errorf("expected removed node peer update to be refused; received %v", err)
+	}
+	if _, err := s.store.GetPeer(ctx, "node-a"); err != store.ErrNotFound {
+		t.Errorf("expected removed node peer to not be saved; received %v", err)
+	}
+
+	// a restored node can register itself again
+	if _, err := s.RestoreNode(ctx, &v1.RestoreNodeRequest{ID: "node-b"}); errors.Cause(err) != ErrNodeNotRemoved {
+		t.Errorf("expected ErrNodeNotRemoved; received %v", err)
+	}
+	if _, err := s.RestoreNode(ctx, &v1.RestoreNodeRequest{ID: "node-a"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.updatePeerInfo(ctx, &v1.Peer{ID: "node-a"}); err != nil {
+		t.Errorf("expected restored node peer update to be accepted; received %v", err)
+	}
+}
+
+func TestNodeHealth(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	s, err := NewServer(&heimdall.Config{
+		ID:                   "test",
+		NodeNetwork:          testNodeNetwork,
+		PeerNetwork:          testPeerNetwork,
+		DataDir:              tmpDir,
+		StoreBackend:         StoreBackendEmbedded,
+		NodeHealthTimeout:    time.Minute,
+		NodeHandshakeTimeout: time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	nodes := []*v1.Node{
+		{ID: "alive", Updated: now},
+		{ID: "dead", Updated: now.Add(-time.Minute * 2)},
+		{ID: "tunnel-down", Updated: now, LastHandshake: now.Add(-time.Minute * 2)},
+	}
+	s.setNodeHealth(context.Background(), nodes)
+
+	if !nodes[0].Healthy {
+		t.Errorf("expected node with recent heartbeat to be healthy: %s", nodes[0].HealthReason)
+	}
+	if nodes[1].Healthy {
+		t.Error("expected node with expired heartbeat to be unhealthy")
+	}
+	if nodes[2].Healthy {
+		t.Error("expected node with expired handshake to be unhealthy")
+	}
+}
+
+func TestReplicaMonitorStop(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	s, err := NewServer(&heimdall.Config{
+		ID:           "test",
+		NodeNetwork:  testNodeNetwork,
+		PeerNetwork:  testPeerNetwork,
+		DataDir:      tmpDir,
+		StoreBackend: StoreBackendEmbedded,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.replicaMonitor(ctx)
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected replica monitor to stop")
+	}
+
+	// stopping twice is safe
+	s.startReplicaMonitor()
+	s.stopReplicaMonitor()
+	s.stopReplicaMonitor()
+}
+
+func TestEmbeddedSingleNode(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	cfg := &heimdall.Config{
+		ID:              "test",
+		NodeNetwork:     testNodeNetwork,
+		PeerNetwork:     testPeerNetwork,
+		DataDir:         tmpDir,
+		StoreBackend:    StoreBackendEmbedded,
+		GRPCPeerAddress: "tcp://127.0.0.1:9000",
+	}
+	if _, err := NewServer(cfg); err != ErrClusteringUnsupported {
+		t.Fatalf("expected ErrClusteringUnsupported joining with the embedded store; received %v", err)
+	}
+
+	cfg.GRPCPeerAddress = ""
+	s, err := NewServer(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Join(context.Background(), &v1.JoinRequest{ID: "node-a"}); err != ErrClusteringUnsupported {
+		t.Errorf("expected ErrClusteringUnsupported for a join; received %v", err)
+	}
+}
@@ -2,56 +2,95 @@ package server
 
 import (
 	"context"
-	"fmt"
+	"net"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ehazlett/heimdall"
 	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/ehazlett/heimdall/firewall"
+	"github.com/ehazlett/heimdall/store"
 	"github.com/ehazlett/heimdall/wg"
 	"github.com/gogo/protobuf/proto"
-	"github.com/gomodule/redigo/redis"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
-// Peers returns a list of known peers
+// Peers returns a list of known peers optionally filtered by tag
 func (s *Server) Peers(ctx context.Context, req *v1.PeersRequest) (*v1.PeersResponse, error) {
 	peers, err := s.getPeers(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if req.Tag != "" {
+		tag := strings.ToLower(req.Tag)
+		tagged := []*v1.Peer{}
+		for _, p := range peers {
+			if hasTag(p.Tags, tag) {
+				tagged = append(tagged, p)
+			}
+		}
+		peers = tagged
+	}
 	return &v1.PeersResponse{
 		Peers: peers,
 	}, nil
 }
 
+// CheckPeerIPs reports peer IPs that have been allocated more than once
+func (s *Server) CheckPeerIPs(ctx context.Context, req *v1.CheckPeerIPsRequest) (*v1.CheckPeerIPsResponse, error) {
+	conflicts, err := s.checkPeerIPs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range conflicts {
+		logrus.Warnf("peer ip %s allocated to multiple peers: %v", c.IP, c.PeerIDs)
+	}
+	return &v1.CheckPeerIPsResponse{
+		Conflicts: conflicts,
+	}, nil
+}
+
 func (s *Server) getPeers(ctx context.Context) ([]*v1.Peer, error) {
-	peerKeys, err := redis.Strings(s.local(ctx, "KEYS", s.getPeerKey("*")))
+	peers, err := s.store.GetPeers(ctx)
 	if err != nil {
 		return nil, err
 	}
-	var peers []*v1.Peer
-	for _, peerKey := range peerKeys {
-		data, err := redis.Bytes(s.local(ctx, "GET", peerKey))
+	tags, err := s.store.GetPeerTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	activeRoutes, err := s.activeRoutes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, peer := range peers {
+		peerIPs, err := s.getPeerIP(ctx, peer.ID)
 		if err != nil {
 			return nil, err
 		}
+		peer.PeerIP, peer.PeerIPV6 = splitAddresses(peerIPs)
+		peer.Tags = tags[peer.ID]
+		peer.AllowedIPs = activeAllowedIPs(peer, activeRoutes)
+	}
+	return peers, nil
+}
 
-		var peer v1.Peer
-		if err := proto.Unmarshal(data, &peer); err != nil {
-			return nil, err
-		}
-		peerIP, err := s.getPeerIP(ctx, peer.ID)
-		if err != nil {
-			return nil, err
-		}
-		if peerIP != nil {
-			peer.PeerIP = peerIP.String()
+// activeAllowedIPs returns the allowed ips of the peer without the routes the
+// peer is not the active node for.  A failed node cannot withdraw its routes
+// so they are removed when the routes fail over.
+func activeAllowedIPs(peer *v1.Peer, activeRoutes map[string]string) []string {
+	allowedIPs := []string{}
+	for _, a := range peer.AllowedIPs {
+		if id, ok := activeRoutes[a]; ok && id != peer.ID {
+			continue
 		}
-		peers = append(peers, &peer)
+		allowedIPs = append(allowedIPs, a)
 	}
-	return peers, nil
+	return allowedIPs
 }
 
 func (s *Server) peerUpdater(ctx context.Context) {
@@ -59,44 +98,62 @@ func (s *Server) peerUpdater(ctx context.Context) {
 	t := time.NewTicker(peerConfigUpdateInterval)
 	for range t.C {
 		uctx, cancel := context.WithTimeout(ctx, peerConfigUpdateInterval)
-		if err := s.updatePeerInfo(uctx, s.cfg.ID, s.cfg.Name); err != nil {
-			logrus.Errorf("updateLocalPeerInfo: %s", err)
+		if err := s.updatePeerInfo(uctx, &v1.Peer{ID: s.cfg.ID, Name: s.cfg.Name, PublicKey: s.publicKey}); err != nil {
 			cancel()
+			if err == ErrNodeRemoved {
+				logrus.Errorf("stopping peer config updater: %s", err)
+				t.Stop()
+				return
+			}
+			logrus.Errorf("updateLocalPeerInfo: %s", err)
 			continue
 		}
 
-		peers, err := s.getPeers(ctx)
-		if err != nil {
-			logrus.Error(err)
+		if err := s.updateTunnel(uctx); err != nil {
+			logrus.Errorf("updateTunnel: %s", err)
 			cancel()
 			continue
 		}
+		cancel()
+	}
+}
 
-		node, err := s.getNode(ctx, s.cfg.ID)
-		if err != nil {
-			logrus.Error(err)
-			cancel()
-			continue
-		}
+// updateTunnel reconfigures the local tunnel with the current cluster peers
+func (s *Server) updateTunnel(ctx context.Context) error {
+	peers, err := s.getPeers(ctx)
+	if err != nil {
+		return err
+	}
 
-		if err := s.updatePeerConfig(uctx, node, peers); err != nil {
-			logrus.Errorf("updatePeerConfig: %s", err)
-			cancel()
-			continue
-		}
-		cancel()
+	node, err := s.getNode(ctx, s.cfg.ID)
+	if err != nil {
+		return err
 	}
+
+	return s.updatePeerConfig(ctx, node, peers)
 }
 
-func (s *Server) updatePeerInfo(ctx context.Context, id, name string) error {
-	keypair, err := s.getOrCreateKeyPair(ctx, id)
+// updatePeerInfo saves the peer with the allowed ips and endpoint derived from
+// the cluster state.  The id, name, public key, exit node and observed
+// addresses are taken from info.
+func (s *Server) updatePeerInfo(ctx context.Context, info *v1.Peer) error {
+	n, err := s.peerInfo(ctx, info)
 	if err != nil {
-		return errors.Wrap(err, "error getting or creating keypair")
+		return err
 	}
+	return s.savePeerInfo(ctx, n)
+}
 
+// peerInfo returns the peer record for the info with the allowed IPs and
+// endpoint of the peer
+func (s *Server) peerInfo(ctx context.Context, info *v1.Peer) (*v1.Peer, error) {
+	id := info.ID
+	if err := s.checkNodeRemoved(ctx, id); err != nil {
+		return nil, err
+	}
 	endpoint, err := s.getPeerEndpoint(ctx, id)
 	if err != nil {
-		return errors.Wrap(err, "error getting peer endpoint")
+		return nil, errors.Wrap(err, "error getting peer endpoint")
 	}
 
 	// build allowedIPs from routes and peer network
@@ -104,15 +161,17 @@ func (s *Server) updatePeerInfo(ctx context.Context, id, name string) error {
 
 	// add peer net
 	if endpoint == "" {
-		peerIP, err := s.getPeerIP(ctx, id)
+		peerIPs, err := s.getPeerIP(ctx, id)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		for _, ip := range peerIPs {
+			allowedIPs = append(allowedIPs, hostPrefix(ip))
 		}
-		allowedIPs = append(allowedIPs, peerIP.String()+"/32")
 	}
 	nodes, err := s.getNodes(ctx)
 	if err != nil {
-		return errors.Wrap(err, "error getting nodes")
+		return nil, errors.Wrap(err, "error getting nodes")
 	}
 
 	for _, node := range nodes {
@@ -121,22 +180,26 @@ func (s *Server) updatePeerInfo(ctx context.Context, id, name string) error {
 			continue
 		}
 
-		_, gatewayNet, err := s.getNodeIP(ctx, node.ID)
+		gateways, err := s.getNodeIPs(ctx, node.ID)
 		if err != nil {
-			return errors.Wrapf(err, "error getting node ip for %s", node.ID)
+			return nil, errors.Wrapf(err, "error getting node ip for %s", node.ID)
 		}
 
-		allowedIPs = append(allowedIPs, gatewayNet.String())
+		for _, gateway := range gateways {
+			allowedIPs = append(allowedIPs, subnetOf(gateway).String())
+		}
 	}
 
 	routes, err := s.getRoutes(ctx)
 	if err != nil {
-		return errors.Wrap(err, "error getting routes")
+		return nil, errors.Wrap(err, "error getting routes")
 	}
 
 	for _, route := range routes {
-		// only add the route if a peer to prevent route blackhole
-		if route.NodeID != id {
+		// only add the route if the active node to prevent route blackhole.
+		// Peers update their info while connected so their own routes are
+		// always added.
+		if route.PeerID != id && activeRouteNode(route, nodes, nil) != id {
 			continue
 		}
 
@@ -145,39 +208,46 @@ func (s *Server) updatePeerInfo(ctx context.Context, id, name string) error {
 
 	// add peer to peer if enabled (only for the peers not the nodes)
 	if endpoint != "" && s.cfg.AllowPeerToPeer {
-		allowedIPs = append(allowedIPs, s.cfg.PeerNetwork)
+		allowedIPs = append(allowedIPs, s.peerNetworks()...)
 	}
 
 	n := &v1.Peer{
-		ID:         id,
-		Name:       name,
-		KeyPair:    keypair,
-		AllowedIPs: allowedIPs,
-		Endpoint:   endpoint,
-	}
-
-	data, err := proto.Marshal(n)
-	if err != nil {
-		return errors.Wrap(err, "error marshalling peer info")
+		ID:                id,
+		Name:              info.Name,
+		PublicKey:         info.PublicKey,
+		AllowedIPs:        allowedIPs,
+		Endpoint:          endpoint,
+		ExitNode:          info.ExitNode,
+		ReflexiveEndpoint: info.ReflexiveEndpoint,
+		RemoteAddress:     info.RemoteAddress,
+		Mesh:              info.Mesh,
+		ListenPort:        info.ListenPort,
+		LocalAddresses:    info.LocalAddresses,
 	}
-	pHash := heimdall.HashData(data)
+	return n, nil
+}
 
-	key := s.getPeerKey(id)
-	peerData, err := redis.Bytes(s.local(ctx, "GET", key))
+// savePeerInfo saves the peer record unless it is unchanged
+func (s *Server) savePeerInfo(ctx context.Context, info *v1.Peer) error {
+	id := info.ID
+	n := proto.Clone(info).(*v1.Peer)
+	existing, err := s.store.GetPeer(ctx, id)
 	if err != nil {
-		if err != redis.ErrNil {
+		if err != store.ErrNotFound {
 			return err
 		}
 	}
-
-	eHash := heimdall.HashData(peerData)
+	// keep the last observed endpoint until the peer is seen again
+	if existing != nil && n.ReflexiveEndpoint == "" {
+		n.ReflexiveEndpoint = existing.ReflexiveEndpoint
+	}
 
 	// skip update if same
-	if pHash == eHash {
+	if existing != nil && proto.Equal(existing, n) {
 		return nil
 	}
 
-	if _, err := s.master(ctx, "SET", key, data); err != nil {
+	if err := s.store.SavePeer(ctx, n); err != nil {
 		return err
 	}
 
@@ -189,7 +259,7 @@ func (s *Server) updatePeerInfo(ctx context.Context, id, name string) error {
 func (s *Server) getPeerEndpoint(ctx context.Context, id string) (string, error) {
 	node, err := s.getNode(ctx, id)
 	if err != nil {
-		if err == redis.ErrNil {
+		if err == store.ErrNotFound {
 			return "", nil
 		}
 		return "", err
@@ -197,24 +267,18 @@ func (s *Server) getPeerEndpoint(ctx context.Context, id string) (string, error)
 	if node == nil {
 		return "", nil
 	}
-	return fmt.Sprintf("%s:%d", node.EndpointIP, node.EndpointPort), nil
+	return net.JoinHostPort(node.EndpointIP, strconv.FormatUint(node.EndpointPort, 10)), nil
 }
 
 func (s *Server) getPeerInfo(ctx context.Context, id string) (*v1.Peer, error) {
-	key := s.getPeerKey(id)
-	data, err := redis.Bytes(s.local(ctx, "GET", key))
+	peer, err := s.store.GetPeer(ctx, id)
 	if err != nil {
-		if err == redis.ErrNil {
+		if err == store.ErrNotFound {
 			return nil, nil
 		}
 		return nil, err
 	}
-	var peer v1.Peer
-	if err := proto.Unmarshal(data, &peer); err != nil {
-		return nil, err
-	}
-
-	return &peer, nil
+	return peer, nil
 }
 
 func (s *Server) updatePeerConfig(ctx context.Context, node *v1.Node, peers []*v1.Peer) error {
@@ -228,15 +292,33 @@ func (s *Server) updatePeerConfig(ctx context.Context, node *v1.Node, peers []*v
 		nodePeers = append(nodePeers, peer)
 	}
 
+	gateways, err := s.getNodeIPs(ctx, node.ID)
+	if err != nil {
+		return errors.Wrapf(err, "error getting node ip for %s", node.ID)
+	}
+
 	wireguardCfg := &wg.Config{
 		Interface:     node.InterfaceName,
 		NodeInterface: s.nodeInterface,
-		PrivateKey:    node.KeyPair.PrivateKey,
+		PrivateKey:    s.privateKey,
 		ListenPort:    int(node.EndpointPort),
-		Address:       fmt.Sprintf("%s/%d", node.GatewayIP, 16),
+		Address:       joinAddresses(gateways),
 		Peers:         nodePeers,
 	}
 
+	filters, err := s.policyFilters(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.updateFirewall(ctx, &firewall.Rules{
+		Interface:     node.InterfaceName,
+		NodeInterface: s.nodeInterface,
+		Filters:       filters,
+		ExitSources:   exitSources(node.ID, peers),
+	}); err != nil {
+		return err
+	}
+
 	wireguardConfigPath := s.getWireguardConfigPath()
 	tmpCfg, err := wg.GenerateNodeConfig(wireguardCfg, wireguardConfigPath)
 	if err != nil {
@@ -252,8 +334,6 @@ func (s *Server) updatePeerConfig(ctx context.Context, node *v1.Node, peers []*v
 	if h == s.currentConfigHash {
 		return nil
 	}
-	// update config hash
-	s.currentConfigHash = h
 
 	logrus.Debugf("updating peer config to version %s", h)
 	// update wireguard config
@@ -261,10 +341,29 @@ func (s *Server) updatePeerConfig(ctx context.Context, node *v1.Node, peers []*v
 		return err
 	}
 
-	// reload wireguard
-	if err := wg.RestartTunnel(ctx, s.getTunnelName()); err != nil {
+	// apply to the live interface
+	if err := s.wgDriver.Apply(ctx, wireguardCfg); err != nil {
 		return err
 	}
+	// only record the hash once applied so a failed config is retried
+	s.currentConfigHash = h
 
 	return nil
 }
+
+// exitSources returns the addresses of the peers using the node as exit node
+func exitSources(id string, peers []*v1.Peer) []string {
+	var sources []string
+	for _, p := range peers {
+		if p.ExitNode != id {
+			continue
+		}
+		for _, ip := range []string{p.PeerIP, p.PeerIPV6} {
+			if ip != "" {
+				sources = append(sources, ip)
+			}
+		}
+	}
+	sort.Strings(sources)
+	return sources
+}
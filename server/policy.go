@@ -0,0 +1,244 @@
+package server
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/ehazlett/heimdall/firewall"
+	"github.com/ehazlett/heimdall/store"
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrPolicyExists is returned when creating a policy with an existing id
+	ErrPolicyExists = errors.New("policy already exists")
+	// ErrInvalidPolicy is returned when a policy cannot be compiled to firewall rules
+	ErrInvalidPolicy = errors.New("invalid policy")
+)
+
+// CreatePolicy creates a new access policy
+func (s *Server) CreatePolicy(ctx context.Context, req *v1.CreatePolicyRequest) (*ptypes.Empty, error) {
+	policy := req.Policy
+	if policy == nil {
+		return nil, errors.Wrap(ErrInvalidPolicy, "policy must be specified")
+	}
+	if err := validatePolicy(policy); err != nil {
+		return nil, err
+	}
+
+	// check for existing policy
+	if _, err := s.store.GetPolicy(ctx, policy.ID); err != store.ErrNotFound {
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.Wrap(ErrPolicyExists, policy.ID)
+	}
+
+	if policy.PeerID != "" && policy.Tag != "" {
+		return nil, errors.Wrap(ErrInvalidPolicy, "peer and tag cannot both be specified")
+	}
+	if policy.Tag != "" {
+		tags, err := normalizeTags([]string{policy.Tag})
+		if err != nil {
+			return nil, err
+		}
+		policy.Tag = tags[0]
+	}
+
+	if policy.PeerID != "" {
+		authorized, err := s.store.IsAuthorized(ctx, policy.PeerID)
+		if err != nil {
+			return nil, err
+		}
+		if !authorized {
+			if _, err := s.store.GetPeer(ctx, policy.PeerID); err != nil {
+				if err == store.ErrNotFound {
+					return nil, errors.Wrapf(ErrInvalidPolicy, "unknown peer %s", policy.PeerID)
+				}
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.store.SavePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return empty, nil
+}
+
+// DeletePolicy deletes an access policy
+func (s *Server) DeletePolicy(ctx context.Context, req *v1.DeletePolicyRequest) (*ptypes.Empty, error) {
+	if err := s.store.DeletePolicy(ctx, req.ID); err != nil {
+		return nil, err
+	}
+	return empty, nil
+}
+
+// Policies returns the access policies in evaluation order
+func (s *Server) Policies(ctx context.Context, req *v1.PoliciesRequest) (*v1.PoliciesResponse, error) {
+	policies, err := s.store.GetPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sortPolicies(policies)
+	return &v1.PoliciesResponse{
+		Policies: policies,
+	}, nil
+}
+
+// validatePolicy checks the policy and normalizes the destination and protocol
+func validatePolicy(p *v1.Policy) error {
+	if p.ID == "" {
+		return errors.Wrap(ErrInvalidPolicy, "id must be specified")
+	}
+	_, dst, err := net.ParseCIDR(p.Destination)
+	if err != nil {
+		return errors.Wrapf(ErrInvalidPolicy, "invalid destination %q", p.Destination)
+	}
+	p.Destination = dst.String()
+
+	p.Protocol = strings.ToLower(p.Protocol)
+	switch p.Protocol {
+	case "", "icmp":
+		if p.Ports != "" {
+			return errors.Wrap(ErrInvalidPolicy, "ports require the tcp or udp protocol")
+		}
+	case "tcp", "udp":
+		if p.Ports != "" {
+			if err := validatePorts(p.Ports); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.Wrapf(ErrInvalidPolicy, "unsupported protocol %q", p.Protocol)
+	}
+	return nil
+}
+
+// validatePorts checks a port (80) or port range (8000-8100)
+func validatePorts(ports string) error {
+	parts := strings.Split(ports, "-")
+	if len(parts) > 2 {
+		return errors.Wrapf(ErrInvalidPolicy, "invalid ports %q", ports)
+	}
+	var prev uint64
+	for _, part := range parts {
+		port, err := strconv.ParseUint(part, 10, 16)
+		if err != nil || port == 0 || port < prev {
+			return errors.Wrapf(ErrInvalidPolicy, "invalid ports %q", ports)
+		}
+		prev = port
+	}
+	return nil
+}
+
+// sortPolicies orders the policies by priority and id
+func sortPolicies(policies []*v1.Policy) {
+	sort.SliceStable(policies, func(i, j int) bool {
+		if policies[i].Priority != policies[j].Priority {
+			return policies[i].Priority < policies[j].Priority
+		}
+		return policies[i].ID < policies[j].ID
+	})
+}
+
+// compilePolicies returns the firewall filters of the policies.  Policies
+// for a peer or tag only match the peer addresses in the address family of
+// the destination and are skipped if there is no such address.
+func compilePolicies(policies []*v1.Policy, peerIPs, peerTags map[string][]string) []firewall.Filter {
+	sortPolicies(policies)
+	tagged := map[string][]string{}
+	for _, id := range sortedIDs(peerTags) {
+		for _, tag := range peerTags[id] {
+			tagged[tag] = append(tagged[tag], id)
+		}
+	}
+	var filters []firewall.Filter
+	for _, p := range policies {
+		f := firewall.Filter{
+			Destination: p.Destination,
+			Protocol:    p.Protocol,
+			Ports:       p.Ports,
+			Accept:      p.Action == v1.Policy_ALLOW,
+		}
+		var peers []string
+		switch {
+		case p.PeerID != "":
+			peers = []string{p.PeerID}
+		case p.Tag != "":
+			peers = tagged[p.Tag]
+		}
+		if p.PeerID != "" || p.Tag != "" {
+			for _, id := range peers {
+				for _, ip := range peerIPs[id] {
+					if store.IsIPv6(ip) == f.IPv6() {
+						f.Sources = append(f.Sources, ip)
+					}
+				}
+			}
+			if len(f.Sources) == 0 {
+				continue
+			}
+		}
+		filters = append(filters, f)
+	}
+	return filters
+}
+
+// policyFilters returns the firewall filters of the cluster access policies
+func (s *Server) policyFilters(ctx context.Context) ([]firewall.Filter, error) {
+	policies, err := s.store.GetPolicies(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting policies")
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	peerIPs, err := s.store.GetPeerIPs(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting peer ips")
+	}
+	peerTags, err := s.store.GetPeerTags(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting peer tags")
+	}
+	return compilePolicies(policies, peerIPs, peerTags), nil
+}
+
+// policyRestricts returns true if a policy applies to the traffic from the
+// source peer to the addresses of the destination peer
+func policyRestricts(policies []*v1.Policy, src, dst *v1.Peer) bool {
+	for _, p := range policies {
+		if p.PeerID != "" && p.PeerID != src.ID {
+			continue
+		}
+		if p.Tag != "" && !hasTag(src.Tags, p.Tag) {
+			continue
+		}
+		_, n, err := net.ParseCIDR(p.Destination)
+		if err != nil {
+			continue
+		}
+		for _, ip := range []string{dst.PeerIP, dst.PeerIPV6} {
+			if v := net.ParseIP(ip); v != nil && n.Contains(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sortedIDs(m map[string][]string) []string {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ehazlett/heimdall"
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/pkg/errors"
+)
+
+func TestCreatePolicy(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	s, err := NewServer(&heimdall.Config{
+		ID:           "test",
+		NodeNetwork:  testNodeNetwork,
+		PeerNetwork:  testPeerNetwork,
+		DataDir:      tmpDir,
+		StoreBackend: StoreBackendEmbedded,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err := s.store.AuthorizePeer(ctx, "peer-a"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range []*v1.Policy{
+		{Destination: "10.100.0.0/24"},
+		{ID: "bad-destination", Destination: "10.100.0.0"},
+		{ID: "bad-protocol", Destination: "10.100.0.0/24", Protocol: "sctp"},
+		{ID: "icmp-ports", Destination: "10.100.0.0/24", Protocol: "icmp", Ports: "80"},
+		{ID: "bad-ports", Destination: "10.100.0.0/24", Protocol: "tcp", Ports: "8100-8000"},
+		{ID: "unknown-peer", PeerID: "peer-b", Destination: "10.100.0.0/24"},
+	} {
+		if _, err := s.CreatePolicy(ctx, &v1.CreatePolicyRequest{Policy: p}); errors.Cause(err) != ErrInvalidPolicy {
+			t.Errorf("expected ErrInvalidPolicy for %+v; received %v", p, err)
+		}
+	}
+
+	policy := &v1.Policy{
+		ID:          "web",
+		PeerID:      "peer-a",
+		Destination: "10.100.0.10/24",
+		Protocol:    "TCP",
+		Ports:       "443",
+	}
+	if _, err := s.CreatePolicy(ctx, &v1.CreatePolicyRequest{Policy: policy}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CreatePolicy(ctx, &v1.CreatePolicyRequest{Policy: policy}); errors.Cause(err) != ErrPolicyExists {
+		t.Errorf("expected ErrPolicyExists; received %v", err)
+	}
+
+	resp, err := s.Policies(ctx, &v1.PoliciesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Policies) != 1 {
+		t.Fatalf("expected 1 policy; received %d", len(resp.Policies))
+	}
+	if p := resp.Policies[0]; p.Destination != "10.100.0.0/24" || p.Protocol != "tcp" {
+		t.Errorf("expected normalized policy; received %+v", p)
+	}
+}
+
+func TestCompilePolicies(t *testing.T) {
+	policies := []*v1.Policy{
+		{ID: "deny-a", PeerID: "peer-a", Destination: "10.100.0.0/24", Action: v1.Policy_DENY, Priority: 10},
+		{ID: "web-a", PeerID: "peer-a", Destination: "10.100.0.0/24", Protocol: "tcp", Ports: "443", Priority: 1},
+		{ID: "web-a6", PeerID: "peer-a", Destination: "fd00:100::/64", Protocol: "tcp", Ports: "443", Priority: 1},
+		{ID: "deny-b", PeerID: "peer-b", Destination: "10.100.0.0/24", Action: v1.Policy_DENY},
+		{ID: "deny-all", Destination: "10.200.0.0/24", Action: v1.Policy_DENY, Priority: 20},
+		{ID: "ops", Tag: "ops", Destination: "10.200.0.0/24", Priority: 30},
+	}
+	peerIPs := map[string][]string{
+		"peer-a": {"10.51.0.2", "fd00:51::2"},
+		"peer-c": {"10.51.0.4"},
+	}
+	peerTags := map[string][]string{
+		"peer-a": {"ops"},
+		"peer-c": {"ops"},
+	}
+
+	filters := compilePolicies(policies, peerIPs, peerTags)
+	// deny-b is skipped as peer-b has no address
+	if len(filters) != 5 {
+		t.Fatalf("expected 4 filters; received %+v", filters)
+	}
+	if f := filters[0]; f.Destination != "10.100.0.0/24" || !f.Accept || f.Ports != "443" || len(f.Sources) != 1 || f.Sources[0] != "10.51.0.2" {
+		t.Errorf("unexpected web-a filter %+v", f)
+	}
+	if f := filters[1]; f.Destination != "fd00:100::/64" || len(f.Sources) != 1 || f.Sources[0] != "fd00:51::2" {
+		t.Errorf("unexpected web-a6 filter %+v", f)
+	}
+	if f := filters[2]; f.Destination != "10.100.0.0/24" || f.Accept {
+		t.Errorf("unexpected deny-a filter %+v", f)
+	}
+	if f := filters[3]; f.Destination != "10.200.0.0/24" || f.Accept || len(f.Sources) != 0 {
+		t.Errorf("unexpected deny-all filter %+v", f)
+	}
+	if f := filters[4]; !f.Accept || len(f.Sources) != 2 || f.Sources[0] != "10.51.0.2" || f.Sources[1] != "10.51.0.4" {
+		t.Errorf("unexpected ops filter %+v", f)
+	}
+}
@@ -2,91 +2,534 @@ package server
 
 import (
 	"context"
+	"net"
+	"sort"
+	"strings"
+	"time"
 
 	v1 "github.com/ehazlett/heimdall/api/v1"
-	"github.com/gogo/protobuf/proto"
+	"github.com/ehazlett/heimdall/store"
 	ptypes "github.com/gogo/protobuf/types"
-	"github.com/gomodule/redigo/redis"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// CreateRoute reserves a new route
+var (
+	// ErrNotRouteNode is returned when the node is not a candidate of the route
+	ErrNotRouteNode = errors.New("node is not a candidate of the route")
+	// ErrInvalidRoute is returned when the route network is not a valid cidr
+	ErrInvalidRoute = errors.New("invalid route")
+	// ErrRouteOverlap is returned when the route overlaps another route or
+	// a cluster network
+	ErrRouteOverlap = errors.New("route overlaps existing network")
+	// ErrRouteNotAdvertised is returned when approving a route the peer does
+	// not advertise
+	ErrRouteNotAdvertised = errors.New("route is not advertised by the peer")
+)
+
+// CreateRoute reserves a new route or adds a candidate node to an existing route
 func (s *Server) CreateRoute(ctx context.Context, req *v1.CreateRouteRequest) (*ptypes.Empty, error) {
-	// check for existing route
-	routeKey := s.getRouteKey(req.Network)
-	routeData, err := redis.Bytes(s.local(ctx, "GET", routeKey))
+	if err := s.createRoute(ctx, req); err != nil {
+		return nil, routeStatus(err)
+	}
+	return empty, nil
+}
+
+func (s *Server) createRoute(ctx context.Context, req *v1.CreateRouteRequest) error {
+	network, err := parseRoute(req.Network)
 	if err != nil {
-		if err != redis.ErrNil {
-			return nil, err
-		}
+		return err
 	}
-	if routeData != nil {
-		return nil, errors.Wrap(ErrRouteExists, req.Network)
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return err
 	}
 
 	// check for node id
-	if _, err := redis.Bytes(s.local(ctx, "GET", s.getNodeKey(req.NodeID))); err != nil {
-		if err == redis.ErrNil {
-			return nil, errors.Wrap(ErrNodeDoesNotExist, req.NodeID)
+	if _, err := s.store.GetNode(ctx, req.NodeID); err != nil {
+		if err == store.ErrNotFound {
+			return errors.Wrap(ErrNodeDoesNotExist, req.NodeID)
 		}
-		return nil, err
+		return err
 	}
 
-	// save route
-	route := &v1.Route{
-		NodeID:  req.NodeID,
-		Network: req.Network,
+	if !req.Force {
+		if err := s.checkRouteOverlap(ctx, network); err != nil {
+			return err
+		}
 	}
 
-	data, err := proto.Marshal(route)
+	route, err := s.store.GetRoute(ctx, network.String())
 	if err != nil {
-		return nil, err
+		if err != store.ErrNotFound {
+			return err
+		}
+		route = &v1.Route{
+			Network: network.String(),
+		}
 	}
-	if _, err := s.master(ctx, "SET", routeKey, data); err != nil {
-		return nil, err
+	if route.PeerID != "" {
+		return errors.Wrapf(ErrRouteExists, "%s via peer %s", route.Network, route.PeerID)
 	}
 
+	// check for existing candidate
+	nodes := routeNodes(route)
+	for _, n := range nodes {
+		if n.NodeID == req.NodeID {
+			return errors.Wrapf(ErrRouteExists, "%s via %s", route.Network, req.NodeID)
+		}
+	}
+	route.Nodes = sortRouteNodes(append(nodes, &v1.RouteNode{
+		NodeID:   req.NodeID,
+		Priority: req.Priority,
+	}))
+	route.NodeID = route.Nodes[0].NodeID
+	if len(tags) > 0 {
+		route.Tags = tags
+	}
+
+	return s.store.SaveRoute(ctx, route)
+}
+
+// ApproveRoute approves a network advertised by a peer so the nodes route the
+// network to the peer
+func (s *Server) ApproveRoute(ctx context.Context, req *v1.ApproveRouteRequest) (*ptypes.Empty, error) {
+	if err := s.approveRoute(ctx, req); err != nil {
+		return nil, routeStatus(err)
+	}
 	return empty, nil
 }
 
-// Delete deletes a new route
+func (s *Server) approveRoute(ctx context.Context, req *v1.ApproveRouteRequest) error {
+	network, err := parseRoute(req.Network)
+	if err != nil {
+		return err
+	}
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return err
+	}
+
+	advertised, err := s.store.GetAdvertisedRoutes(ctx)
+	if err != nil {
+		return err
+	}
+	if !hasNetwork(advertised[req.PeerID], network.String()) {
+		return errors.Wrapf(ErrRouteNotAdvertised, "%s via peer %s", network, req.PeerID)
+	}
+
+	if _, err := s.store.GetRoute(ctx, network.String()); err != store.ErrNotFound {
+		if err != nil {
+			return err
+		}
+		return errors.Wrap(ErrRouteExists, network.String())
+	}
+	if !req.Force {
+		if err := s.checkRouteOverlap(ctx, network); err != nil {
+			return err
+		}
+	}
+
+	logrus.Infof("approved route %s via peer %s", network, req.PeerID)
+	return s.store.SaveRoute(ctx, &v1.Route{
+		Network: network.String(),
+		PeerID:  req.PeerID,
+		Tags:    tags,
+	})
+}
+
+// Delete deletes a route or removes a candidate node from the route
 func (s *Server) DeleteRoute(ctx context.Context, req *v1.DeleteRouteRequest) (*ptypes.Empty, error) {
-	routeKey := s.getRouteKey(req.Network)
-	if _, err := s.master(ctx, "DEL", routeKey); err != nil {
-		return nil, err
+	if err := s.deleteRoute(ctx, req); err != nil {
+		return nil, routeStatus(err)
 	}
 	return empty, nil
 }
 
-// Routes returns a list of known routes
+func (s *Server) deleteRoute(ctx context.Context, req *v1.DeleteRouteRequest) error {
+	// routes created before validation may not be normalized
+	network := req.Network
+	if n, err := parseRoute(req.Network); err == nil {
+		if _, err := s.store.GetRoute(ctx, n.String()); err == nil {
+			network = n.String()
+		}
+	}
+
+	if req.NodeID == "" {
+		return s.store.DeleteRoute(ctx, network)
+	}
+
+	route, err := s.store.GetRoute(ctx, network)
+	if err != nil {
+		if err == store.ErrNotFound {
+			return errors.Wrapf(err, "route %s", network)
+		}
+		return err
+	}
+	return s.removeRouteNode(ctx, route, req.NodeID)
+}
+
+// Routes returns a list of known routes with their active node.  Routes
+// without a live node are stale.
 func (s *Server) Routes(ctx context.Context, req *v1.RoutesRequest) (*v1.RoutesResponse, error) {
 	routes, err := s.getRoutes(ctx)
 	if err != nil {
 		return nil, err
 	}
+	nodes, err := s.getNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := s.peerConfigStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range routes {
+		r.Nodes = routeNodes(r)
+		r.ActiveNodeID = activeRouteNode(r, nodes, statuses)
+		r.Stale = r.ActiveNodeID == ""
+	}
+	pending, err := s.pendingRoutes(ctx, routes)
+	if err != nil {
+		return nil, err
+	}
+	routes = append(routes, pending...)
 	return &v1.RoutesResponse{
 		Routes: routes,
 	}, nil
 }
 
 func (s *Server) getRoutes(ctx context.Context) ([]*v1.Route, error) {
-	routeKeys, err := redis.Strings(s.local(ctx, "KEYS", s.getRouteKey("*")))
+	return s.store.GetRoutes(ctx)
+}
+
+// removeRouteNode removes the candidate node from the route and deletes the
+// route when no candidates are left
+func (s *Server) removeRouteNode(ctx context.Context, route *v1.Route, id string) error {
+	var nodes []*v1.RouteNode
+	for _, n := range routeNodes(route) {
+		if n.NodeID != id {
+			nodes = append(nodes, n)
+		}
+	}
+	if len(nodes) == len(routeNodes(route)) {
+		return errors.Wrapf(ErrNotRouteNode, "%s via %s", route.Network, id)
+	}
+	if len(nodes) == 0 {
+		return s.store.DeleteRoute(ctx, route.Network)
+	}
+	route.Nodes = nodes
+	route.NodeID = nodes[0].NodeID
+	return s.store.SaveRoute(ctx, route)
+}
+
+// pendingRoutes returns the routes advertised by peers that are not approved
+func (s *Server) pendingRoutes(ctx context.Context, routes []*v1.Route) ([]*v1.Route, error) {
+	advertised, err := s.store.GetAdvertisedRoutes(ctx)
 	if err != nil {
 		return nil, err
 	}
-	var routes []*v1.Route
-	for _, routeKey := range routeKeys {
-		data, err := redis.Bytes(s.local(ctx, "GET", routeKey))
+	approved := map[string]struct{}{}
+	for _, r := range routes {
+		if r.PeerID != "" {
+			approved[r.PeerID+"/"+r.Network] = struct{}{}
+		}
+	}
+	pending := []*v1.Route{}
+	for _, id := range sortedIDs(advertised) {
+		for _, network := range advertised[id] {
+			if _, ok := approved[id+"/"+network]; ok {
+				continue
+			}
+			pending = append(pending, &v1.Route{
+				Network: network,
+				PeerID:  id,
+				Pending: true,
+			})
+		}
+	}
+	return pending, nil
+}
+
+// advertiseRoutes records the networks advertised by the peer and removes
+// the approved routes the peer no longer advertises
+func (s *Server) advertiseRoutes(ctx context.Context, id string, advertised []string) error {
+	networks := []string{}
+	for _, a := range advertised {
+		n, err := parseRoute(a)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if !hasNetwork(networks, n.String()) {
+			networks = append(networks, n.String())
 		}
+	}
+	sort.Strings(networks)
+	current, err := s.store.GetAdvertisedRoutes(ctx)
+	if err != nil {
+		return err
+	}
+	if equalStrings(current[id], networks) {
+		return nil
+	}
+	if err := s.store.SetAdvertisedRoutes(ctx, id, networks); err != nil {
+		return err
+	}
 
-		var route v1.Route
-		if err := proto.Unmarshal(data, &route); err != nil {
-			return nil, err
+	routes, err := s.getRoutes(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range routes {
+		if r.PeerID != id || hasNetwork(networks, r.Network) {
+			continue
+		}
+		logrus.Infof("withdrawing route %s no longer advertised by peer %s", r.Network, id)
+		if err := s.store.DeleteRoute(ctx, r.Network); err != nil {
+			return err
 		}
-		routes = append(routes, &route)
 	}
+	return nil
+}
 
-	return routes, nil
+// checkRouteOverlap returns an error if the network contains or is contained
+// in a cluster network or another route
+func (s *Server) checkRouteOverlap(ctx context.Context, network *net.IPNet) error {
+	clusterNetworks := append(s.nodeNetworks(), s.peerNetworks()...)
+	for _, c := range clusterNetworks {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			continue
+		}
+		if networksOverlap(network, n) {
+			return errors.Wrapf(ErrRouteOverlap, "%s overlaps cluster network %s", network, n)
+		}
+	}
+
+	routes, err := s.getRoutes(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range routes {
+		// adding a candidate to an existing route is not an overlap
+		if r.Network == network.String() {
+			continue
+		}
+		_, n, err := net.ParseCIDR(r.Network)
+		if err != nil {
+			continue
+		}
+		if networksOverlap(network, n) {
+			return errors.Wrapf(ErrRouteOverlap, "%s overlaps route %s", network, r.Network)
+		}
+	}
+	return nil
+}
+
+// deleteStaleRoutes deletes the routes that have had no live node for longer
+// than the route stale timeout.  This is only called from the master heartbeat.
+func (s *Server) deleteStaleRoutes(ctx context.Context) error {
+	if s.cfg.RouteStaleTimeout == 0 {
+		return nil
+	}
+	routes, err := s.getRoutes(ctx)
+	if err != nil {
+		return err
+	}
+	nodes, err := s.getNodes(ctx)
+	if err != nil {
+		return err
+	}
+	statuses, err := s.peerConfigStatuses(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range routes {
+		if activeRouteNode(r, nodes, statuses) != "" {
+			continue
+		}
+		// peers without a config sync, i.e. that only use connect, have
+		// never been observed so their routes are kept
+		if _, ok := statuses[r.PeerID]; r.PeerID != "" && !ok {
+			continue
+		}
+		if time.Since(routeLastSeen(r, nodes, statuses)) < s.cfg.RouteStaleTimeout {
+			continue
+		}
+		logrus.Infof("deleting stale route %s", r.Network)
+		if err := s.store.DeleteRoute(ctx, r.Network); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// activeRoutes returns the active node of each route by network
+func (s *Server) activeRoutes(ctx context.Context) (map[string]string, error) {
+	routes, err := s.getRoutes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := s.getNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := s.peerConfigStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active := make(map[string]string, len(routes))
+	for _, r := range routes {
+		active[r.Network] = activeRouteNode(r, nodes, statuses)
+	}
+	return active, nil
+}
+
+// parseRoute returns the normalized network of the route.  The default route
+// is reserved for exit nodes.
+func parseRoute(network string) (*net.IPNet, error) {
+	_, n, err := net.ParseCIDR(strings.TrimSpace(network))
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidRoute, "%q is not a valid cidr", network)
+	}
+	if ones, _ := n.Mask.Size(); ones == 0 {
+		return nil, errors.Wrapf(ErrInvalidRoute, "%s is the default route; use an exit node instead", n)
+	}
+	return n, nil
+}
+
+// hasNetwork returns true if the network is in the list
+func hasNetwork(networks []string, network string) bool {
+	for _, n := range networks {
+		if n == network {
+			return true
+		}
+	}
+	return false
+}
+
+// equalStrings returns true if the lists have the same items in order
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// networksOverlap returns true if one of the networks contains the other
+func networksOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// routeStatus returns the route error with its grpc status code
+func routeStatus(err error) error {
+	var code codes.Code
+	switch errors.Cause(err) {
+	case ErrInvalidRoute, ErrInvalidTag:
+		code = codes.InvalidArgument
+	case ErrNodeDoesNotExist, ErrNotRouteNode, store.ErrNotFound:
+		code = codes.NotFound
+	case ErrRouteExists:
+		code = codes.AlreadyExists
+	case ErrRouteOverlap, ErrRouteNotAdvertised:
+		code = codes.FailedPrecondition
+	default:
+		return err
+	}
+	return status.Error(code, err.Error())
+}
+
+// routeNodes returns the candidate nodes of the route in order of priority.
+// Routes created before candidates were supported only have a node id.
+func routeNodes(r *v1.Route) []*v1.RouteNode {
+	if len(r.Nodes) == 0 {
+		if r.NodeID == "" {
+			return nil
+		}
+		return []*v1.RouteNode{{NodeID: r.NodeID}}
+	}
+	return sortRouteNodes(r.Nodes)
+}
+
+// sortRouteNodes sorts the candidates by priority and then id so every node
+// selects the same active node
+func sortRouteNodes(nodes []*v1.RouteNode) []*v1.RouteNode {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Priority != nodes[j].Priority {
+			return nodes[i].Priority < nodes[j].Priority
+		}
+		return nodes[i].NodeID < nodes[j].NodeID
+	})
+	return nodes
+}
+
+// activeRouteNode returns the first healthy candidate of the route that is not
+// draining.  No node is returned if no candidate is live so the route is
+// withdrawn until one of the nodes comes back.  Peer routes are routed to the
+// advertising peer while it is connected.
+func activeRouteNode(r *v1.Route, nodes []*v1.Node, statuses map[string]*v1.PeerConfigStatus) string {
+	if r.PeerID != "" {
+		if peerConnected(statuses[r.PeerID], nodes) {
+			return r.PeerID
+		}
+		return ""
+	}
+	live := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		live[n.ID] = n.Healthy && !n.Draining
+	}
+	for _, c := range routeNodes(r) {
+		if live[c.NodeID] {
+			return c.NodeID
+		}
+	}
+	return ""
+}
+
+// routeLastSeen returns the latest heartbeat of the candidates of the route.
+// Peer routes were last seen when the peer disconnected or, if the node the
+// peer is connected to failed, at the last heartbeat of the node.
+func routeLastSeen(r *v1.Route, nodes []*v1.Node, statuses map[string]*v1.PeerConfigStatus) time.Time {
+	updated := make(map[string]time.Time, len(nodes))
+	for _, n := range nodes {
+		updated[n.ID] = n.Updated
+	}
+	if r.PeerID != "" {
+		status, ok := statuses[r.PeerID]
+		if !ok {
+			return time.Time{}
+		}
+		if status.Connected {
+			return updated[status.NodeID]
+		}
+		return status.Disconnected
+	}
+	var last time.Time
+	for _, c := range routeNodes(r) {
+		if t := updated[c.NodeID]; t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
+
+// peerConnected returns true if the peer holds a config sync with a healthy
+// node
+func peerConnected(status *v1.PeerConfigStatus, nodes []*v1.Node) bool {
+	if status == nil || !status.Connected {
+		return false
+	}
+	for _, n := range nodes {
+		if n.ID == status.NodeID {
+			return n.Healthy
+		}
+	}
+	return false
 }
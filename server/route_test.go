@@ -0,0 +1,371 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ehazlett/heimdall"
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/ehazlett/heimdall/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRouteFailover(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	s, err := NewServer(&heimdall.Config{
+		ID:           "test",
+		NodeNetwork:  testNodeNetwork,
+		PeerNetwork:  testPeerNetwork,
+		DataDir:      tmpDir,
+		StoreBackend: StoreBackendEmbedded,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for _, id := range []string{"node-a", "node-b"} {
+		if err := s.store.SaveNode(ctx, &v1.Node{ID: id, Updated: time.Now()}, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	network := "10.100.0.0/24"
+	if _, err := s.CreateRoute(ctx, &v1.CreateRouteRequest{NodeID: "node-b", Network: network, Priority: 20}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CreateRoute(ctx, &v1.CreateRouteRequest{NodeID: "node-a", Network: network, Priority: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CreateRoute(ctx, &v1.CreateRouteRequest{NodeID: "node-a", Network: network}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists; received %v", err)
+	}
+
+	activeNode := func() string {
+		resp, err := s.Routes(ctx, &v1.RoutesRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Routes) != 1 {
+			t.Fatalf("expected 1 route; received %d", len(resp.Routes))
+		}
+		if resp.Routes[0].Stale != (resp.Routes[0].ActiveNodeID == "") {
+			t.Errorf("expected route to be stale only without an active node")
+		}
+		return resp.Routes[0].ActiveNodeID
+	}
+	if id := activeNode(); id != "node-a" {
+		t.Errorf("expected node-a to be active; received %s", id)
+	}
+
+	// fail over when the preferred node stops sending heartbeats
+	if err := s.store.SaveNode(ctx, &v1.Node{ID: "node-a", Updated: time.Now().Add(-time.Hour)}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if id := activeNode(); id != "node-b" {
+		t.Errorf("expected node-b to be active; received %s", id)
+	}
+
+	// the route is removed from the failed node
+	peer := &v1.Peer{ID: "node-a", AllowedIPs: []string{"10.10.1.0/24", network}}
+	active, err := s.activeRoutes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allowedIPs := activeAllowedIPs(peer, active); !reflect.DeepEqual(allowedIPs, []string{"10.10.1.0/24"}) {
+		t.Errorf("expected route to be removed from node-a; received %v", allowedIPs)
+	}
+
+	if _, err := s.DeleteRoute(ctx, &v1.DeleteRouteRequest{Network: network, NodeID: "node-c"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound; received %v", err)
+	}
+	if _, err := s.DeleteRoute(ctx, &v1.DeleteRouteRequest{Network: network, NodeID: "node-b"}); err != nil {
+		t.Fatal(err)
+	}
+	// the route is withdrawn while the remaining node is down
+	if id := activeNode(); id != "" {
+		t.Errorf("expected route to be stale; received %s", id)
+	}
+	if err := s.store.SaveNode(ctx, &v1.Node{ID: "node-a", Updated: time.Now()}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if id := activeNode(); id != "node-a" {
+		t.Errorf("expected route to be restored to node-a; received %s", id)
+	}
+	if _, err := s.DeleteRoute(ctx, &v1.DeleteRouteRequest{Network: network, NodeID: "node-a"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.store.GetRoute(ctx, network); err != store.ErrNotFound {
+		t.Errorf("expected route to be deleted; received %v", err)
+	}
+}
+
+func TestDeleteStaleRoutes(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	s, err := NewServer(&heimdall.Config{
+		ID:                "test",
+		NodeNetwork:       testNodeNetwork,
+		PeerNetwork:       testPeerNetwork,
+		DataDir:           tmpDir,
+		StoreBackend:      StoreBackendEmbedded,
+		RouteStaleTimeout: time.Minute * 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+	for _, n := range []*v1.Node{
+		{ID: "alive", Updated: now},
+		{ID: "down", Updated: now.Add(-time.Minute * 5)},
+		{ID: "dead", Updated: now.Add(-time.Hour)},
+	} {
+		if err := s.store.SaveNode(ctx, n, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, id := range []string{"alive", "down", "dead"} {
+		route := &v1.Route{NodeID: id, Network: fmt.Sprintf("10.100.%d.0/24", i)}
+		if err := s.store.SaveRoute(ctx, route); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a route with a live standby is not stale
+	if err := s.store.SaveRoute(ctx, &v1.Route{
+		NodeID:  "dead",
+		Network: "10.100.3.0/24",
+		Nodes:   []*v1.RouteNode{{NodeID: "dead"}, {NodeID: "alive", Priority: 10}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// a peer without a config sync status has never been observed and its
+	// route is kept while the route of a peer that disconnected is deleted
+	for i, id := range []string{"peer-connect", "peer-gone"} {
+		route := &v1.Route{PeerID: id, Network: fmt.Sprintf("192.168.%d.0/24", i)}
+		if err := s.store.SaveRoute(ctx, route); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.store.SavePeerConfigStatus(ctx, &v1.PeerConfigStatus{ID: "peer-gone", NodeID: "alive", Disconnected: now.Add(-time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.deleteStaleRoutes(ctx); err != nil {
+		t.Fatal(err)
+	}
+	routes, err := s.getRoutes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	networks := []string{}
+	for _, r := range routes {
+		networks = append(networks, r.Network)
+	}
+	sort.Strings(networks)
+	expected := []string{"10.100.0.0/24", "10.100.1.0/24", "10.100.3.0/24", "192.168.0.0/24"}
+	if !reflect.DeepEqual(networks, expected) {
+		t.Errorf("expected routes %v; received %v", expected, networks)
+	}
+}
+
+func TestCreateRouteValidation(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	s, err := NewServer(&heimdall.Config{
+		ID:            "test",
+		NodeNetwork:   testNodeNetwork,
+		PeerNetwork:   testPeerNetwork,
+		PeerNetworkV6: "fd51::/64",
+		DataDir:       tmpDir,
+		StoreBackend:  StoreBackendEmbedded,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err := s.store.SaveNode(ctx, &v1.Node{ID: "node-a", Updated: time.Now()}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CreateRoute(ctx, &v1.CreateRouteRequest{NodeID: "node-a", Network: "10.100.0.1/16"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.store.GetRoute(ctx, "10.100.0.0/16"); err != nil {
+		t.Errorf("expected normalized route; received %v", err)
+	}
+
+	for _, tc := range []struct {
+		network string
+		nodeID  string
+		force   bool
+		code    codes.Code
+	}{
+		{network: "10.200.0.0", code: codes.InvalidArgument},
+		{network: "0.0.0.0/0", code: codes.InvalidArgument},
+		{network: "::/0", force: true, code: codes.InvalidArgument},
+		{network: "10.200.0.0/24", nodeID: "node-b", code: codes.NotFound},
+		{network: "10.100.0.0/16", code: codes.AlreadyExists},
+		{network: "10.100.1.0/24", code: codes.FailedPrecondition},
+		{network: "10.0.0.0/8", code: codes.FailedPrecondition},
+		{network: "10.10.5.0/24", code: codes.FailedPrecondition},
+		{network: "fd51::/96", code: codes.FailedPrecondition},
+		{network: "10.100.1.0/24", force: true, code: codes.OK},
+		{network: "fd52::/64", code: codes.OK},
+	} {
+		nodeID := tc.nodeID
+		if nodeID == "" {
+			nodeID = "node-a"
+		}
+		_, err := s.CreateRoute(ctx, &v1.CreateRouteRequest{NodeID: nodeID, Network: tc.network, Force: tc.force})
+		if code := status.Code(err); code != tc.code {
+			t.Errorf("%s: expected %s; received %v", tc.network, tc.code, err)
+		}
+	}
+
+	if _, err := s.DeleteRoute(ctx, &v1.DeleteRouteRequest{Network: "10.100.1.1/24"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.store.GetRoute(ctx, "10.100.1.0/24"); err != store.ErrNotFound {
+		t.Errorf("expected route to be deleted; received %v", err)
+	}
+}
+
+func TestPeerRoutes(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	s, err := NewServer(&heimdall.Config{
+		ID:           "test",
+		NodeNetwork:  testNodeNetwork,
+		PeerNetwork:  testPeerNetwork,
+		DataDir:      tmpDir,
+		StoreBackend: StoreBackendEmbedded,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	network := "192.168.1.0/24"
+	if err := s.advertiseRoutes(ctx, "peer-a", []string{"192.168.1.1/24", network}); err != nil {
+		t.Fatal(err)
+	}
+	// advertising the same routes again does not write to the store
+	revision, err := s.store.Revision(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.advertiseRoutes(ctx, "peer-a", []string{network}); err != nil {
+		t.Fatal(err)
+	}
+	if r, err := s.store.Revision(ctx); err != nil || r != revision {
+		t.Errorf("expected revision %d; received %d (%v)", revision, r, err)
+	}
+	resp, err := s.Routes(ctx, &v1.RoutesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Routes) != 1 || !resp.Routes[0].Pending || resp.Routes[0].Network != network {
+		t.Fatalf("expected pending route %s; received %v", network, resp.Routes)
+	}
+
+	if _, err := s.ApproveRoute(ctx, &v1.ApproveRouteRequest{PeerID: "peer-a", Network: "192.168.2.0/24"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition; received %v", err)
+	}
+	if _, err := s.ApproveRoute(ctx, &v1.ApproveRouteRequest{PeerID: "peer-a", Network: network}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.store.SaveNode(ctx, &v1.Node{ID: "node-a", Updated: time.Now()}, 0); err != nil {
+		t.Fatal(err)
+	}
+	peerStatus := &v1.PeerConfigStatus{ID: "peer-a", NodeID: "node-a", Connected: true}
+	if err := s.store.SavePeerConfigStatus(ctx, peerStatus); err != nil {
+		t.Fatal(err)
+	}
+	resp, err = s.Routes(ctx, &v1.RoutesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Routes) != 1 || resp.Routes[0].Pending || resp.Routes[0].ActiveNodeID != "peer-a" {
+		t.Fatalf("expected route %s to be active via peer-a; received %v", network, resp.Routes)
+	}
+
+	// peers reach the network through a node that relays it to the peer
+	routes, err := s.getRoutes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	peers := func() []*v1.Peer {
+		return []*v1.Peer{
+			{ID: "node-a", Endpoint: "10.0.0.1:10100", AllowedIPs: []string{"10.10.0.0/24"}},
+			{ID: "node-b", Endpoint: "10.0.0.2:10100", AllowedIPs: []string{"10.10.1.0/24"}},
+			{ID: "peer-a", AllowedIPs: []string{"10.51.0.2/32", network}},
+		}
+	}
+	relayed := peers()
+	relayPeerRoutes("peer-b", relayed, routes, nil)
+	if expected := []string{"10.10.0.0/24", network}; !reflect.DeepEqual(relayed[0].AllowedIPs, expected) {
+		t.Errorf("expected %v; received %v", expected, relayed[0].AllowedIPs)
+	}
+	if expected := []string{"10.51.0.2/32"}; !reflect.DeepEqual(relayed[2].AllowedIPs, expected) {
+		t.Errorf("expected %v; received %v", expected, relayed[2].AllowedIPs)
+	}
+	own := peers()
+	relayPeerRoutes("peer-a", own, routes, nil)
+	if expected := []string{"10.10.0.0/24"}; !reflect.DeepEqual(own[0].AllowedIPs, expected) {
+		t.Errorf("expected the advertising peer to not relay its own route; received %v", own[0].AllowedIPs)
+	}
+
+	// routes of an offline peer are stale and not relayed
+	peerStatus.Connected = false
+	peerStatus.Disconnected = time.Now()
+	if err := s.store.SavePeerConfigStatus(ctx, peerStatus); err != nil {
+		t.Fatal(err)
+	}
+	resp, err = s.Routes(ctx, &v1.RoutesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Routes) != 1 || !resp.Routes[0].Stale {
+		t.Fatalf("expected route %s to be stale; received %v", network, resp.Routes)
+	}
+	offline := peers()
+	offline[2].AllowedIPs = []string{"10.51.0.2/32"}
+	relayPeerRoutes("peer-b", offline, routes, nil)
+	if expected := []string{"10.10.0.0/24"}; !reflect.DeepEqual(offline[0].AllowedIPs, expected) {
+		t.Errorf("expected the offline peer route to not be relayed; received %v", offline[0].AllowedIPs)
+	}
+
+	// routes are withdrawn when the peer stops advertising them
+	if err := s.advertiseRoutes(ctx, "peer-a", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.store.GetRoute(ctx, network); err != store.ErrNotFound {
+		t.Errorf("expected route to be withdrawn; received %v", err)
+	}
+}
@@ -1,8 +1,6 @@
 package server
 
 import (
-	"bufio"
-	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -15,63 +13,94 @@ import (
 	"runtime"
 	"runtime/pprof"
 	"strconv"
-	"strings"
+	"sync"
 	"time"
 
 	ping "github.com/digineo/go-ping"
 	"github.com/ehazlett/heimdall"
 	v1 "github.com/ehazlett/heimdall/api/v1"
 	"github.com/ehazlett/heimdall/client"
+	"github.com/ehazlett/heimdall/firewall"
+	"github.com/ehazlett/heimdall/ipam"
+	"github.com/ehazlett/heimdall/store"
 	"github.com/ehazlett/heimdall/version"
 	"github.com/ehazlett/heimdall/wg"
-	"github.com/gogo/protobuf/proto"
 	ptypes "github.com/gogo/protobuf/types"
-	"github.com/gomodule/redigo/redis"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 )
 
 const (
-	masterKey                 = "heimdall:master"
-	clusterKey                = "heimdall:key"
-	keypairsKey               = "heimdall:keypairs"
-	nodesKey                  = "heimdall:nodes"
-	peersKey                  = "heimdall:peers"
-	routesKey                 = "heimdall:routes"
-	peerIPsKey                = "heimdall:peerips"
-	nodeIPsKey                = "heimdall:nodeips"
-	nodeNetworksKey           = "heimdall:nodenetworks"
-	authorizedPeersKey        = "heimdall:authorized"
-	nodeEventJoinKey          = "heimdall:join"
-	nodeEventRestartTunnelKey = "heimdall:restarttunnel"
+	// StoreBackendRedis uses a managed replicated Redis for cluster state
+	StoreBackendRedis = "redis"
+	// StoreBackendEmbedded uses an embedded single node store for cluster state
+	StoreBackendEmbedded = "embedded"
 
 	wireguardConfigDir = "/etc/wireguard"
+	wireguardKeyName   = "wireguard.key"
+	embeddedStateName  = "state.json"
 )
 
 var (
 	empty                    = &ptypes.Empty{}
 	masterHeartbeatInterval  = time.Second * 5
 	nodeHeartbeatInterval    = time.Second * 15
-	nodeHeartbeatExpiry      = 86400
+	nodeHeartbeatExpiry      = time.Hour * 24
 	peerConfigUpdateInterval = time.Second * 10
+	// nodeRemovalExpiry is how long a removed node is refused from rejoining
+	// so a node that is still running does not register itself again
+	nodeRemovalExpiry = nodeHeartbeatExpiry
+	// firewallReconcileInterval is how often the host firewall is checked
+	// for drift from the applied forwarding rules
+	firewallReconcileInterval = time.Second * 30
+	// peerPoolRefreshInterval is how often the peer address pools are
+	// reloaded from the store to pick up addresses released by other nodes
+	peerPoolRefreshInterval = time.Minute
 
 	// ErrRouteExists is returned when a requested route is already reserved
 	ErrRouteExists = errors.New("route already reserved")
 	// ErrNodeDoesNotExist is returned when an invalid node is requested
 	ErrNodeDoesNotExist = errors.New("node does not exist")
+	// ErrRemoveMaster is returned when removing the current master
+	ErrRemoveMaster = errors.New("cannot remove the master; step down first")
+	// ErrInvalidReassign is returned when routes are reassigned to the removed node
+	ErrInvalidReassign = errors.New("cannot reassign routes to the removed node")
+	// ErrNodeRemoved is returned when a removed node attempts to rejoin the cluster
+	ErrNodeRemoved = errors.New("node has been removed from the cluster")
+	// ErrNodeNotRemoved is returned when restoring a node that has not been removed
+	ErrNodeNotRemoved = errors.New("node has not been removed from the cluster")
+	// ErrClusteringUnsupported is returned when joining a cluster with a store backend that does not replicate
+	ErrClusteringUnsupported = errors.New("clustering requires the redis store backend")
 )
 
 // Server represents the Heimdall server
 type Server struct {
 	cfg               *heimdall.Config
 	nodeInterface     string
+	store             store.Store
+	redis             *store.Redis
 	redisCmd          *exec.Cmd
 	redisURL          string
-	rpool             *redis.Pool
-	wpool             *redis.Pool
-	replicaCh         chan struct{}
 	currentConfigHash string
+	privateKey        string
+	publicKey         string
+	wgDriver          wg.Driver
+	fwDriver          firewall.Driver
+	fwMu              sync.Mutex
+	fwRules           *firewall.Rules
+	ipamMu            sync.Mutex
+	peerPools         []*ipam.Pool
+	peerPoolsLoaded   time.Time
+	electionMu        sync.Mutex
+	term              uint64
+	votedTerm         uint64
+	votedFor          string
+	master            string
+	masterCancel      context.CancelFunc
+	replicaCancel     context.CancelFunc
+	takeMasterMu      sync.Mutex
+	promotedRevision  uint64
 }
 
 // NewServer returns a new Heimdall server
@@ -79,31 +108,58 @@ func NewServer(cfg *heimdall.Config) (*Server, error) {
 	if err := os.MkdirAll(cfg.DataDir, 0750); err != nil {
 		return nil, err
 	}
-	ctx := context.Background()
-	// start embedded managed redis server
-	logrus.Debugf("starting redis on %d", cfg.RedisPort)
-	redisCmd, err := startRedis(ctx, &redisConfig{
-		DataDir:    cfg.DataDir,
-		ListenAddr: "127.0.0.1",
-		Port:       cfg.RedisPort,
-	})
-	if err != nil {
-		return nil, err
+	s := &Server{
+		cfg:           cfg,
+		nodeInterface: cfg.NodeInterface,
 	}
-	redisURL := fmt.Sprintf("redis://127.0.0.1:%d", cfg.RedisPort)
-	pool, err := getPool(redisURL)
-	if err != nil {
+
+	switch cfg.StoreBackend {
+	case "", StoreBackendRedis:
+		ctx := context.Background()
+		// start embedded managed redis server
+		logrus.Debugf("starting redis on %d", cfg.RedisPort)
+		redisCmd, err := startRedis(ctx, &redisConfig{
+			DataDir:    cfg.DataDir,
+			ListenAddr: "127.0.0.1",
+			Port:       cfg.RedisPort,
+		})
+		if err != nil {
+			return nil, err
+		}
+		redisURL := fmt.Sprintf("redis://127.0.0.1:%d", cfg.RedisPort)
+		pool, err := store.NewPool(redisURL)
+		if err != nil {
+			return nil, err
+		}
+		s.redisCmd = redisCmd
+		s.redisURL = redisURL
+		s.redis = store.NewRedis(pool, pool)
+		s.store = s.redis
+	case StoreBackendEmbedded:
+		// the embedded store does not replicate so it is limited to a single node
+		if cfg.GRPCPeerAddress != "" {
+			return nil, ErrClusteringUnsupported
+		}
+		st, err := store.NewEmbedded(filepath.Join(cfg.DataDir, embeddedStateName))
+		if err != nil {
+			return nil, err
+		}
+		s.store = st
+	default:
+		return nil, fmt.Errorf("unknown store backend %q", cfg.StoreBackend)
+	}
+
+	// validate the node subnet sizes and peer network exclusions
+	for _, nodeNetwork := range s.nodeNetworks() {
+		if _, err := s.nodeSubnetSize(nodeNetwork); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := s.newPeerPools(); err != nil {
 		return nil, err
 	}
-	return &Server{
-		cfg:           cfg,
-		redisCmd:      redisCmd,
-		redisURL:      redisURL,
-		rpool:         pool,
-		wpool:         pool,
-		replicaCh:     make(chan struct{}, 1),
-		nodeInterface: cfg.NodeInterface,
-	}, nil
+
+	return s, nil
 }
 
 // Register enables callers to register this service with an existing GRPC server
@@ -128,6 +184,17 @@ func (s *Server) GenerateProfile() (string, error) {
 
 func (s *Server) Run() error {
 	ctx := context.Background()
+	// load local keypair; the private key is never sent to the cluster
+	if err := s.ensureKeyPair(ctx); err != nil {
+		return err
+	}
+	s.wgDriver = wg.NewDriver()
+	fwDriver, err := firewall.NewDriver(s.cfg.FirewallDriver)
+	if err != nil {
+		return err
+	}
+	s.fwDriver = fwDriver
+
 	// check peer address and make a grpc request for master info if present
 	masterRedisURL := ""
 	if s.cfg.GRPCPeerAddress != "" {
@@ -138,15 +205,7 @@ func (s *Server) Run() error {
 		}
 		defer c.Close()
 
-		r, err := c.Join(ctx, &v1.JoinRequest{
-			ID:            s.cfg.ID,
-			Name:          s.cfg.Name,
-			ClusterKey:    s.cfg.ClusterKey,
-			GRPCAddress:   s.cfg.GRPCAddress,
-			EndpointIP:    s.cfg.EndpointIP,
-			EndpointPort:  uint64(s.cfg.EndpointPort),
-			InterfaceName: s.cfg.InterfaceName,
-		})
+		r, err := c.Join(ctx, s.joinRequest())
 		if err != nil {
 			return err
 		}
@@ -175,15 +234,16 @@ func (s *Server) Run() error {
 
 		masterRedisURL = r.Master.RedisURL
 
-		go s.replicaMonitor()
+		s.startReplicaMonitor()
 	} else {
 		if err := s.configureNode(); err != nil {
 			return err
 		}
 	}
 
-	// ensure keypair
-	if _, err := s.getOrCreateKeyPair(ctx, s.cfg.ID); err != nil {
+	// the node subnet size of the cluster is stored by the first node and
+	// the local config must match it like the config of any joining node
+	if err := s.checkNodeSubnetSize(ctx, s.joinRequest()); err != nil {
 		return err
 	}
 
@@ -201,7 +261,7 @@ func (s *Server) Run() error {
 	go s.updateNodeInfo(ctx)
 
 	// initial peer info update
-	if err := s.updatePeerInfo(ctx, s.cfg.ID, s.cfg.Name); err != nil {
+	if err := s.updatePeerInfo(ctx, &v1.Peer{ID: s.cfg.ID, Name: s.cfg.Name, PublicKey: s.publicKey}); err != nil {
 		return err
 	}
 
@@ -219,40 +279,37 @@ func (s *Server) Run() error {
 		return err
 	}
 
-	// reconfigure redis to listen on gateway ip
-	nodeIP, _, err := s.getNodeIP(ctx, s.cfg.ID)
-	if err != nil {
-		return err
-	}
-	// if no master was joined, configure local redis as master
-	if masterRedisURL == "" {
-		masterRedisURL = fmt.Sprintf("redis://%s:%d", nodeIP.String(), s.cfg.RedisPort)
-	}
-	if err := s.reconfigureRedis(ctx, nodeIP.String(), masterRedisURL); err != nil {
-		return err
+	if s.redis != nil {
+		// reconfigure redis to listen on gateway ip
+		nodeIP, _, err := s.getNodeIP(ctx, s.cfg.ID)
+		if err != nil {
+			return err
+		}
+		// if no master was joined, configure local redis as master
+		if masterRedisURL == "" {
+			masterRedisURL = fmt.Sprintf("redis://%s", net.JoinHostPort(nodeIP.String(), strconv.Itoa(s.cfg.RedisPort)))
+		}
+		if err := s.reconfigureRedis(ctx, nodeIP.String(), masterRedisURL); err != nil {
+			return err
+		}
 	}
 
 	// start peer config updater to configure wireguard as peers join
 	go s.peerUpdater(ctx)
 
-	// start listener for pub/sub
+	// repair drift of the node forwarding rules
+	go s.firewallReconciler(ctx)
+
+	// start listener for cluster events
 	errCh := make(chan error, 1)
+	events, err := s.store.Subscribe(ctx, store.EventUpdateTunnel, store.EventWatch)
+	if err != nil {
+		return err
+	}
 	go func() {
-		c := s.rpool.Get()
-		defer c.Close()
-
-		psc := redis.PubSubConn{Conn: c}
-		psc.Subscribe(nodeEventJoinKey)
-		psc.Subscribe(nodeEventRestartTunnelKey)
-		for {
-			switch v := psc.Receive().(type) {
-			case redis.Message:
-				if err := s.eventHandler(ctx, v); err != nil {
-					logrus.WithError(err).Error("error handling event")
-				}
-			case redis.Subscription:
-			default:
-				logrus.Debugf("unknown message type %T: %s", v, v)
+		for ev := range events {
+			if err := s.eventHandler(ctx, ev); err != nil {
+				logrus.WithError(err).Error("error handling event")
 			}
 		}
 	}()
@@ -273,8 +330,13 @@ func (s *Server) Run() error {
 }
 
 func (s *Server) Stop() error {
+	if s.wgDriver != nil {
+		if err := s.wgDriver.Close(); err != nil {
+			logrus.WithError(err).Error("error closing wireguard driver")
+		}
+	}
 	if s.redisCmd != nil {
-		if _, err := s.local(context.Background(), "SHUTDOWN"); err != nil {
+		if _, err := s.redis.Local(context.Background(), "SHUTDOWN"); err != nil {
 			if err != io.EOF {
 				logrus.WithError(err).Error("error shutting down redis")
 			}
@@ -283,32 +345,16 @@ func (s *Server) Stop() error {
 	return nil
 }
 
-func getPool(redisURL string) (*redis.Pool, error) {
-	pool := redis.NewPool(func() (redis.Conn, error) {
-		conn, err := redis.DialURL(redisURL)
-		if err != nil {
-			return nil, errors.Wrap(err, "unable to connect to redis")
-		}
-
-		u, err := url.Parse(redisURL)
-		if err != nil {
-			return nil, err
-		}
-
-		auth, ok := u.User.Password()
-		if ok {
-			if _, err := conn.Do("CONFIG", "SET", "MASTERAUTH", auth); err != nil {
-				return nil, errors.Wrap(err, "error authenticating to redis")
-			}
-		}
-		return conn, nil
-	}, 10)
-
-	return pool, nil
-}
-
 func (s *Server) waitForMaster(ctx context.Context, m *v1.Master) error {
-	p, err := ping.New("0.0.0.0", "")
+	ip, err := net.ResolveIPAddr("ip", m.GatewayIP)
+	if err != nil {
+		return err
+	}
+	bind4, bind6 := "0.0.0.0", ""
+	if ip.IP.To4() == nil {
+		bind4, bind6 = "", "::"
+	}
+	p, err := ping.New(bind4, bind6)
 	if err != nil {
 		return err
 	}
@@ -319,11 +365,6 @@ func (s *Server) waitForMaster(ctx context.Context, m *v1.Master) error {
 
 	go func() {
 		for {
-			ip, err := net.ResolveIPAddr("ip4", m.GatewayIP)
-			if err != nil {
-				errCh <- err
-				return
-			}
 			rtt, err := p.Ping(ip, time.Second*30)
 			if err != nil {
 				errCh <- err
@@ -339,7 +380,6 @@ func (s *Server) waitForMaster(ctx context.Context, m *v1.Master) error {
 	case err := <-errCh:
 		return err
 	}
-	return nil
 }
 
 func (s *Server) waitForRedisSync(ctx context.Context) error {
@@ -348,24 +388,15 @@ func (s *Server) waitForRedisSync(ctx context.Context) error {
 
 	go func() {
 		for {
-			info, err := redis.String(s.local(ctx, "INFO", "REPLICATION"))
+			info, err := s.replicationInfo(ctx)
 			if err != nil {
 				logrus.Warn(err)
+				time.Sleep(time.Second * 1)
 				continue
 			}
-
-			b := bytes.NewBufferString(info)
-			s := bufio.NewScanner(b)
-
-			for s.Scan() {
-				v := s.Text()
-				parts := strings.SplitN(v, ":", 2)
-				if parts[0] == "master_link_status" {
-					if parts[1] == "up" {
-						doneCh <- true
-						return
-					}
-				}
+			if info["master_link_status"] == "up" {
+				doneCh <- true
+				return
 			}
 			time.Sleep(time.Second * 1)
 		}
@@ -382,106 +413,91 @@ func (s *Server) waitForRedisSync(ctx context.Context) error {
 }
 
 func (s *Server) ensureNetworkSubnet(ctx context.Context, id string) error {
-	network, err := redis.String(s.local(ctx, "GET", s.getNodeNetworkKey(id)))
-	if err != nil {
-		if err != redis.ErrNil {
+	for _, nodeNetwork := range s.nodeNetworks() {
+		if err := s.ensureNodeNetwork(ctx, id, nodeNetwork); err != nil {
 			return err
 		}
-		// allocate initial node subnet
-		r, err := parseSubnetRange(s.cfg.NodeNetwork)
-		if err != nil {
-			return err
-		}
-		// iterate node networks to find first free
-		nodeNetworkKeys, err := redis.Strings(s.local(ctx, "KEYS", s.getNodeNetworkKey("*")))
-		if err != nil {
-			return err
+	}
+	return nil
+}
+
+// ensureNodeNetwork allocates a subnet of the node network to the node if the
+// node does not have one in the address family of the node network
+func (s *Server) ensureNodeNetwork(ctx context.Context, id, nodeNetwork string) error {
+	r, err := parseSubnetRange(nodeNetwork)
+	if err != nil {
+		return err
+	}
+	networks, err := s.store.GetNodeNetwork(ctx, id)
+	if err != nil && err != store.ErrNotFound {
+		return err
+	}
+	for _, network := range networks {
+		if store.IsIPv6(network) == store.IsIPv6(nodeNetwork) {
+			logrus.Debugf("node network for %s: %s", id, network)
+			return nil
 		}
-		lookup := map[string]struct{}{}
-		for _, netKey := range nodeNetworkKeys {
-			n, err := redis.String(s.local(ctx, "GET", netKey))
-			if err != nil {
-				return err
-			}
+	}
+	// iterate node networks to find first free
+	nodeNetworks, err := s.store.GetNodeNetworks(ctx)
+	if err != nil {
+		return err
+	}
+	lookup := map[string]struct{}{}
+	for _, networks := range nodeNetworks {
+		for _, n := range networks {
 			lookup[n] = struct{}{}
 		}
+	}
 
-		subnet := r.Subnet
-		size, _ := subnet.Mask.Size()
+	size, err := s.nodeSubnetSize(nodeNetwork)
+	if err != nil {
+		return err
+	}
+	prefix, bits := r.Subnet.Mask.Size()
+	// node subnets the size of the node network are allocated after it.
+	// smaller node subnets are carved out of the node network.
+	within := size > prefix
+	n := &net.IPNet{IP: r.Subnet.IP, Mask: net.CIDRMask(size, bits)}
+	if !within {
+		next, ok := nextSubnet(r.Subnet, size)
+		if !ok {
+			return fmt.Errorf("error getting next subnet")
+		}
+		n = next
+	}
 
-		for {
-			n, ok := nextSubnet(subnet, size)
-			if !ok {
-				return fmt.Errorf("error getting next subnet")
-			}
-			if _, exists := lookup[n.String()]; exists {
-				subnet = n
-				continue
+	for {
+		if within && !r.Subnet.Contains(n.IP) {
+			return fmt.Errorf("no available node subnets in %s", nodeNetwork)
+		}
+		if _, exists := lookup[n.String()]; !exists {
+			// reserve on the master as the local lookup may be stale
+			network, err := s.reserveNodeNetwork(ctx, id, n.String())
+			if err == nil {
+				logrus.Debugf("allocated network %s for %s", network, id)
+				return nil
 			}
-			logrus.Debugf("allocated network %s for %s", n.String(), id)
-			if err := s.updateNodeNetwork(ctx, id, n.String()); err != nil {
+			if err != store.ErrExists {
 				return err
 			}
-			break
 		}
-
-		return nil
+		next, ok := nextSubnet(n, size)
+		if !ok {
+			return fmt.Errorf("error getting next subnet")
+		}
+		n = next
 	}
-	logrus.Debugf("node network for %s: %s", id, network)
-	return nil
 }
 
-func (s *Server) getOrCreateKeyPair(ctx context.Context, id string) (*v1.KeyPair, error) {
-	key := s.getKeyPairKey(id)
-	keyData, err := redis.Bytes(s.master(ctx, "GET", key))
+func (s *Server) ensureKeyPair(ctx context.Context) error {
+	privateKey, publicKey, err := wg.LoadOrGenerateKeys(ctx, filepath.Join(s.cfg.DataDir, wireguardKeyName))
 	if err != nil {
-		if err != redis.ErrNil {
-			return nil, err
-		}
-		logrus.Debugf("generating new keypair for %s", s.cfg.ID)
-		privateKey, publicKey, err := wg.GenerateWireguardKeys(ctx)
-		if err != nil {
-			return nil, err
-		}
-		keyPair := &v1.KeyPair{
-			PrivateKey: privateKey,
-			PublicKey:  publicKey,
-		}
-		data, err := proto.Marshal(keyPair)
-		if err != nil {
-			return nil, err
-		}
-		if _, err := s.master(ctx, "SET", key, data); err != nil {
-			return nil, err
-		}
-		return keyPair, nil
+		return errors.Wrap(err, "error loading wireguard keypair")
 	}
-
-	var keyPair v1.KeyPair
-	if err := proto.Unmarshal(keyData, &keyPair); err != nil {
-		return nil, err
-	}
-	return &keyPair, nil
-}
-
-func (s *Server) getNodeKey(id string) string {
-	return fmt.Sprintf("%s:%s", nodesKey, id)
-}
-
-func (s *Server) getRouteKey(network string) string {
-	return fmt.Sprintf("%s:%s", routesKey, network)
-}
-
-func (s *Server) getPeerKey(id string) string {
-	return fmt.Sprintf("%s:%s", peersKey, id)
-}
-
-func (s *Server) getKeyPairKey(id string) string {
-	return fmt.Sprintf("%s:%s", keypairsKey, id)
-}
-
-func (s *Server) getNodeNetworkKey(id string) string {
-	return fmt.Sprintf("%s:%s", nodeNetworksKey, id)
+	s.privateKey = privateKey
+	s.publicKey = publicKey
+	return nil
 }
 
 func (s *Server) getClient(addr string) (*client.Client, error) {
@@ -504,7 +520,7 @@ func (s *Server) getClient(addr string) (*client.Client, error) {
 }
 
 func (s *Server) getClusterKey(ctx context.Context) (string, error) {
-	return redis.String(s.local(ctx, "GET", clusterKey))
+	return s.store.GetClusterKey(ctx)
 }
 
 func (s *Server) getWireguardConfigPath() string {
@@ -515,30 +531,12 @@ func (s *Server) getTunnelName() string {
 	return s.cfg.InterfaceName
 }
 
-func (s *Server) local(ctx context.Context, cmd string, args ...interface{}) (interface{}, error) {
-	return s.do(ctx, s.rpool, cmd, args...)
-}
-
-func (s *Server) master(ctx context.Context, cmd string, args ...interface{}) (interface{}, error) {
-	return s.do(ctx, s.wpool, cmd, args...)
-}
-
-func (s *Server) do(ctx context.Context, pool *redis.Pool, cmd string, args ...interface{}) (interface{}, error) {
-	conn, err := pool.GetContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer conn.Close()
-	r, err := conn.Do(cmd, args...)
-	return r, err
-}
-
 func (s *Server) reconfigureRedis(ctx context.Context, localIP string, masterRedisURL string) error {
 	logrus.Debugf("reconfiguring local redis: local=%s master=%s", localIP, masterRedisURL)
 	// TODO: mutex lock for server
 	if s.redisCmd != nil {
 		logrus.Debug("shutting down existing redis...")
-		pool, err := getPool(s.redisURL)
+		pool, err := store.NewPool(s.redisURL)
 		if err != nil {
 			return err
 		}
@@ -588,17 +586,17 @@ func (s *Server) reconfigureRedis(ctx context.Context, localIP string, masterRed
 	s.redisCmd = redisCmd
 
 	localRedisURL := fmt.Sprintf("redis://%s:%d", localIP, s.cfg.RedisPort)
-	pool, err := getPool(localRedisURL)
+	pool, err := store.NewPool(localRedisURL)
 	if err != nil {
 		return err
 	}
-	s.rpool = pool
+	s.redis.SetLocalPool(pool)
 
-	wpool, err := getPool(masterRedisURL)
+	wpool, err := store.NewPool(masterRedisURL)
 	if err != nil {
 		return err
 	}
-	s.wpool = wpool
+	s.redis.SetMasterPool(wpool)
 
 	return nil
 }
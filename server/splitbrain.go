@@ -0,0 +1,543 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/ehazlett/heimdall/store"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	conflictPeerIP      = "peer_ip"
+	conflictNodeNetwork = "node_network"
+	conflictRoute       = "route"
+	conflictPeer        = "peer"
+	conflictPolicy      = "policy"
+	conflictAuthorized  = "authorized"
+	conflictNodeRemoved = "node_removed"
+)
+
+var (
+	// splitBrainCheckInterval is how often the master compares its role with the other nodes
+	splitBrainCheckInterval = masterHeartbeatInterval * 2
+)
+
+// clusterState is a copy of the state that can diverge between masters
+type clusterState struct {
+	peerIPs      map[string][]string
+	nodeNetworks map[string][]string
+	routes       []*v1.Route
+	policies     []*v1.Policy
+	peers        []*v1.Peer
+	authorized   []string
+	tags         map[string][]string
+	// removed are the nodes removed after the split
+	removed []string
+	// writes are the ids of the watch events recorded after the split
+	writes map[v1.WatchEvent_Type]map[string]struct{}
+}
+
+// written returns true if the id was written with any of the event types
+// after the split
+func (c *clusterState) written(id string, types ...v1.WatchEvent_Type) bool {
+	for _, t := range types {
+		if _, ok := c.writes[t][id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Master returns the master known to the node
+func (s *Server) Master(ctx context.Context, req *v1.MasterRequest) (*v1.MasterResponse, error) {
+	m, err := s.store.GetMaster(ctx)
+	if err != nil {
+		if err == store.ErrNotFound {
+			return &v1.MasterResponse{}, nil
+		}
+		return nil, err
+	}
+	return &v1.MasterResponse{
+		Master: m,
+	}, nil
+}
+
+// ReconcileReports returns the reports of previous split brain reconciliations
+func (s *Server) ReconcileReports(ctx context.Context, req *v1.ReconcileReportsRequest) (*v1.ReconcileReportsResponse, error) {
+	reports, err := s.store.GetReconcileReports(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.ReconcileReportsResponse{
+		Reports: reports,
+	}, nil
+}
+
+// splitBrainMonitor periodically checks that no other node is also acting as
+// master and demotes the local node if it loses
+func (s *Server) splitBrainMonitor(ctx context.Context) {
+	logrus.Debugf("starting split brain monitor: interval=%s", splitBrainCheckInterval)
+	t := time.NewTicker(splitBrainCheckInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logrus.Debug("stopping split brain monitor")
+			return
+		case <-t.C:
+		}
+
+		winner, err := s.checkSplitBrain(ctx)
+		if err != nil {
+			logrus.WithError(err).Warn("error checking for split brain")
+			continue
+		}
+		if winner == nil {
+			continue
+		}
+		if err := s.demote(winner); err != nil {
+			logrus.WithError(err).Error("error demoting master")
+			continue
+		}
+		return
+	}
+}
+
+// checkSplitBrain returns the competing master if another node is acting as
+// master with precedence over the local node
+func (s *Server) checkSplitBrain(ctx context.Context) (*v1.Master, error) {
+	nodes, err := s.getNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	term := s.currentTerm()
+	checked := map[string]struct{}{}
+	for _, n := range nodes {
+		if n.ID == s.cfg.ID {
+			continue
+		}
+		m, err := s.remoteMaster(ctx, n.Addr)
+		if err != nil {
+			logrus.Debugf("unable to get master from %s: %s", n.ID, err)
+			continue
+		}
+		if m == nil || m.ID == s.cfg.ID {
+			continue
+		}
+		if _, ok := checked[m.ID]; ok {
+			continue
+		}
+		checked[m.ID] = struct{}{}
+
+		// confirm the reported master still considers itself master
+		c, err := s.remoteMaster(ctx, m.GRPCAddress)
+		if err != nil || c == nil || c.ID != m.ID {
+			continue
+		}
+		logrus.Warnf("split brain detected: %s is master term=%d; local term=%d", c.ID, c.Term, term)
+		if !masterPrecedes(c, s.cfg.ID, term) {
+			logrus.Warnf("local master has precedence over %s", c.ID)
+			continue
+		}
+		return c, nil
+	}
+	return nil, nil
+}
+
+func (s *Server) remoteMaster(ctx context.Context, addr string) (*v1.Master, error) {
+	c, err := s.getClient(addr)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, voteRequestTimeout)
+	defer cancel()
+	resp, err := c.Master(ctx, &v1.MasterRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Master, nil
+}
+
+// masterPrecedes returns true if the master wins over the local master.  The
+// higher term wins and ties are broken by the lowest id.
+func masterPrecedes(m *v1.Master, id string, term uint64) bool {
+	if m.Term != term {
+		return m.Term > term
+	}
+	return m.ID < id
+}
+
+// demote converts the local master to a replica of the winning master and
+// reconciles the state written to the local store during the partition
+func (s *Server) demote(winner *v1.Master) error {
+	ctx := context.Background()
+	logrus.Warnf("demoting master %s term=%d in favor of %s term=%d", s.cfg.ID, s.currentTerm(), winner.ID, winner.Term)
+
+	local, err := s.snapshotState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "error reading local state")
+	}
+	// the split is the later of the promotions of the two masters as each
+	// replicated the other until it was promoted
+	split := s.currentPromotedRevision()
+	if winner.Revision > split {
+		split = winner.Revision
+	}
+	if local.writes, err = s.splitWrites(ctx, split); err != nil {
+		return errors.Wrap(err, "error reading local writes")
+	}
+	for id := range local.writes[v1.WatchEvent_NODE_LEFT] {
+		removed, err := s.store.IsNodeRemoved(ctx, id)
+		if err != nil {
+			return errors.Wrap(err, "error reading removed nodes")
+		}
+		if removed {
+			local.removed = append(local.removed, id)
+		}
+	}
+	demotedTerm := s.currentTerm()
+
+	s.stopMaster()
+	if err := s.joinMaster(winner); err != nil {
+		return err
+	}
+	logrus.Infof("waiting for redis sync with %s", winner.ID)
+	if err := s.waitForRedisSync(ctx); err != nil {
+		return err
+	}
+	s.startReplicaMonitor()
+
+	report, err := s.reconcile(ctx, local, winner.Revision)
+	if err != nil {
+		return errors.Wrap(err, "error reconciling state")
+	}
+	report.MasterID = winner.ID
+	report.MasterTerm = winner.Term
+	report.DemotedID = s.cfg.ID
+	report.DemotedTerm = demotedTerm
+
+	logrus.Infof("reconciled state with %s: restored=%d deleted=%d conflicts=%d", winner.ID, report.Restored, report.Deleted, len(report.Conflicts))
+	for _, c := range report.Conflicts {
+		logrus.Warnf("conflict %s %s: local=%s master=%s: %s", c.Kind, c.Key, c.Local, c.Master, c.Resolution)
+	}
+	if err := s.store.SaveReconcileReport(ctx, report); err != nil {
+		return err
+	}
+
+	// notify nodes to update tunnels
+	return s.store.Publish(ctx, store.EventUpdateTunnel)
+}
+
+func (s *Server) snapshotState(ctx context.Context) (*clusterState, error) {
+	peerIPs, err := s.store.GetPeerIPs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodeNetworks, err := s.store.GetNodeNetworks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	routes, err := s.store.GetRoutes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := s.store.GetPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	peers, err := s.store.GetPeers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	authorized, err := s.store.AuthorizedPeers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.store.GetPeerTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &clusterState{
+		peerIPs:      peerIPs,
+		nodeNetworks: nodeNetworks,
+		routes:       routes,
+		policies:     policies,
+		peers:        peers,
+		authorized:   authorized,
+		tags:         tags,
+	}, nil
+}
+
+// splitWrites returns the ids of the watch events recorded after the revision
+func (s *Server) splitWrites(ctx context.Context, revision uint64) (map[v1.WatchEvent_Type]map[string]struct{}, error) {
+	writes := map[v1.WatchEvent_Type]map[string]struct{}{}
+	events, err := s.store.WatchEvents(ctx, revision)
+	if err != nil {
+		if err == store.ErrCompacted {
+			logrus.Warnf("writes after revision %d have been compacted; local state will not be restored", revision)
+			return writes, nil
+		}
+		return nil, err
+	}
+	for _, ev := range events {
+		if _, ok := writes[ev.Type]; !ok {
+			writes[ev.Type] = map[string]struct{}{}
+		}
+		writes[ev.Type][ev.ID] = struct{}{}
+	}
+	return writes, nil
+}
+
+// reconcile restores state written to the local store after the split that
+// is missing from the master, applies the deletes made on the local store
+// after the split and reports entries that conflict with the master.  The
+// master always wins conflicts, entries the master removed before the split
+// are not restored and deletes are not applied to entries the master wrote
+// after its promotion at the revision.
+func (s *Server) reconcile(ctx context.Context, local *clusterState, revision uint64) (*v1.ReconcileReport, error) {
+	master, err := s.snapshotState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if master.writes, err = s.splitWrites(ctx, revision); err != nil {
+		return nil, err
+	}
+	report := &v1.ReconcileReport{
+		Created: time.Now(),
+	}
+	conflict := func(kind, key, local, master, resolution string) {
+		report.Conflicts = append(report.Conflicts, &v1.ReconcileConflict{
+			Kind:       kind,
+			Key:        key,
+			Local:      local,
+			Master:     master,
+			Resolution: resolution,
+		})
+	}
+
+	for id, ips := range local.peerIPs {
+		if !local.written(id, v1.WatchEvent_PEER_ADDED, v1.WatchEvent_PEER_UPDATED) {
+			continue
+		}
+		for _, ip := range ips {
+			if existing, ok := familyAllocation(master.peerIPs[id], ip); ok {
+				if existing != ip {
+					conflict(conflictPeerIP, id, ip, existing, "kept master allocation")
+				}
+				continue
+			}
+			if _, err := s.store.ReservePeerIP(ctx, id, ip); err != nil {
+				if err != store.ErrExists {
+					return nil, err
+				}
+				conflict(conflictPeerIP, id, ip, "", "ip allocated to another peer; peer will be allocated a new ip on connect")
+				continue
+			}
+			report.Restored++
+		}
+	}
+
+	for id, networks := range local.nodeNetworks {
+		if !local.written(id, v1.WatchEvent_NODE_JOINED) {
+			continue
+		}
+		for _, network := range networks {
+			if existing, ok := familyAllocation(master.nodeNetworks[id], network); ok {
+				if existing != network {
+					conflict(conflictNodeNetwork, id, network, existing, "kept master allocation")
+				}
+				continue
+			}
+			if _, err := s.store.ReserveNodeNetwork(ctx, id, network); err != nil {
+				if err != store.ErrExists {
+					return nil, err
+				}
+				conflict(conflictNodeNetwork, id, network, "", "network allocated to another node; node must rejoin")
+				continue
+			}
+			report.Restored++
+		}
+	}
+
+	masterRoutes := map[string]*v1.Route{}
+	for _, r := range master.routes {
+		masterRoutes[r.Network] = r
+	}
+	for _, r := range local.routes {
+		if !local.written(r.Network, v1.WatchEvent_ROUTE_CREATED, v1.WatchEvent_ROUTE_UPDATED) {
+			continue
+		}
+		if existing, ok := masterRoutes[r.Network]; ok {
+			if !proto.Equal(existing, r) {
+				conflict(conflictRoute, r.Network, r.String(), existing.String(), "kept master route")
+			}
+			continue
+		}
+		if err := s.store.SaveRoute(ctx, r); err != nil {
+			return nil, err
+		}
+		report.Restored++
+	}
+	localRoutes := map[string]struct{}{}
+	for _, r := range local.routes {
+		localRoutes[r.Network] = struct{}{}
+	}
+	for network := range local.writes[v1.WatchEvent_ROUTE_DELETED] {
+		existing, ok := masterRoutes[network]
+		if _, exists := localRoutes[network]; exists || !ok {
+			continue
+		}
+		if master.written(network, v1.WatchEvent_ROUTE_CREATED, v1.WatchEvent_ROUTE_UPDATED) {
+			conflict(conflictRoute, network, "deleted", existing.String(), "kept master route; delete the route on the master if it is no longer needed")
+			continue
+		}
+		if err := s.store.DeleteRoute(ctx, network); err != nil {
+			return nil, err
+		}
+		report.Deleted++
+	}
+
+	masterPolicies := map[string]*v1.Policy{}
+	for _, p := range master.policies {
+		masterPolicies[p.ID] = p
+	}
+	for _, p := range local.policies {
+		if !local.written(p.ID, v1.WatchEvent_POLICY_CREATED, v1.WatchEvent_POLICY_UPDATED) {
+			continue
+		}
+		if existing, ok := masterPolicies[p.ID]; ok {
+			if !proto.Equal(existing, p) {
+				conflict(conflictPolicy, p.ID, p.String(), existing.String(), "kept master policy")
+			}
+			continue
+		}
+		if err := s.store.SavePolicy(ctx, p); err != nil {
+			return nil, err
+		}
+		report.Restored++
+	}
+	localPolicies := map[string]struct{}{}
+	for _, p := range local.policies {
+		localPolicies[p.ID] = struct{}{}
+	}
+	for id := range local.writes[v1.WatchEvent_POLICY_DELETED] {
+		existing, ok := masterPolicies[id]
+		if _, exists := localPolicies[id]; exists || !ok {
+			continue
+		}
+		if master.written(id, v1.WatchEvent_POLICY_CREATED, v1.WatchEvent_POLICY_UPDATED) {
+			conflict(conflictPolicy, id, "deleted", existing.String(), "kept master policy; delete the policy on the master if it is no longer needed")
+			continue
+		}
+		if err := s.store.DeletePolicy(ctx, id); err != nil {
+			return nil, err
+		}
+		report.Deleted++
+	}
+
+	masterPeers := map[string]*v1.Peer{}
+	for _, p := range master.peers {
+		masterPeers[p.ID] = p
+	}
+	for _, p := range local.peers {
+		if !local.written(p.ID, v1.WatchEvent_PEER_ADDED, v1.WatchEvent_PEER_UPDATED) {
+			continue
+		}
+		if existing, ok := masterPeers[p.ID]; ok {
+			if existing.PublicKey != p.PublicKey {
+				conflict(conflictPeer, p.ID, p.PublicKey, existing.PublicKey, "kept master public key")
+			}
+			continue
+		}
+		if err := s.store.SavePeer(ctx, p); err != nil {
+			return nil, err
+		}
+		report.Restored++
+	}
+
+	// authorizations are only restored and deauthorizations only applied if
+	// the master did not change the authorization of the peer after the split
+	authorized := map[string]struct{}{}
+	for _, id := range master.authorized {
+		authorized[id] = struct{}{}
+	}
+	localAuthorized := map[string]struct{}{}
+	for _, id := range local.authorized {
+		localAuthorized[id] = struct{}{}
+		if !local.written(id, v1.WatchEvent_PEER_AUTHORIZED) {
+			continue
+		}
+		if _, ok := authorized[id]; ok {
+			continue
+		}
+		if master.written(id, v1.WatchEvent_PEER_DEAUTHORIZED) {
+			conflict(conflictAuthorized, id, "authorized", "deauthorized", "kept master deauthorization; peer must be authorized on the master")
+			continue
+		}
+		if err := s.store.AuthorizePeer(ctx, id); err != nil {
+			return nil, err
+		}
+		report.Restored++
+	}
+	for id := range local.writes[v1.WatchEvent_PEER_DEAUTHORIZED] {
+		if _, ok := localAuthorized[id]; ok {
+			continue
+		}
+		if _, ok := authorized[id]; !ok {
+			continue
+		}
+		if master.written(id, v1.WatchEvent_PEER_AUTHORIZED) {
+			conflict(conflictAuthorized, id, "deauthorized", "authorized", "kept master authorization; peer must be deauthorized on the master")
+			continue
+		}
+		if err := s.store.DeauthorizePeer(ctx, id); err != nil {
+			return nil, err
+		}
+		report.Deleted++
+	}
+
+	// node removals are reported as removing a node also reassigns its
+	// routes which the operator has to choose on the master
+	for _, id := range local.removed {
+		removed, err := s.store.IsNodeRemoved(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if removed {
+			continue
+		}
+		conflict(conflictNodeRemoved, id, "removed", "", "node must be removed on the master")
+	}
+
+	// tags are only restored for peers without tags on the master
+	for id, tags := range local.tags {
+		if !local.written(id, v1.WatchEvent_PEER_TAGGED) {
+			continue
+		}
+		if _, ok := master.tags[id]; ok {
+			continue
+		}
+		if err := s.store.SetPeerTags(ctx, id, tags); err != nil {
+			return nil, err
+		}
+		report.Restored++
+	}
+
+	return report, nil
+}
+
+// familyAllocation returns the allocation in the address family of addr
+func familyAllocation(allocations []string, addr string) (string, bool) {
+	for _, a := range allocations {
+		if store.IsIPv6(a) == store.IsIPv6(addr) {
+			return a, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,289 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ehazlett/heimdall"
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/ehazlett/heimdall/store"
+)
+
+func TestMasterPrecedes(t *testing.T) {
+	if !masterPrecedes(&v1.Master{ID: "b", Term: 3}, "a", 2) {
+		t.Error("expected higher term to win")
+	}
+	if masterPrecedes(&v1.Master{ID: "a", Term: 1}, "b", 2) {
+		t.Error("expected lower term to lose")
+	}
+	if !masterPrecedes(&v1.Master{ID: "a", Term: 2}, "b", 2) {
+		t.Error("expected lowest id to win a tie")
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	s, err := NewServer(&heimdall.Config{
+		ID:           "test",
+		NodeNetwork:  testNodeNetwork,
+		PeerNetwork:  testPeerNetwork,
+		DataDir:      tmpDir,
+		StoreBackend: StoreBackendEmbedded,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	// master state
+	if _, err := s.store.ReservePeerIP(ctx, "peer-a", "10.51.0.2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.store.ReservePeerIP(ctx, "peer-b", "10.51.0.3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.store.SaveRoute(ctx, &v1.Route{NodeID: "node-a", Network: "10.100.0.0/24"}); err != nil {
+		t.Fatal(err)
+	}
+	revision, err := s.store.Revision(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// state of the demoted master; only the writes after the split are
+	// restored and peer-e was deauthorized on the master before the split
+	local := &clusterState{
+		peerIPs: map[string][]string{
+			"peer-a": {"10.51.0.2"},
+			"peer-c": {"10.51.0.3"},
+			"peer-d": {"10.51.0.4"},
+		},
+		routes: []*v1.Route{
+			{NodeID: "node-b", Network: "10.100.0.0/24"},
+			{NodeID: "node-b", Network: "10.200.0.0/24"},
+			{NodeID: "node-b", Network: "10.150.0.0/24"},
+		},
+		policies: []*v1.Policy{
+			{ID: "web", PeerID: "peer-d", Destination: "10.100.0.0/24"},
+		},
+		authorized: []string{"peer-d", "peer-e"},
+		writes: map[v1.WatchEvent_Type]map[string]struct{}{
+			v1.WatchEvent_PEER_ADDED: {
+				"peer-a": {},
+				"peer-c": {},
+				"peer-d": {},
+			},
+			v1.WatchEvent_ROUTE_CREATED: {
+				"10.100.0.0/24": {},
+				"10.200.0.0/24": {},
+			},
+			v1.WatchEvent_POLICY_CREATED: {
+				"web": {},
+			},
+			v1.WatchEvent_PEER_AUTHORIZED: {
+				"peer-d": {},
+			},
+		},
+	}
+
+	report, err := s.reconcile(ctx, local, revision)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// peer-d ip, 10.200.0.0/24 route, web policy and peer-d authorization
+	if report.Restored != 4 {
+		t.Errorf("expected 4 restored; received %d", report.Restored)
+	}
+	// peer-c ip and 10.100.0.0/24 route
+	if len(report.Conflicts) != 2 {
+		t.Fatalf("expected 2 conflicts; received %+v", report.Conflicts)
+	}
+
+	ips, err := s.store.GetPeerIPs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ips["peer-d"]) != 1 || ips["peer-d"][0] != "10.51.0.4" {
+		t.Errorf("expected peer-d ip to be restored; received %v", ips["peer-d"])
+	}
+	if _, ok := ips["peer-c"]; ok {
+		t.Error("expected conflicting peer-c ip to not be restored")
+	}
+	route, err := s.store.GetRoute(ctx, "10.100.0.0/24")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route.NodeID != "node-a" {
+		t.Errorf("expected master route to be kept; received %s", route.NodeID)
+	}
+	if _, err := s.store.GetRoute(ctx, "10.150.0.0/24"); err != store.ErrNotFound {
+		t.Errorf("expected route deleted before the split to not be restored; received %v", err)
+	}
+	for id, expected := range map[string]bool{"peer-d": true, "peer-e": false} {
+		authorized, err := s.store.IsAuthorized(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if authorized != expected {
+			t.Errorf("expected %s authorized %t; received %t", id, expected, authorized)
+		}
+	}
+}
+
+func TestReconcileDeletes(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	s, err := NewServer(&heimdall.Config{
+		ID:           "test",
+		NodeNetwork:  testNodeNetwork,
+		PeerNetwork:  testPeerNetwork,
+		DataDir:      tmpDir,
+		StoreBackend: StoreBackendEmbedded,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	// master state before the split
+	for _, network := range []string{"10.100.0.0/24", "10.200.0.0/24"} {
+		if err := s.store.SaveRoute(ctx, &v1.Route{NodeID: "node-a", Network: network}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.store.SavePolicy(ctx, &v1.Policy{ID: "web", PeerID: "peer-a", Destination: "10.100.0.0/24"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"peer-a", "peer-b"} {
+		if err := s.store.AuthorizePeer(ctx, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	revision, err := s.store.Revision(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// master writes after the split
+	if err := s.store.SaveRoute(ctx, &v1.Route{NodeID: "node-b", Network: "10.200.0.0/24"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.store.DeauthorizePeer(ctx, "peer-b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.store.AuthorizePeer(ctx, "peer-b"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the demoted master deleted everything after the split
+	local := &clusterState{
+		removed: []string{"node-c"},
+		writes: map[v1.WatchEvent_Type]map[string]struct{}{
+			v1.WatchEvent_ROUTE_DELETED: {
+				"10.100.0.0/24": {},
+				"10.200.0.0/24": {},
+			},
+			v1.WatchEvent_POLICY_DELETED: {
+				"web": {},
+			},
+			v1.WatchEvent_PEER_DEAUTHORIZED: {
+				"peer-a": {},
+				"peer-b": {},
+			},
+		},
+	}
+	report, err := s.reconcile(ctx, local, revision)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 10.100.0.0/24 route, web policy and peer-a authorization
+	if report.Deleted != 3 {
+		t.Errorf("expected 3 deleted; received %d", report.Deleted)
+	}
+	// 10.200.0.0/24 route, peer-b authorization and node-c removal
+	kinds := map[string]bool{}
+	for _, c := range report.Conflicts {
+		kinds[c.Kind] = true
+	}
+	if len(report.Conflicts) != 3 || !kinds[conflictRoute] || !kinds[conflictAuthorized] || !kinds[conflictNodeRemoved] {
+		t.Fatalf("expected route, authorized and node removed conflicts; received %+v", report.Conflicts)
+	}
+
+	if _, err := s.store.GetRoute(ctx, "10.100.0.0/24"); err != store.ErrNotFound {
+		t.Errorf("expected route deleted after the split to be deleted; received %v", err)
+	}
+	route, err := s.store.GetRoute(ctx, "10.200.0.0/24")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route.NodeID != "node-b" {
+		t.Errorf("expected route updated on the master to be kept; received %s", route.NodeID)
+	}
+	policies, err := s.store.GetPolicies(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(policies) != 0 {
+		t.Errorf("expected policy to be deleted; received %+v", policies)
+	}
+	for id, expected := range map[string]bool{"peer-a": false, "peer-b": true} {
+		authorized, err := s.store.IsAuthorized(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if authorized != expected {
+			t.Errorf("expected %s authorized %t; received %t", id, expected, authorized)
+		}
+	}
+}
+
+func TestReconcileRouteConflict(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	s, err := NewServer(&heimdall.Config{
+		ID:           "test",
+		NodeNetwork:  testNodeNetwork,
+		PeerNetwork:  testPeerNetwork,
+		DataDir:      tmpDir,
+		StoreBackend: StoreBackendEmbedded,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err := s.store.SaveRoute(ctx, &v1.Route{NodeID: "node-a", Network: "10.100.0.0/24", Nodes: []*v1.RouteNode{{NodeID: "node-a"}, {NodeID: "node-b", Priority: 1}}}); err != nil {
+		t.Fatal(err)
+	}
+	local := &clusterState{
+		routes: []*v1.Route{
+			{NodeID: "node-a", Network: "10.100.0.0/24", Nodes: []*v1.RouteNode{{NodeID: "node-a"}}},
+		},
+		writes: map[v1.WatchEvent_Type]map[string]struct{}{
+			v1.WatchEvent_ROUTE_UPDATED: {
+				"10.100.0.0/24": {},
+			},
+		},
+	}
+	report, err := s.reconcile(ctx, local, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Conflicts) != 1 || report.Conflicts[0].Kind != conflictRoute {
+		t.Fatalf("expected route conflict; received %+v", report.Conflicts)
+	}
+}
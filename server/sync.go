@@ -0,0 +1,228 @@
+package server
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ehazlett/heimdall"
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/ehazlett/heimdall/store"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// configResyncInterval is how often the desired peer config is
+	// recomputed to pick up changes that are not recorded as watch events
+	// such as node health
+	configResyncInterval = peerConfigUpdateInterval
+
+	// ErrConnectRequired is returned when a config sync does not start with a connect
+	ErrConnectRequired = errors.New("connect must be sent first")
+)
+
+// PeerConfigStatuses returns the config delivery status of all peers
+func (s *Server) PeerConfigStatuses(ctx context.Context, req *v1.PeerConfigStatusesRequest) (*v1.PeerConfigStatusesResponse, error) {
+	statuses, err := s.store.GetPeerConfigStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.PeerConfigStatusesResponse{
+		Statuses: statuses,
+	}, nil
+}
+
+// SyncConfig pushes the desired config to the peer whenever it changes and
+// records the versions acknowledged by the peer
+func (s *Server) SyncConfig(stream v1.Heimdall_SyncConfigServer) error {
+	ctx := stream.Context()
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if req.Connect == nil {
+		return ErrConnectRequired
+	}
+	id := req.Connect.ID
+
+	notifications, err := s.store.Subscribe(ctx, store.EventWatch, store.EventUpdateTunnel)
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("peer %s connected for config sync", id)
+	defer s.disconnectPeerConfig(id)
+
+	// mu serializes status updates between sending configs and acks
+	var mu sync.Mutex
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			if req.Ack == nil {
+				continue
+			}
+			mu.Lock()
+			err = s.ackPeerConfig(ctx, id, req.Ack)
+			mu.Unlock()
+			if err != nil {
+				errCh <- err
+				return
+			}
+		}
+	}()
+
+	written, err := s.connectPeer(ctx, req.Connect)
+	if err != nil {
+		return err
+	}
+
+	t := time.NewTicker(configResyncInterval)
+	defer t.Stop()
+	sentHash := ""
+	for {
+		cfg, err := s.desiredConfig(ctx, req.Connect)
+		if err != nil {
+			return err
+		}
+		// the peer info is only saved when it changes from the last info
+		// saved by this node so that nodes observing different addresses
+		// do not overwrite each other on every push
+		info, err := s.connectedPeerInfo(ctx, req.Connect)
+		if err != nil {
+			return err
+		}
+		if !proto.Equal(info, written) {
+			if err := s.savePeerInfo(ctx, info); err != nil {
+				return err
+			}
+			written = info
+		}
+		mu.Lock()
+		sentHash, err = s.sendPeerConfig(stream, id, cfg, sentHash)
+		mu.Unlock()
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errCh:
+			if err == io.EOF {
+				logrus.Infof("peer %s closed config sync", id)
+				return nil
+			}
+			return err
+		case <-notifications:
+		case <-t.C:
+		}
+	}
+}
+
+// sendPeerConfig sends the config to the peer if it differs from the last
+// sent config.  The version is incremented each time the desired config of
+// the peer changes.
+func (s *Server) sendPeerConfig(stream v1.Heimdall_SyncConfigServer, id string, cfg *v1.DesiredConfig, sentHash string) (string, error) {
+	ctx := stream.Context()
+	data, err := proto.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	hash := heimdall.HashData(data)
+	if hash == sentHash {
+		return sentHash, nil
+	}
+
+	status, err := s.peerConfigStatus(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if status.DesiredHash != hash {
+		status.DesiredVersion++
+		status.DesiredHash = hash
+		status.Desired = time.Now()
+	}
+	status.NodeID = s.cfg.ID
+	status.Connected = true
+	if err := s.store.SavePeerConfigStatus(ctx, status); err != nil {
+		return "", err
+	}
+
+	cfg.Version = status.DesiredVersion
+	logrus.Debugf("sending config version %d to peer %s", cfg.Version, id)
+	if err := stream.Send(cfg); err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+// ackPeerConfig records the config version applied by the peer
+func (s *Server) ackPeerConfig(ctx context.Context, id string, ack *v1.ConfigAck) error {
+	status, err := s.peerConfigStatus(ctx, id)
+	if err != nil {
+		return err
+	}
+	if ack.Error != "" {
+		logrus.Warnf("peer %s failed to apply config version %d: %s", id, ack.Version, ack.Error)
+		status.Error = ack.Error
+	} else {
+		logrus.Debugf("peer %s applied config version %d", id, ack.Version)
+		status.AppliedVersion = ack.Version
+		status.Applied = time.Now()
+		status.Error = ""
+	}
+	return s.store.SavePeerConfigStatus(ctx, status)
+}
+
+// disconnectPeerConfig marks the peer as disconnected unless it has since
+// connected to another node
+func (s *Server) disconnectPeerConfig(id string) {
+	ctx, cancel := context.WithTimeout(context.Background(), peerConfigUpdateInterval)
+	defer cancel()
+
+	status, err := s.peerConfigStatus(ctx, id)
+	if err != nil {
+		logrus.WithError(err).Warnf("error getting config status for peer %s", id)
+		return
+	}
+	if status.NodeID != s.cfg.ID {
+		return
+	}
+	status.Connected = false
+	status.Disconnected = time.Now()
+	if err := s.store.SavePeerConfigStatus(ctx, status); err != nil {
+		logrus.WithError(err).Warnf("error saving config status for peer %s", id)
+	}
+}
+
+// peerConfigStatuses returns the config delivery status of all peers by id
+func (s *Server) peerConfigStatuses(ctx context.Context) (map[string]*v1.PeerConfigStatus, error) {
+	statuses, err := s.store.GetPeerConfigStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]*v1.PeerConfigStatus, len(statuses))
+	for _, status := range statuses {
+		m[status.ID] = status
+	}
+	return m, nil
+}
+
+func (s *Server) peerConfigStatus(ctx context.Context, id string) (*v1.PeerConfigStatus, error) {
+	status, err := s.store.GetPeerConfigStatus(ctx, id)
+	if err != nil {
+		if err != store.ErrNotFound {
+			return nil, err
+		}
+		return &v1.PeerConfigStatus{ID: id}, nil
+	}
+	return status, nil
+}
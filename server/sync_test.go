@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ehazlett/heimdall"
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"google.golang.org/grpc"
+)
+
+type testSyncStream struct {
+	grpc.ServerStream
+	sent []*v1.DesiredConfig
+}
+
+func (t *testSyncStream) Context() context.Context {
+	return context.Background()
+}
+
+func (t *testSyncStream) Send(cfg *v1.DesiredConfig) error {
+	t.sent = append(t.sent, cfg)
+	return nil
+}
+
+func (t *testSyncStream) Recv() (*v1.SyncConfigRequest, error) {
+	return nil, io.EOF
+}
+
+func TestSyncConfigVersions(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	s, err := NewServer(&heimdall.Config{
+		ID:           "test",
+		NodeNetwork:  testNodeNetwork,
+		PeerNetwork:  testPeerNetwork,
+		DataDir:      tmpDir,
+		StoreBackend: StoreBackendEmbedded,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	stream := &testSyncStream{}
+	hash, err := s.sendPeerConfig(stream, "peer", &v1.DesiredConfig{Address: "10.51.0.2/16"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// unchanged configs are not resent
+	if hash, err = s.sendPeerConfig(stream, "peer", &v1.DesiredConfig{Address: "10.51.0.2/16"}, hash); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.sendPeerConfig(stream, "peer", &v1.DesiredConfig{Address: "10.51.0.3/16"}, hash); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.sent) != 2 {
+		t.Fatalf("expected 2 configs sent; received %d", len(stream.sent))
+	}
+	if stream.sent[0].Version != 1 || stream.sent[1].Version != 2 {
+		t.Fatalf("expected versions 1 and 2; received %d and %d", stream.sent[0].Version, stream.sent[1].Version)
+	}
+
+	if err := s.ackPeerConfig(ctx, "peer", &v1.ConfigAck{Version: 1}); err != nil {
+		t.Fatal(err)
+	}
+	status, err := s.store.GetPeerConfigStatus(ctx, "peer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.DesiredVersion != 2 || status.AppliedVersion != 1 {
+		t.Errorf("expected desired 2 and applied 1; received %d and %d", status.DesiredVersion, status.AppliedVersion)
+	}
+	if !status.Connected || status.NodeID != "test" {
+		t.Errorf("expected peer to be connected to test; received %+v", status)
+	}
+
+	s.disconnectPeerConfig("peer")
+	if status, err = s.store.GetPeerConfigStatus(ctx, "peer"); err != nil {
+		t.Fatal(err)
+	}
+	if status.Connected {
+		t.Error("expected peer to be disconnected")
+	}
+}
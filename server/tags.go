@@ -0,0 +1,56 @@
+package server
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInvalidTag is returned when a tag is not a valid dns label
+	ErrInvalidTag = errors.New("invalid tag")
+
+	tagPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+)
+
+// normalizeTags returns the lowercased, sorted and deduplicated tags.  Tags
+// must be valid dns labels so they can be resolved.
+func normalizeTags(tags []string) ([]string, error) {
+	seen := map[string]struct{}{}
+	normalized := []string{}
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if !tagPattern.MatchString(t) || len(t) > 63 {
+			return nil, errors.Wrapf(ErrInvalidTag, "%q", t)
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		normalized = append(normalized, t)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// hasTag returns true if the tags contain the tag
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAnyTag returns true if the tags contain any of the selectors
+func hasAnyTag(tags, selectors []string) bool {
+	for _, s := range selectors {
+		if hasTag(tags, s) {
+			return true
+		}
+	}
+	return false
+}
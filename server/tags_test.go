@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ehazlett/heimdall"
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/pkg/errors"
+)
+
+func TestPeerTags(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	s, err := NewServer(&heimdall.Config{
+		ID:           "test",
+		NodeNetwork:  testNodeNetwork,
+		PeerNetwork:  testPeerNetwork,
+		DataDir:      tmpDir,
+		StoreBackend: StoreBackendEmbedded,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if _, err := s.AuthorizePeer(ctx, &v1.AuthorizePeerRequest{ID: "peer-a", Tags: []string{"bad tag"}}); errors.Cause(err) != ErrInvalidTag {
+		t.Errorf("expected ErrInvalidTag; received %v", err)
+	}
+	if _, err := s.AuthorizePeer(ctx, &v1.AuthorizePeerRequest{ID: "peer-a", Tags: []string{"Ops", "laptops", "ops"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AuthorizePeer(ctx, &v1.AuthorizePeerRequest{ID: "peer-b"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []*v1.Peer{
+		{ID: "peer-a"},
+		{ID: "peer-b"},
+		{ID: "node-a", AllowedIPs: []string{"10.100.0.0/24", "10.200.0.0/24"}},
+	} {
+		if err := s.store.SavePeer(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.store.SaveRoute(ctx, &v1.Route{NodeID: "node-a", Network: "10.100.0.0/24", Tags: []string{"ops"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.store.SaveRoute(ctx, &v1.Route{NodeID: "node-a", Network: "10.200.0.0/24"}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := s.Peers(ctx, &v1.PeersRequest{Tag: "ops"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Peers) != 1 || resp.Peers[0].ID != "peer-a" {
+		t.Fatalf("expected only peer-a to be tagged ops; received %+v", resp.Peers)
+	}
+	if tags := resp.Peers[0].Tags; len(tags) != 2 || tags[0] != "laptops" || tags[1] != "ops" {
+		t.Errorf("expected normalized tags; received %v", tags)
+	}
+
+	peers, err := s.getPeers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hidden, err := s.hiddenRoutes(ctx, "peer-a", peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hidden) != 0 {
+		t.Errorf("expected no hidden routes for peer-a; received %v", hidden)
+	}
+	hidden, err = s.hiddenRoutes(ctx, "peer-b", peers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := hidden["10.100.0.0/24"]; !ok || len(hidden) != 1 {
+		t.Errorf("expected ops route to be hidden for peer-b; received %v", hidden)
+	}
+
+	if _, err := s.DeauthorizePeer(ctx, &v1.DeauthorizePeerRequest{ID: "peer-a"}); err != nil {
+		t.Fatal(err)
+	}
+	tags, err := s.store.GetPeerTags(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := tags["peer-a"]; ok {
+		t.Error("expected tags to be removed on deauthorize")
+	}
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"time"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/ehazlett/heimdall/store"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// watchResyncInterval is how often watches check for events in case a
+	// notification was missed
+	watchResyncInterval = time.Second * 30
+)
+
+// Watch streams cluster state changes after the requested revision.  A
+// SYNCED event is sent once the stream has caught up to the current revision.
+func (s *Server) Watch(req *v1.WatchRequest, stream v1.Heimdall_WatchServer) error {
+	ctx := stream.Context()
+
+	// subscribe before reading the revision so no events are missed
+	notifications, err := s.store.Subscribe(ctx, store.EventWatch)
+	if err != nil {
+		return err
+	}
+
+	revision := req.Revision
+	if revision == 0 {
+		if revision, err = s.store.Revision(ctx); err != nil {
+			return err
+		}
+	}
+	if revision, err = s.sendWatchEvents(stream, revision); err != nil {
+		return err
+	}
+	if err := stream.Send(&v1.WatchEvent{
+		Type:     v1.WatchEvent_SYNCED,
+		Revision: revision,
+		Created:  time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	t := time.NewTicker(watchResyncInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-notifications:
+			if !ok {
+				return nil
+			}
+		case <-t.C:
+		}
+		if revision, err = s.sendWatchEvents(stream, revision); err != nil {
+			return err
+		}
+	}
+}
+
+// sendWatchEvents sends the events after the revision and returns the
+// revision of the last event sent
+func (s *Server) sendWatchEvents(stream v1.Heimdall_WatchServer, revision uint64) (uint64, error) {
+	events, err := s.store.WatchEvents(stream.Context(), revision)
+	if err != nil {
+		if err == store.ErrCompacted {
+			return 0, status.Errorf(codes.OutOfRange, "revision %d has been compacted", revision)
+		}
+		return 0, err
+	}
+	for _, ev := range events {
+		logrus.Debugf("watch: revision=%d type=%s id=%s", ev.Revision, ev.Type, ev.ID)
+		if err := stream.Send(ev); err != nil {
+			return 0, err
+		}
+		revision = ev.Revision
+	}
+	return revision, nil
+}
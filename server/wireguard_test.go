@@ -19,8 +19,6 @@ func TestWireguardTemplate(t *testing.T) {
 PrivateKey = SERVER-PRIVATE-KEY
 ListenPort = 10000
 Address = 1.2.3.4:10000
-PostUp = iptables -A FORWARD -i darknet -j ACCEPT; iptables -t nat -A POSTROUTING -o eth0 -j MASQUERADE; ip6tables -A FORWARD -i darknet -j ACCEPT; ip6tables -t nat -A POSTROUTING -o eth0 -j MASQUERADE
-PostDown = iptables -D FORWARD -i darknet -j ACCEPT; iptables -t nat -D POSTROUTING -o eth0 -j MASQUERADE; ip6tables -D FORWARD -i darknet -j ACCEPT; ip6tables -t nat -D POSTROUTING -o eth0 -j MASQUERADE
 
 # test-peer
 [Peer]
@@ -38,11 +36,8 @@ Endpoint = 100.100.100.100:10000
 		Address:       "1.2.3.4:10000",
 		Peers: []*v1.Peer{
 			{
-				ID: "test-peer",
-				KeyPair: &v1.KeyPair{
-					PrivateKey: "PEER-PRIVATE-KEY",
-					PublicKey:  "PEER-PUBLIC-KEY",
-				},
+				ID:         "test-peer",
+				PublicKey:  "PEER-PUBLIC-KEY",
 				AllowedIPs: []string{"10.100.0.0/24", "10.254.0.0/16"},
 				Endpoint:   "100.100.100.100:10000",
 			},
@@ -68,3 +63,75 @@ Endpoint = 100.100.100.100:10000
 		t.Fatalf("config does not match; expected \n %q \n received \n %q", expectedConf, string(data))
 	}
 }
+
+func TestWireguardPeerTemplate(t *testing.T) {
+	expectedConf := `# managed by heimdall
+[Interface]
+PrivateKey = PEER-PRIVATE-KEY
+ListenPort = 51820
+Address = 10.51.0.2/16
+DNS = 10.10.0.1
+
+
+# node
+[Peer]
+PublicKey = NODE-PUBLIC-KEY
+PersistentKeepalive = 25
+AllowedIPs = 10.10.0.0/24
+Endpoint = 100.100.100.100:10000
+
+
+# mesh-peer
+[Peer]
+PublicKey = MESH-PUBLIC-KEY
+PersistentKeepalive = 25
+AllowedIPs = 10.51.0.3/32
+
+
+`
+	cfg := &wg.Config{
+		Interface:  defaultWireguardInterface,
+		PrivateKey: "PEER-PRIVATE-KEY",
+		ListenPort: 51820,
+		Address:    "10.51.0.2/16",
+		DNS:        []string{"10.10.0.1"},
+		Peers: []*v1.Peer{
+			{
+				ID:         "node",
+				PublicKey:  "NODE-PUBLIC-KEY",
+				AllowedIPs: []string{"10.10.0.0/24"},
+				Endpoint:   "100.100.100.100:10000",
+			},
+			{
+				ID:         "mesh-peer",
+				PublicKey:  "MESH-PUBLIC-KEY",
+				AllowedIPs: []string{"10.51.0.3/32"},
+				Direct:     true,
+			},
+			{
+				ID:         "relayed-peer",
+				PublicKey:  "RELAYED-PUBLIC-KEY",
+				AllowedIPs: []string{"10.51.0.4/32"},
+			},
+		},
+	}
+	tmpDir, err := ioutil.TempDir("", "heimdall-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	configPath, err := wg.GeneratePeerConfig(cfg, tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != expectedConf {
+		t.Fatalf("config does not match; expected \n %q \n received \n %q", expectedConf, string(data))
+	}
+}
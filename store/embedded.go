@@ -0,0 +1,816 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/gogo/protobuf/proto"
+)
+
+// Embedded is a Store kept in memory and persisted to a local file.  It does
+// not replicate and is intended for single node clusters and testing.
+type Embedded struct {
+	mu    sync.RWMutex
+	path  string
+	state *embeddedState
+	subs  map[chan string]map[string]struct{}
+}
+
+type embeddedState struct {
+	Master           *v1.Master                      `json:"master,omitempty"`
+	MasterExpires    time.Time                       `json:"master_expires"`
+	Term             uint64                          `json:"term"`
+	ClusterKey       string                          `json:"cluster_key"`
+	NodeSubnetSize   uint32                          `json:"node_subnet_size,omitempty"`
+	NodeSubnetSizeV6 uint32                          `json:"node_subnet_size_v6,omitempty"`
+	Nodes            map[string]*v1.Node             `json:"nodes"`
+	NodeExpires      map[string]time.Time            `json:"node_expires"`
+	NodeNetworks     map[string]string               `json:"node_networks"`
+	NodeNetworks6    map[string]string               `json:"node_networks6"`
+	Draining         map[string]bool                 `json:"draining"`
+	Removed          map[string]time.Time            `json:"removed"`
+	Peers            map[string]*v1.Peer             `json:"peers"`
+	PeerIPs          map[string]string               `json:"peer_ips"`
+	PeerIPs6         map[string]string               `json:"peer_ips6"`
+	PeerConfigs      map[string]*v1.PeerConfigStatus `json:"peer_configs"`
+	Routes           map[string]*v1.Route            `json:"routes"`
+	Policies         map[string]*v1.Policy           `json:"policies"`
+	Authorized       map[string]bool                 `json:"authorized"`
+	PeerTags         map[string][]string             `json:"peer_tags"`
+	PeerRoutes       map[string][]string             `json:"peer_routes"`
+	Reports          []*v1.ReconcileReport           `json:"reports,omitempty"`
+	Revision         uint64                          `json:"revision"`
+	Events           []*v1.WatchEvent                `json:"events,omitempty"`
+}
+
+// appendEvent records the watch event with the next revision
+func (s *embeddedState) appendEvent(ev *v1.WatchEvent) {
+	s.Revision++
+	ev.Revision = s.Revision
+	s.Events = append(s.Events, ev)
+	if len(s.Events) > maxWatchEvents {
+		s.Events = s.Events[len(s.Events)-maxWatchEvents:]
+	}
+}
+
+// nodeNetworks returns the node subnet allocations in the address family of
+// the subnet
+func (s *embeddedState) nodeNetworks(network string) map[string]string {
+	if IsIPv6(network) {
+		return s.NodeNetworks6
+	}
+	return s.NodeNetworks
+}
+
+// peerIPs returns the peer IP allocations in the address family of the IP
+func (s *embeddedState) peerIPs(ip string) map[string]string {
+	if IsIPv6(ip) {
+		return s.PeerIPs6
+	}
+	return s.PeerIPs
+}
+
+// NewEmbedded returns a new embedded store persisted to the specified path.
+// If path is empty the state is only kept in memory.
+func NewEmbedded(path string) (*Embedded, error) {
+	state := &embeddedState{
+		Nodes:         map[string]*v1.Node{},
+		NodeExpires:   map[string]time.Time{},
+		NodeNetworks:  map[string]string{},
+		NodeNetworks6: map[string]string{},
+		Draining:      map[string]bool{},
+		Removed:       map[string]time.Time{},
+		Peers:         map[string]*v1.Peer{},
+		PeerIPs:       map[string]string{},
+		PeerIPs6:      map[string]string{},
+		PeerConfigs:   map[string]*v1.PeerConfigStatus{},
+		Routes:        map[string]*v1.Route{},
+		Policies:      map[string]*v1.Policy{},
+		Authorized:    map[string]bool{},
+		PeerTags:      map[string][]string{},
+		PeerRoutes:    map[string][]string{},
+	}
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, state); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &Embedded{
+		path:  path,
+		state: state,
+		subs:  map[chan string]map[string]struct{}{},
+	}, nil
+}
+
+func (e *Embedded) GetMaster(ctx context.Context) (*v1.Master, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.state.Master == nil || expired(e.state.MasterExpires) {
+		return nil, ErrNotFound
+	}
+	return clone(e.state.Master).(*v1.Master), nil
+}
+
+func (e *Embedded) GetTerm(ctx context.Context) (uint64, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.state.Term, nil
+}
+
+func (e *Embedded) AcquireMaster(ctx context.Context, master *v1.Master, ttl time.Duration) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if master.Term <= e.state.Term || e.leaseHeld(master.ID) {
+		return ErrFenced
+	}
+	e.state.Term = master.Term
+	e.state.Master = clone(master).(*v1.Master)
+	e.state.MasterExpires = expiry(ttl)
+	return e.persist()
+}
+
+func (e *Embedded) SetMaster(ctx context.Context, master *v1.Master, ttl time.Duration) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if master.Term != e.state.Term || e.leaseHeld(master.ID) {
+		return ErrFenced
+	}
+	e.state.Master = clone(master).(*v1.Master)
+	e.state.MasterExpires = expiry(ttl)
+	return e.persist()
+}
+
+func (e *Embedded) HandoffMaster(ctx context.Context, from, to string, term uint64, ttl time.Duration) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if term != e.state.Term+1 || e.leaseHeld(from) {
+		return ErrFenced
+	}
+	e.state.Term = term
+	e.state.Master = &v1.Master{ID: to, Term: term}
+	e.state.MasterExpires = expiry(ttl)
+	return e.persist()
+}
+
+// leaseHeld returns true if the master lease is held by another node
+func (e *Embedded) leaseHeld(id string) bool {
+	return e.state.Master != nil && e.state.Master.ID != id && !expired(e.state.MasterExpires)
+}
+
+func (e *Embedded) GetClusterKey(ctx context.Context) (string, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.state.ClusterKey == "" {
+		return "", ErrNotFound
+	}
+	return e.state.ClusterKey, nil
+}
+
+func (e *Embedded) SetClusterKey(ctx context.Context, key string) error {
+	return e.update(func(s *embeddedState) {
+		s.ClusterKey = key
+	})
+}
+
+func (e *Embedded) InitNodeSubnetSize(ctx context.Context, v4, v6 uint32) (uint32, uint32, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.state.NodeSubnetSize != 0 {
+		return e.state.NodeSubnetSize, e.state.NodeSubnetSizeV6, nil
+	}
+	e.state.NodeSubnetSize = v4
+	e.state.NodeSubnetSizeV6 = v6
+	if err := e.persist(); err != nil {
+		return 0, 0, err
+	}
+	return v4, v6, nil
+}
+
+func (e *Embedded) GetNode(ctx context.Context, id string) (*v1.Node, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	n, ok := e.state.Nodes[id]
+	if !ok || expired(e.state.NodeExpires[id]) {
+		return nil, ErrNotFound
+	}
+	return clone(n).(*v1.Node), nil
+}
+
+func (e *Embedded) GetNodes(ctx context.Context) ([]*v1.Node, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	var nodes []*v1.Node
+	for _, id := range sortedKeys(e.state.Nodes) {
+		if expired(e.state.NodeExpires[id]) {
+			continue
+		}
+		nodes = append(nodes, clone(e.state.Nodes[id]).(*v1.Node))
+	}
+	return nodes, nil
+}
+
+func (e *Embedded) SaveNode(ctx context.Context, node *v1.Node, ttl time.Duration) error {
+	return e.update(func(s *embeddedState) {
+		if _, ok := s.Nodes[node.ID]; !ok || expired(s.NodeExpires[node.ID]) {
+			ev := watchEvent(v1.WatchEvent_NODE_JOINED, node.ID)
+			ev.Node = clone(node).(*v1.Node)
+			s.appendEvent(ev)
+		}
+		s.Nodes[node.ID] = clone(node).(*v1.Node)
+		s.NodeExpires[node.ID] = expiry(ttl)
+	})
+}
+
+func (e *Embedded) DeleteNode(ctx context.Context, id string) error {
+	return e.update(func(s *embeddedState) {
+		if _, ok := s.Nodes[id]; ok {
+			s.appendEvent(watchEvent(v1.WatchEvent_NODE_LEFT, id))
+		}
+		delete(s.Nodes, id)
+		delete(s.NodeExpires, id)
+	})
+}
+
+func (e *Embedded) DrainNode(ctx context.Context, id string) error {
+	return e.update(func(s *embeddedState) {
+		s.Draining[id] = true
+	})
+}
+
+func (e *Embedded) UndrainNode(ctx context.Context, id string) error {
+	return e.update(func(s *embeddedState) {
+		delete(s.Draining, id)
+	})
+}
+
+func (e *Embedded) DrainingNodes(ctx context.Context) ([]string, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return sortedKeys(e.state.Draining), nil
+}
+
+func (e *Embedded) MarkNodeRemoved(ctx context.Context, id string, ttl time.Duration) error {
+	return e.update(func(s *embeddedState) {
+		s.Removed[id] = expiry(ttl)
+	})
+}
+
+func (e *Embedded) IsNodeRemoved(ctx context.Context, id string) (bool, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	t, ok := e.state.Removed[id]
+	return ok && !expired(t), nil
+}
+
+func (e *Embedded) ClearNodeRemoved(ctx context.Context, id string) error {
+	return e.update(func(s *embeddedState) {
+		delete(s.Removed, id)
+	})
+}
+
+func (e *Embedded) GetNodeNetwork(ctx context.Context, id string) ([]string, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	networks := mergeAllocations(e.state.NodeNetworks, e.state.NodeNetworks6)[id]
+	if len(networks) == 0 {
+		return nil, ErrNotFound
+	}
+	return networks, nil
+}
+
+func (e *Embedded) GetNodeNetworks(ctx context.Context) (map[string][]string, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return mergeAllocations(e.state.NodeNetworks, e.state.NodeNetworks6), nil
+}
+
+func (e *Embedded) ReserveNodeNetwork(ctx context.Context, id, network string) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	networks := e.state.nodeNetworks(network)
+	if existing, ok := networks[id]; ok {
+		return existing, nil
+	}
+	for _, v := range networks {
+		if v == network {
+			return "", ErrExists
+		}
+	}
+	networks[id] = network
+	if err := e.persist(); err != nil {
+		return "", err
+	}
+	return network, nil
+}
+
+func (e *Embedded) DeleteNodeNetwork(ctx context.Context, id string) error {
+	return e.update(func(s *embeddedState) {
+		delete(s.NodeNetworks, id)
+		delete(s.NodeNetworks6, id)
+	})
+}
+
+func (e *Embedded) GetPeer(ctx context.Context, id string) (*v1.Peer, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	p, ok := e.state.Peers[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return clone(p).(*v1.Peer), nil
+}
+
+func (e *Embedded) GetPeers(ctx context.Context) ([]*v1.Peer, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	var peers []*v1.Peer
+	for _, id := range sortedKeys(e.state.Peers) {
+		peers = append(peers, clone(e.state.Peers[id]).(*v1.Peer))
+	}
+	return peers, nil
+}
+
+func (e *Embedded) SavePeer(ctx context.Context, peer *v1.Peer) error {
+	return e.update(func(s *embeddedState) {
+		prev, ok := s.Peers[peer.ID]
+		if !ok || !proto.Equal(prev, peer) {
+			t := v1.WatchEvent_PEER_ADDED
+			if ok {
+				t = v1.WatchEvent_PEER_UPDATED
+			}
+			ev := watchEvent(t, peer.ID)
+			ev.Peer = clone(peer).(*v1.Peer)
+			s.appendEvent(ev)
+		}
+		s.Peers[peer.ID] = clone(peer).(*v1.Peer)
+	})
+}
+
+func (e *Embedded) DeletePeer(ctx context.Context, id string) error {
+	return e.update(func(s *embeddedState) {
+		if _, ok := s.Peers[id]; ok {
+			s.appendEvent(watchEvent(v1.WatchEvent_PEER_REMOVED, id))
+		}
+		delete(s.Peers, id)
+	})
+}
+
+func (e *Embedded) GetPeerConfigStatus(ctx context.Context, id string) (*v1.PeerConfigStatus, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	s, ok := e.state.PeerConfigs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return clone(s).(*v1.PeerConfigStatus), nil
+}
+
+func (e *Embedded) GetPeerConfigStatuses(ctx context.Context) ([]*v1.PeerConfigStatus, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	var statuses []*v1.PeerConfigStatus
+	for _, id := range sortedKeys(e.state.PeerConfigs) {
+		statuses = append(statuses, clone(e.state.PeerConfigs[id]).(*v1.PeerConfigStatus))
+	}
+	return statuses, nil
+}
+
+func (e *Embedded) SavePeerConfigStatus(ctx context.Context, status *v1.PeerConfigStatus) error {
+	return e.update(func(s *embeddedState) {
+		s.PeerConfigs[status.ID] = clone(status).(*v1.PeerConfigStatus)
+	})
+}
+
+func (e *Embedded) DeletePeerConfigStatus(ctx context.Context, id string) error {
+	return e.update(func(s *embeddedState) {
+		delete(s.PeerConfigs, id)
+	})
+}
+
+func (e *Embedded) GetPeerIP(ctx context.Context, id string) ([]string, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	ips := mergeAllocations(e.state.PeerIPs, e.state.PeerIPs6)[id]
+	if len(ips) == 0 {
+		return nil, ErrNotFound
+	}
+	return ips, nil
+}
+
+func (e *Embedded) GetPeerIPs(ctx context.Context) (map[string][]string, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return mergeAllocations(e.state.PeerIPs, e.state.PeerIPs6), nil
+}
+
+func (e *Embedded) ReservePeerIP(ctx context.Context, id, ip string) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ips := e.state.peerIPs(ip)
+	if existing, ok := ips[id]; ok {
+		return existing, nil
+	}
+	for _, v := range ips {
+		if v == ip {
+			return "", ErrExists
+		}
+	}
+	ips[id] = ip
+	if err := e.persist(); err != nil {
+		return "", err
+	}
+	return ip, nil
+}
+
+func (e *Embedded) ReplacePeerIP(ctx context.Context, id string, ips []string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, ip := range ips {
+		for peerID, v := range e.state.peerIPs(ip) {
+			if v == ip && peerID != id {
+				return ErrExists
+			}
+		}
+	}
+	delete(e.state.PeerIPs, id)
+	delete(e.state.PeerIPs6, id)
+	for _, ip := range ips {
+		e.state.peerIPs(ip)[id] = ip
+	}
+	return e.persist()
+}
+
+func (e *Embedded) DeletePeerIP(ctx context.Context, id string) error {
+	return e.update(func(s *embeddedState) {
+		delete(s.PeerIPs, id)
+		delete(s.PeerIPs6, id)
+	})
+}
+
+func (e *Embedded) GetRoute(ctx context.Context, network string) (*v1.Route, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	r, ok := e.state.Routes[network]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return clone(r).(*v1.Route), nil
+}
+
+func (e *Embedded) GetRoutes(ctx context.Context) ([]*v1.Route, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	var routes []*v1.Route
+	for _, network := range sortedKeys(e.state.Routes) {
+		routes = append(routes, clone(e.state.Routes[network]).(*v1.Route))
+	}
+	return routes, nil
+}
+
+func (e *Embedded) SaveRoute(ctx context.Context, route *v1.Route) error {
+	return e.update(func(s *embeddedState) {
+		prev, ok := s.Routes[route.Network]
+		if !ok || !proto.Equal(prev, route) {
+			t := v1.WatchEvent_ROUTE_CREATED
+			if ok {
+				t = v1.WatchEvent_ROUTE_UPDATED
+			}
+			ev := watchEvent(t, route.Network)
+			ev.Route = clone(route).(*v1.Route)
+			s.appendEvent(ev)
+		}
+		s.Routes[route.Network] = clone(route).(*v1.Route)
+	})
+}
+
+func (e *Embedded) DeleteRoute(ctx context.Context, network string) error {
+	return e.update(func(s *embeddedState) {
+		if _, ok := s.Routes[network]; ok {
+			s.appendEvent(watchEvent(v1.WatchEvent_ROUTE_DELETED, network))
+		}
+		delete(s.Routes, network)
+	})
+}
+
+func (e *Embedded) GetPolicy(ctx context.Context, id string) (*v1.Policy, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	p, ok := e.state.Policies[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return clone(p).(*v1.Policy), nil
+}
+
+func (e *Embedded) GetPolicies(ctx context.Context) ([]*v1.Policy, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	var policies []*v1.Policy
+	for _, id := range sortedKeys(e.state.Policies) {
+		policies = append(policies, clone(e.state.Policies[id]).(*v1.Policy))
+	}
+	return policies, nil
+}
+
+func (e *Embedded) SavePolicy(ctx context.Context, policy *v1.Policy) error {
+	return e.update(func(s *embeddedState) {
+		prev, ok := s.Policies[policy.ID]
+		if !ok || !proto.Equal(prev, policy) {
+			t := v1.WatchEvent_POLICY_CREATED
+			if ok {
+				t = v1.WatchEvent_POLICY_UPDATED
+			}
+			ev := watchEvent(t, policy.ID)
+			ev.Policy = clone(policy).(*v1.Policy)
+			s.appendEvent(ev)
+		}
+		s.Policies[policy.ID] = clone(policy).(*v1.Policy)
+	})
+}
+
+func (e *Embedded) DeletePolicy(ctx context.Context, id string) error {
+	return e.update(func(s *embeddedState) {
+		if _, ok := s.Policies[id]; ok {
+			s.appendEvent(watchEvent(v1.WatchEvent_POLICY_DELETED, id))
+		}
+		delete(s.Policies, id)
+	})
+}
+
+func (e *Embedded) AuthorizePeer(ctx context.Context, id string) error {
+	return e.update(func(s *embeddedState) {
+		if !s.Authorized[id] {
+			s.appendEvent(watchEvent(v1.WatchEvent_PEER_AUTHORIZED, id))
+		}
+		s.Authorized[id] = true
+	})
+}
+
+func (e *Embedded) DeauthorizePeer(ctx context.Context, id string) error {
+	return e.update(func(s *embeddedState) {
+		if s.Authorized[id] {
+			s.appendEvent(watchEvent(v1.WatchEvent_PEER_DEAUTHORIZED, id))
+		}
+		delete(s.Authorized, id)
+	})
+}
+
+func (e *Embedded) IsAuthorized(ctx context.Context, id string) (bool, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.state.Authorized[id], nil
+}
+
+func (e *Embedded) AuthorizedPeers(ctx context.Context) ([]string, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return sortedKeys(e.state.Authorized), nil
+}
+
+func (e *Embedded) GetPeerTags(ctx context.Context) (map[string][]string, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	tags := make(map[string][]string, len(e.state.PeerTags))
+	for id, t := range e.state.PeerTags {
+		tags[id] = append([]string{}, t...)
+	}
+	return tags, nil
+}
+
+func (e *Embedded) SetPeerTags(ctx context.Context, id string, tags []string) error {
+	return e.update(func(s *embeddedState) {
+		if equalStrings(s.PeerTags[id], tags) {
+			return
+		}
+		s.appendEvent(watchEvent(v1.WatchEvent_PEER_TAGGED, id))
+		if len(tags) == 0 {
+			delete(s.PeerTags, id)
+			return
+		}
+		s.PeerTags[id] = append([]string{}, tags...)
+	})
+}
+
+func (e *Embedded) GetAdvertisedRoutes(ctx context.Context) (map[string][]string, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	routes := make(map[string][]string, len(e.state.PeerRoutes))
+	for id, n := range e.state.PeerRoutes {
+		routes[id] = append([]string{}, n...)
+	}
+	return routes, nil
+}
+
+func (e *Embedded) SetAdvertisedRoutes(ctx context.Context, id string, networks []string) error {
+	return e.update(func(s *embeddedState) {
+		if equalStrings(s.PeerRoutes[id], networks) {
+			return
+		}
+		s.appendEvent(watchEvent(v1.WatchEvent_PEER_ROUTES_ADVERTISED, id))
+		if len(networks) == 0 {
+			delete(s.PeerRoutes, id)
+			return
+		}
+		s.PeerRoutes[id] = append([]string{}, networks...)
+	})
+}
+
+func (e *Embedded) SaveReconcileReport(ctx context.Context, report *v1.ReconcileReport) error {
+	return e.update(func(s *embeddedState) {
+		s.Reports = append([]*v1.ReconcileReport{clone(report).(*v1.ReconcileReport)}, s.Reports...)
+		if len(s.Reports) > maxReconcileReports {
+			s.Reports = s.Reports[:maxReconcileReports]
+		}
+	})
+}
+
+func (e *Embedded) GetReconcileReports(ctx context.Context) ([]*v1.ReconcileReport, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	reports := make([]*v1.ReconcileReport, 0, len(e.state.Reports))
+	for _, r := range e.state.Reports {
+		reports = append(reports, clone(r).(*v1.ReconcileReport))
+	}
+	return reports, nil
+}
+
+func (e *Embedded) Revision(ctx context.Context) (uint64, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.state.Revision, nil
+}
+
+func (e *Embedded) WatchEvents(ctx context.Context, revision uint64) ([]*v1.WatchEvent, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if revision > e.state.Revision {
+		return nil, ErrCompacted
+	}
+	if revision == e.state.Revision {
+		return nil, nil
+	}
+	i := sort.Search(len(e.state.Events), func(i int) bool {
+		return e.state.Events[i].Revision > revision
+	})
+	if i == len(e.state.Events) || e.state.Events[i].Revision != revision+1 {
+		return nil, ErrCompacted
+	}
+	events := make([]*v1.WatchEvent, 0, len(e.state.Events)-i)
+	for _, ev := range e.state.Events[i:] {
+		events = append(events, clone(ev).(*v1.WatchEvent))
+	}
+	return events, nil
+}
+
+func (e *Embedded) Publish(ctx context.Context, event string) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	for ch, events := range e.subs {
+		if _, ok := events[event]; !ok {
+			continue
+		}
+		// drop the event if the subscriber is busy to avoid blocking writers
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+func (e *Embedded) Subscribe(ctx context.Context, events ...string) (<-chan string, error) {
+	ch := make(chan string, 16)
+	lookup := make(map[string]struct{}, len(events))
+	for _, ev := range events {
+		lookup[ev] = struct{}{}
+	}
+	e.mu.Lock()
+	e.subs[ch] = lookup
+	e.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		e.mu.Lock()
+		delete(e.subs, ch)
+		close(ch)
+		e.mu.Unlock()
+	}()
+	return ch, nil
+}
+
+// update applies the change and persists the state.  Watchers are notified
+// if the change recorded watch events.
+func (e *Embedded) update(fn func(s *embeddedState)) error {
+	e.mu.Lock()
+	revision := e.state.Revision
+	fn(e.state)
+	err := e.persist()
+	notify := e.state.Revision != revision
+	e.mu.Unlock()
+
+	if notify {
+		e.Publish(context.Background(), EventWatch)
+	}
+	return err
+}
+
+func (e *Embedded) persist() error {
+	if e.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(e.state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(e.path), 0750); err != nil {
+		return err
+	}
+	tmp := e.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, e.path)
+}
+
+func expiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
+
+func expired(t time.Time) bool {
+	return !t.IsZero() && time.Now().After(t)
+}
+
+// clone returns a deep copy of the message.  proto.Clone is not used as it
+// cannot copy stdtime fields once the local time zone has been loaded.
+func clone(m proto.Message) proto.Message {
+	data, err := proto.Marshal(m)
+	if err != nil {
+		panic(err)
+	}
+	c := reflect.New(reflect.TypeOf(m).Elem()).Interface().(proto.Message)
+	if err := proto.Unmarshal(data, c); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// mergeAllocations returns the allocations of each address family by id in
+// the order of the families
+func mergeAllocations(families ...map[string]string) map[string][]string {
+	m := map[string][]string{}
+	for _, allocations := range families {
+		for id, v := range allocations {
+			m[id] = append(m[id], v)
+		}
+	}
+	return m
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch v := m.(type) {
+	case map[string]*v1.Node:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case map[string]*v1.Peer:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case map[string]*v1.Route:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case map[string]*v1.Policy:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case map[string]*v1.PeerConfigStatus:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case map[string]bool:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
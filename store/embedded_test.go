@@ -0,0 +1,264 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+)
+
+func TestEmbeddedPersist(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "heimdall-store-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	ctx := context.Background()
+	statePath := filepath.Join(tmpDir, "state.json")
+	s, err := NewEmbedded(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.SavePeer(ctx, &v1.Peer{ID: "test-peer", Name: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AuthorizePeer(ctx, "test-peer"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ReservePeerIP(ctx, "test-peer", "10.51.0.2"); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewEmbedded(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	peer, err := r.GetPeer(ctx, "test-peer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if peer.Name != "test" {
+		t.Errorf("expected peer name test; received %s", peer.Name)
+	}
+	authorized, err := r.IsAuthorized(ctx, "test-peer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !authorized {
+		t.Error("expected peer to be authorized")
+	}
+	ips, err := r.GetPeerIPs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ips["test-peer"]) != 1 || ips["test-peer"][0] != "10.51.0.2" {
+		t.Errorf("expected peer ip 10.51.0.2; received %v", ips["test-peer"])
+	}
+}
+
+func TestEmbeddedReplacePeerIP(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewEmbedded("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.ReservePeerIP(ctx, "peer-a", "10.51.0.2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ReservePeerIP(ctx, "peer-b", "10.51.0.3"); err != nil {
+		t.Fatal(err)
+	}
+	// the existing allocation is kept if the new ip is taken
+	if err := s.ReplacePeerIP(ctx, "peer-a", []string{"10.51.0.3", "fd00::2"}); err != ErrExists {
+		t.Fatalf("expected ErrExists; received %v", err)
+	}
+	ips, err := s.GetPeerIP(ctx, "peer-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ips) != 1 || ips[0] != "10.51.0.2" {
+		t.Errorf("expected peer ip 10.51.0.2; received %v", ips)
+	}
+
+	if err := s.ReplacePeerIP(ctx, "peer-a", []string{"10.51.0.4", "fd00::2"}); err != nil {
+		t.Fatal(err)
+	}
+	ips, err = s.GetPeerIP(ctx, "peer-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ips) != 2 || ips[0] != "10.51.0.4" || ips[1] != "fd00::2" {
+		t.Errorf("expected peer ips [10.51.0.4 fd00::2]; received %v", ips)
+	}
+	// the released ip can be reserved again
+	if _, err := s.ReservePeerIP(ctx, "peer-c", "10.51.0.2"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEmbeddedExpiry(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewEmbedded("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.SetMaster(ctx, &v1.Master{ID: "test"}, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond * 5)
+	if _, err := s.GetMaster(ctx); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound; received %v", err)
+	}
+
+	if err := s.SaveNode(ctx, &v1.Node{ID: "test"}, 0); err != nil {
+		t.Fatal(err)
+	}
+	nodes, err := s.GetNodes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 {
+		t.Fatalf("expected 1 node; received %d", len(nodes))
+	}
+}
+
+func TestEmbeddedMasterLease(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewEmbedded("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.AcquireMaster(ctx, &v1.Master{ID: "a", Term: 1}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AcquireMaster(ctx, &v1.Master{ID: "b", Term: 2}, time.Minute); err != ErrFenced {
+		t.Fatalf("expected ErrFenced acquiring held lease; received %v", err)
+	}
+	if err := s.SetMaster(ctx, &v1.Master{ID: "a", Term: 1}, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond * 5)
+
+	if err := s.AcquireMaster(ctx, &v1.Master{ID: "b", Term: 1}, time.Minute); err != ErrFenced {
+		t.Fatalf("expected ErrFenced acquiring stale term; received %v", err)
+	}
+	if err := s.AcquireMaster(ctx, &v1.Master{ID: "b", Term: 2}, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetMaster(ctx, &v1.Master{ID: "a", Term: 1}, time.Minute); err != ErrFenced {
+		t.Fatalf("expected ErrFenced renewing lost lease; received %v", err)
+	}
+	term, err := s.GetTerm(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if term != 2 {
+		t.Fatalf("expected term 2; received %d", term)
+	}
+}
+
+func TestEmbeddedSubscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := NewEmbedded("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, err := s.Subscribe(ctx, EventUpdateTunnel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Publish(ctx, "heimdall:other"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Publish(ctx, EventUpdateTunnel); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case ev := <-ch:
+		if ev != EventUpdateTunnel {
+			t.Fatalf("expected event %s; received %s", EventUpdateTunnel, ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting on event")
+	}
+}
+
+func TestEmbeddedWatchEvents(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewEmbedded("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peer := &v1.Peer{ID: "test-peer", Name: "test"}
+	if err := s.SavePeer(ctx, peer); err != nil {
+		t.Fatal(err)
+	}
+	// unchanged saves are not recorded
+	if err := s.SavePeer(ctx, peer); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SavePeer(ctx, &v1.Peer{ID: "test-peer", Name: "updated"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AuthorizePeer(ctx, "test-peer"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeletePeer(ctx, "test-peer"); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := s.WatchEvents(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []v1.WatchEvent_Type{
+		v1.WatchEvent_PEER_ADDED,
+		v1.WatchEvent_PEER_UPDATED,
+		v1.WatchEvent_PEER_AUTHORIZED,
+		v1.WatchEvent_PEER_REMOVED,
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events; received %d", len(expected), len(events))
+	}
+	for i, ev := range events {
+		if ev.Type != expected[i] {
+			t.Errorf("expected event %d to be %s; received %s", i, expected[i], ev.Type)
+		}
+		if ev.Revision != uint64(i+1) {
+			t.Errorf("expected revision %d; received %d", i+1, ev.Revision)
+		}
+	}
+
+	events, err = s.WatchEvents(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Revision != 3 {
+		t.Fatalf("expected events after revision 2; received %+v", events)
+	}
+	if _, err := s.WatchEvents(ctx, 10); err != ErrCompacted {
+		t.Fatalf("expected ErrCompacted for future revision; received %v", err)
+	}
+
+	for i := 0; i < maxWatchEvents; i++ {
+		if err := s.AuthorizePeer(ctx, fmt.Sprintf("peer-%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.WatchEvents(ctx, 1); err != ErrCompacted {
+		t.Fatalf("expected ErrCompacted for compacted revision; received %v", err)
+	}
+}
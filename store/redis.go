@@ -0,0 +1,1194 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	masterKey           = "heimdall:master"
+	leaseKey            = "heimdall:lease"
+	termKey             = "heimdall:term"
+	clusterKey          = "heimdall:key"
+	nodeSubnetSizeKey   = "heimdall:nodesubnetsize"
+	keypairsKey         = "heimdall:keypairs"
+	nodesKey            = "heimdall:nodes"
+	peersKey            = "heimdall:peers"
+	routesKey           = "heimdall:routes"
+	policiesKey         = "heimdall:policies"
+	peerIPsKey          = "heimdall:peerips"
+	peerIPs6Key         = "heimdall:peerips6"
+	peerIPIndexKey      = "heimdall:peeripindex"
+	nodeNetworksKey     = "heimdall:nodenetworks"
+	nodeNetworks6Key    = "heimdall:nodenetworks6"
+	nodeNetworkIndexKey = "heimdall:nodenetworkindex"
+	authorizedPeersKey  = "heimdall:authorized"
+	peerTagsKey         = "heimdall:peertags"
+	peerRoutesKey       = "heimdall:peerroutes"
+	reconcileReportsKey = "heimdall:reconcilereports"
+	drainingNodesKey    = "heimdall:draining"
+	removedNodesKey     = "heimdall:removed"
+	revisionKey         = "heimdall:revision"
+	watchEventsKey      = "heimdall:watchevents"
+	peerConfigsKey      = "heimdall:peerconfigs"
+)
+
+// fenceCheck is prepended to all write scripts to reject writes from nodes
+// that have not observed the current master term.  The term key is passed
+// as the last key and the writer term as the last argument.
+const fenceCheck = `
+local fence = tonumber(redis.call('GET', KEYS[#KEYS]) or '0')
+if fence ~= tonumber(ARGV[#ARGV]) then
+	return redis.error_reply('FENCED stale term ' .. ARGV[#ARGV] .. ' current ' .. fence)
+end
+`
+
+// appendEvent is prepended to scripts that record watch events.  The
+// revision and event keys are passed as the second and third keys and the
+// notification channel and number of events to keep as the first arguments.
+const appendEvent = `
+local function appendEvent(ev)
+	local rev = redis.call('INCR', KEYS[2])
+	redis.call('ZADD', KEYS[3], rev, rev .. ':' .. ev)
+	redis.call('ZREMRANGEBYRANK', KEYS[3], 0, -tonumber(ARGV[2]) - 1)
+	redis.call('PUBLISH', ARGV[1], rev)
+	return rev
+end
+`
+
+var (
+	// writeScript executes a single write command
+	writeScript = fencedScript(0, `
+return redis.call(unpack(ARGV, 1, #ARGV - 1))
+`)
+	// saveEventScript saves the value and records the created event for new
+	// keys or the updated event if the value changed
+	saveEventScript = fencedScript(3, appendEvent+`
+local prev = redis.call('GET', KEYS[1])
+redis.call('SET', KEYS[1], ARGV[3])
+if tonumber(ARGV[4]) > 0 then
+	redis.call('EXPIRE', KEYS[1], ARGV[4])
+end
+if not prev then
+	return appendEvent(ARGV[5])
+end
+if ARGV[6] == '' or prev == ARGV[3] then
+	return 0
+end
+return appendEvent(ARGV[6])
+`)
+	// deleteEventScript deletes the key and records the event if it existed
+	deleteEventScript = fencedScript(3, appendEvent+`
+if redis.call('DEL', KEYS[1]) == 0 then
+	return 0
+end
+return appendEvent(ARGV[3])
+`)
+	// setEventScript adds or removes the set member and records the event if
+	// the set changed
+	setEventScript = fencedScript(3, appendEvent+`
+if redis.call(ARGV[3], KEYS[1], ARGV[4]) == 0 then
+	return 0
+end
+return appendEvent(ARGV[5])
+`)
+	// acquireMasterScript acquires the master lease for a new term
+	acquireMasterScript = redis.NewScript(3, `
+local term = tonumber(redis.call('GET', KEYS[1]) or '0')
+if tonumber(ARGV[1]) <= term then
+	return redis.error_reply('FENCED term ' .. ARGV[1] .. ' is not newer than ' .. term)
+end
+if not redis.call('SET', KEYS[2], ARGV[2], 'NX', 'PX', ARGV[4]) then
+	return redis.error_reply('FENCED lease held by ' .. redis.call('GET', KEYS[2]))
+end
+redis.call('SET', KEYS[1], ARGV[1])
+redis.call('SET', KEYS[3], ARGV[3], 'PX', ARGV[4])
+return 1
+`)
+	// renewMasterScript renews the master lease for the current term
+	renewMasterScript = redis.NewScript(3, `
+local term = tonumber(redis.call('GET', KEYS[1]) or '0')
+if tonumber(ARGV[1]) ~= term then
+	return redis.error_reply('FENCED term ' .. ARGV[1] .. ' superseded by ' .. term)
+end
+local holder = redis.call('GET', KEYS[2])
+if holder and holder ~= ARGV[2] then
+	return redis.error_reply('FENCED lease held by ' .. holder)
+end
+redis.call('SET', KEYS[2], ARGV[2], 'PX', ARGV[4])
+redis.call('SET', KEYS[3], ARGV[3], 'PX', ARGV[4])
+return 1
+`)
+	// handoffMasterScript transfers the master lease to another node for the next term
+	handoffMasterScript = redis.NewScript(2, `
+local term = tonumber(redis.call('GET', KEYS[1]) or '0')
+if tonumber(ARGV[3]) ~= term + 1 then
+	return redis.error_reply('FENCED term ' .. ARGV[3] .. ' does not follow ' .. term)
+end
+local holder = redis.call('GET', KEYS[2])
+if holder and holder ~= ARGV[1] then
+	return redis.error_reply('FENCED lease held by ' .. holder)
+end
+redis.call('SET', KEYS[1], ARGV[3])
+redis.call('SET', KEYS[2], ARGV[2], 'PX', ARGV[4])
+return 1
+`)
+	// initNodeSubnetSizeScript sets the node subnet sizes unless they are set
+	initNodeSubnetSizeScript = fencedScript(1, `
+local existing = redis.call('GET', KEYS[1])
+if existing then
+	return existing
+end
+redis.call('SET', KEYS[1], ARGV[1])
+return ARGV[1]
+`)
+	// reservePeerIPScript reserves an IP for a peer using the IP to ID index
+	// to ensure an IP is never handed out twice
+	reservePeerIPScript = fencedScript(2, `
+local existing = redis.call('HGET', KEYS[1], ARGV[1])
+if existing then
+	return existing
+end
+if redis.call('HSETNX', KEYS[2], ARGV[2], ARGV[1]) == 0 then
+	return false
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+return ARGV[2]
+`)
+	// releasePeerIPScript removes the IP allocation and index for a peer
+	releasePeerIPScript = fencedScript(2, `
+local ip = redis.call('HGET', KEYS[1], ARGV[1])
+if not ip then
+	return 0
+end
+redis.call('HDEL', KEYS[1], ARGV[1])
+if redis.call('HGET', KEYS[2], ip) == ARGV[1] then
+	redis.call('HDEL', KEYS[2], ip)
+end
+return 1
+`)
+	// replacePeerIPScript replaces the IPv4 and IPv6 allocations of a peer
+	// unless any of the new IPs is allocated to another peer
+	replacePeerIPScript = fencedScript(3, `
+local ips = {ARGV[2], ARGV[3]}
+for i = 1, 2 do
+	if ips[i] ~= '' then
+		local owner = redis.call('HGET', KEYS[3], ips[i])
+		if owner and owner ~= ARGV[1] then
+			return false
+		end
+	end
+end
+for i = 1, 2 do
+	local old = redis.call('HGET', KEYS[i], ARGV[1])
+	if old then
+		redis.call('HDEL', KEYS[i], ARGV[1])
+		if redis.call('HGET', KEYS[3], old) == ARGV[1] then
+			redis.call('HDEL', KEYS[3], old)
+		end
+	end
+	if ips[i] ~= '' then
+		redis.call('HSET', KEYS[i], ARGV[1], ips[i])
+		redis.call('HSET', KEYS[3], ips[i], ARGV[1])
+	end
+end
+return 1
+`)
+	// reserveNodeNetworkScript reserves a subnet for a node using the subnet
+	// to ID index to ensure a subnet is never handed out twice
+	reserveNodeNetworkScript = fencedScript(2, `
+local existing = redis.call('GET', KEYS[1])
+if existing then
+	return existing
+end
+if redis.call('HSETNX', KEYS[2], ARGV[2], ARGV[1]) == 0 then
+	return false
+end
+redis.call('SET', KEYS[1], ARGV[2])
+return ARGV[2]
+`)
+	// releaseNodeNetworkScript removes the subnet allocation and index for a node
+	releaseNodeNetworkScript = fencedScript(2, `
+local network = redis.call('GET', KEYS[1])
+if not network then
+	return 0
+end
+redis.call('DEL', KEYS[1])
+if redis.call('HGET', KEYS[2], network) == ARGV[1] then
+	redis.call('HDEL', KEYS[2], network)
+end
+return 1
+`)
+	// indexPeerIPsScript adds missing index entries for existing allocations
+	indexPeerIPsScript = fencedScript(2, `
+local ips = redis.call('HGETALL', KEYS[1])
+local n = 0
+for i = 1, #ips, 2 do
+	n = n + redis.call('HSETNX', KEYS[2], ips[i+1], ips[i])
+end
+return n
+`)
+)
+
+// Redis is a Store backed by Redis.  Reads are performed against the local
+// replica and writes are sent to the current master.
+type Redis struct {
+	mu     sync.RWMutex
+	local  *redis.Pool
+	master *redis.Pool
+	term   uint64
+}
+
+// NewPool returns a new Redis connection pool for the specified url
+func NewPool(redisURL string) (*redis.Pool, error) {
+	pool := redis.NewPool(func() (redis.Conn, error) {
+		conn, err := redis.DialURL(redisURL)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to connect to redis")
+		}
+
+		u, err := url.Parse(redisURL)
+		if err != nil {
+			return nil, err
+		}
+
+		auth, ok := u.User.Password()
+		if ok {
+			if _, err := conn.Do("CONFIG", "SET", "MASTERAUTH", auth); err != nil {
+				return nil, errors.Wrap(err, "error authenticating to redis")
+			}
+		}
+		return conn, nil
+	}, 10)
+
+	return pool, nil
+}
+
+// NewRedis returns a new Redis store using the local pool for reads and the
+// master pool for writes
+func NewRedis(local, master *redis.Pool) *Redis {
+	return &Redis{
+		local:  local,
+		master: master,
+	}
+}
+
+// SetLocalPool updates the pool used for reads
+func (r *Redis) SetLocalPool(p *redis.Pool) {
+	r.mu.Lock()
+	r.local = p
+	r.mu.Unlock()
+}
+
+// SetMasterPool updates the pool used for writes
+func (r *Redis) SetMasterPool(p *redis.Pool) {
+	r.mu.Lock()
+	r.master = p
+	r.mu.Unlock()
+}
+
+// SetTerm updates the master term used to fence writes
+func (r *Redis) SetTerm(term uint64) {
+	r.mu.Lock()
+	r.term = term
+	r.mu.Unlock()
+}
+
+// Local executes the command against the local Redis
+func (r *Redis) Local(ctx context.Context, cmd string, args ...interface{}) (interface{}, error) {
+	r.mu.RLock()
+	p := r.local
+	r.mu.RUnlock()
+	return do(ctx, p, cmd, args...)
+}
+
+// Master executes the command against the master Redis.  The command is
+// rejected with ErrFenced if the master term has changed.
+func (r *Redis) Master(ctx context.Context, cmd string, args ...interface{}) (interface{}, error) {
+	return r.script(ctx, writeScript, nil, append([]interface{}{cmd}, args...)...)
+}
+
+// RemoveLegacyKeyPairs removes keypairs stored by previous versions which
+// contained the private keys for every node and peer in the cluster.  Node
+// and peer records of previous versions still hold the keypair as an unknown
+// field and are rewritten without it.
+func (r *Redis) RemoveLegacyKeyPairs(ctx context.Context) error {
+	keys, err := redis.Strings(r.Master(ctx, "KEYS", key(keypairsKey, "*")))
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		logrus.Debugf("removing legacy keypair %s", k)
+		if _, err := r.Master(ctx, "DEL", k); err != nil {
+			return err
+		}
+	}
+	if err := r.rewriteUnknown(ctx, nodesKey, func() proto.Message { return &v1.Node{} }); err != nil {
+		return err
+	}
+	return r.rewriteUnknown(ctx, peersKey, func() proto.Message { return &v1.Peer{} })
+}
+
+// rewriteUnknown rewrites the records with the prefix that contain unknown
+// fields without them.  The expiry of the records is kept.
+func (r *Redis) rewriteUnknown(ctx context.Context, prefix string, newMessage func() proto.Message) error {
+	keys, err := redis.Strings(r.Local(ctx, "KEYS", key(prefix, "*")))
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		data, err := redis.Bytes(r.Local(ctx, "GET", k))
+		if err != nil {
+			if err == redis.ErrNil {
+				continue
+			}
+			return err
+		}
+		clean, changed, err := withoutUnknown(data, newMessage())
+		if err != nil {
+			return errors.Wrapf(err, "error unmarshalling %s", k)
+		}
+		if !changed {
+			continue
+		}
+		ttl, err := redis.Int64(r.Local(ctx, "PTTL", k))
+		if err != nil {
+			return err
+		}
+		args := []interface{}{k, clean}
+		if ttl > 0 {
+			args = append(args, "PX", ttl)
+		}
+		logrus.Debugf("removing legacy fields from %s", k)
+		if _, err := r.Master(ctx, "SET", args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withoutUnknown returns the message data without unknown fields and whether
+// any were removed
+func withoutUnknown(data []byte, m proto.Message) ([]byte, bool, error) {
+	if err := proto.Unmarshal(data, m); err != nil {
+		return nil, false, err
+	}
+	proto.DiscardUnknown(m)
+	clean, err := proto.Marshal(m)
+	if err != nil {
+		return nil, false, err
+	}
+	return clean, !bytes.Equal(clean, data), nil
+}
+
+func (r *Redis) GetMaster(ctx context.Context) (*v1.Master, error) {
+	var master v1.Master
+	if err := r.get(ctx, masterKey, &master); err != nil {
+		return nil, err
+	}
+	return &master, nil
+}
+
+func (r *Redis) GetTerm(ctx context.Context) (uint64, error) {
+	term, err := redis.Uint64(r.Local(ctx, "GET", termKey))
+	if err != nil {
+		if err == redis.ErrNil {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return term, nil
+}
+
+func (r *Redis) AcquireMaster(ctx context.Context, master *v1.Master, ttl time.Duration) error {
+	return r.lease(ctx, acquireMasterScript, master, ttl)
+}
+
+func (r *Redis) SetMaster(ctx context.Context, master *v1.Master, ttl time.Duration) error {
+	return r.lease(ctx, renewMasterScript, master, ttl)
+}
+
+func (r *Redis) HandoffMaster(ctx context.Context, from, to string, term uint64, ttl time.Duration) error {
+	r.mu.RLock()
+	p := r.master
+	r.mu.RUnlock()
+	conn, err := p.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = handoffMasterScript.Do(conn, termKey, leaseKey, from, to, term, ttl.Milliseconds())
+	return fenced(err)
+}
+
+func (r *Redis) lease(ctx context.Context, s *redis.Script, master *v1.Master, ttl time.Duration) error {
+	data, err := proto.Marshal(master)
+	if err != nil {
+		return err
+	}
+	r.mu.RLock()
+	p := r.master
+	r.mu.RUnlock()
+	conn, err := p.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = s.Do(conn, termKey, leaseKey, masterKey, master.Term, master.ID, data, ttl.Milliseconds())
+	return fenced(err)
+}
+
+func (r *Redis) GetClusterKey(ctx context.Context) (string, error) {
+	return notFound(redis.String(r.Local(ctx, "GET", clusterKey)))
+}
+
+func (r *Redis) SetClusterKey(ctx context.Context, k string) error {
+	_, err := r.Master(ctx, "SET", clusterKey, k)
+	return err
+}
+
+func (r *Redis) InitNodeSubnetSize(ctx context.Context, v4, v6 uint32) (uint32, uint32, error) {
+	v, err := redis.String(r.script(ctx, initNodeSubnetSizeScript, []interface{}{nodeSubnetSizeKey}, fmt.Sprintf("%d/%d", v4, v6)))
+	if err != nil {
+		return 0, 0, err
+	}
+	var size, size6 uint32
+	if _, err := fmt.Sscanf(v, "%d/%d", &size, &size6); err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid node subnet size %q", v)
+	}
+	return size, size6, nil
+}
+
+func (r *Redis) GetNode(ctx context.Context, id string) (*v1.Node, error) {
+	var node v1.Node
+	if err := r.get(ctx, key(nodesKey, id), &node); err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+func (r *Redis) GetNodes(ctx context.Context) ([]*v1.Node, error) {
+	var nodes []*v1.Node
+	if err := r.list(ctx, nodesKey, func() proto.Message {
+		n := &v1.Node{}
+		nodes = append(nodes, n)
+		return n
+	}); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+func (r *Redis) SaveNode(ctx context.Context, node *v1.Node, ttl time.Duration) error {
+	joined := watchEvent(v1.WatchEvent_NODE_JOINED, node.ID)
+	joined.Node = node
+	return r.saveWithEvent(ctx, key(nodesKey, node.ID), node, ttl, joined, nil)
+}
+
+func (r *Redis) DeleteNode(ctx context.Context, id string) error {
+	return r.deleteWithEvent(ctx, key(nodesKey, id), watchEvent(v1.WatchEvent_NODE_LEFT, id))
+}
+
+func (r *Redis) DrainNode(ctx context.Context, id string) error {
+	_, err := r.Master(ctx, "SADD", drainingNodesKey, id)
+	return err
+}
+
+func (r *Redis) UndrainNode(ctx context.Context, id string) error {
+	_, err := r.Master(ctx, "SREM", drainingNodesKey, id)
+	return err
+}
+
+func (r *Redis) DrainingNodes(ctx context.Context) ([]string, error) {
+	return redis.Strings(r.Local(ctx, "SMEMBERS", drainingNodesKey))
+}
+
+func (r *Redis) MarkNodeRemoved(ctx context.Context, id string, ttl time.Duration) error {
+	_, err := r.Master(ctx, "SET", key(removedNodesKey, id), time.Now().Unix(), "PX", ttl.Milliseconds())
+	return err
+}
+
+func (r *Redis) IsNodeRemoved(ctx context.Context, id string) (bool, error) {
+	return redis.Bool(r.Local(ctx, "EXISTS", key(removedNodesKey, id)))
+}
+
+func (r *Redis) ClearNodeRemoved(ctx context.Context, id string) error {
+	_, err := r.Master(ctx, "DEL", key(removedNodesKey, id))
+	return err
+}
+
+func (r *Redis) GetNodeNetwork(ctx context.Context, id string) ([]string, error) {
+	values, err := redis.Strings(r.Local(ctx, "MGET", key(nodeNetworksKey, id), key(nodeNetworks6Key, id)))
+	if err != nil {
+		return nil, err
+	}
+	var networks []string
+	for _, v := range values {
+		if v != "" {
+			networks = append(networks, v)
+		}
+	}
+	if len(networks) == 0 {
+		return nil, ErrNotFound
+	}
+	return networks, nil
+}
+
+func (r *Redis) GetNodeNetworks(ctx context.Context) (map[string][]string, error) {
+	index, err := redis.StringMap(r.Local(ctx, "HGETALL", nodeNetworkIndexKey))
+	if err != nil {
+		return nil, err
+	}
+	networks := make(map[string][]string, len(index))
+	for network, id := range index {
+		networks[id] = append(networks[id], network)
+	}
+	for _, v := range networks {
+		sortAddresses(v)
+	}
+	return networks, nil
+}
+
+// nodeNetworkKey returns the allocation key of the node in the address
+// family of the subnet
+func nodeNetworkKey(id, network string) string {
+	if IsIPv6(network) {
+		return key(nodeNetworks6Key, id)
+	}
+	return key(nodeNetworksKey, id)
+}
+
+func (r *Redis) ReserveNodeNetwork(ctx context.Context, id, network string) (string, error) {
+	v, err := redis.String(r.script(ctx, reserveNodeNetworkScript, []interface{}{nodeNetworkKey(id, network), nodeNetworkIndexKey}, id, network))
+	if err != nil {
+		if err == redis.ErrNil {
+			return "", ErrExists
+		}
+		return "", err
+	}
+	return v, nil
+}
+
+func (r *Redis) DeleteNodeNetwork(ctx context.Context, id string) error {
+	for _, k := range []string{key(nodeNetworksKey, id), key(nodeNetworks6Key, id)} {
+		if _, err := r.script(ctx, releaseNodeNetworkScript, []interface{}{k, nodeNetworkIndexKey}, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IndexNodeNetworks adds subnet index entries for allocations made by
+// previous versions
+func (r *Redis) IndexNodeNetworks(ctx context.Context) error {
+	keys, err := redis.Strings(r.Master(ctx, "KEYS", key(nodeNetworksKey, "*")))
+	if err != nil {
+		return err
+	}
+	n := 0
+	for _, k := range keys {
+		network, err := redis.String(r.Master(ctx, "GET", k))
+		if err != nil {
+			if err == redis.ErrNil {
+				continue
+			}
+			return err
+		}
+		added, err := redis.Int(r.Master(ctx, "HSETNX", nodeNetworkIndexKey, network, k[len(nodeNetworksKey)+1:]))
+		if err != nil {
+			return err
+		}
+		n += added
+	}
+	if n > 0 {
+		logrus.Infof("indexed %d existing node networks", n)
+	}
+	return nil
+}
+
+func (r *Redis) GetPeer(ctx context.Context, id string) (*v1.Peer, error) {
+	var peer v1.Peer
+	if err := r.get(ctx, key(peersKey, id), &peer); err != nil {
+		return nil, err
+	}
+	return &peer, nil
+}
+
+func (r *Redis) GetPeers(ctx context.Context) ([]*v1.Peer, error) {
+	var peers []*v1.Peer
+	if err := r.list(ctx, peersKey, func() proto.Message {
+		p := &v1.Peer{}
+		peers = append(peers, p)
+		return p
+	}); err != nil {
+		return nil, err
+	}
+	return peers, nil
+}
+
+func (r *Redis) SavePeer(ctx context.Context, peer *v1.Peer) error {
+	added := watchEvent(v1.WatchEvent_PEER_ADDED, peer.ID)
+	added.Peer = peer
+	updated := watchEvent(v1.WatchEvent_PEER_UPDATED, peer.ID)
+	updated.Peer = peer
+	return r.saveWithEvent(ctx, key(peersKey, peer.ID), peer, 0, added, updated)
+}
+
+func (r *Redis) DeletePeer(ctx context.Context, id string) error {
+	return r.deleteWithEvent(ctx, key(peersKey, id), watchEvent(v1.WatchEvent_PEER_REMOVED, id))
+}
+
+func (r *Redis) GetPeerConfigStatus(ctx context.Context, id string) (*v1.PeerConfigStatus, error) {
+	var status v1.PeerConfigStatus
+	if err := r.get(ctx, key(peerConfigsKey, id), &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func (r *Redis) GetPeerConfigStatuses(ctx context.Context) ([]*v1.PeerConfigStatus, error) {
+	var statuses []*v1.PeerConfigStatus
+	if err := r.list(ctx, peerConfigsKey, func() proto.Message {
+		s := &v1.PeerConfigStatus{}
+		statuses = append(statuses, s)
+		return s
+	}); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+func (r *Redis) SavePeerConfigStatus(ctx context.Context, status *v1.PeerConfigStatus) error {
+	return r.set(ctx, key(peerConfigsKey, status.ID), status, 0)
+}
+
+func (r *Redis) DeletePeerConfigStatus(ctx context.Context, id string) error {
+	_, err := r.Master(ctx, "DEL", key(peerConfigsKey, id))
+	return err
+}
+
+func (r *Redis) GetPeerIP(ctx context.Context, id string) ([]string, error) {
+	var ips []string
+	for _, k := range []string{peerIPsKey, peerIPs6Key} {
+		ip, err := redis.String(r.Local(ctx, "HGET", k, id))
+		if err != nil {
+			if err == redis.ErrNil {
+				continue
+			}
+			return nil, err
+		}
+		ips = append(ips, ip)
+	}
+	if len(ips) == 0 {
+		return nil, ErrNotFound
+	}
+	return ips, nil
+}
+
+func (r *Redis) GetPeerIPs(ctx context.Context) (map[string][]string, error) {
+	ips := map[string][]string{}
+	for _, k := range []string{peerIPsKey, peerIPs6Key} {
+		m, err := redis.StringMap(r.Local(ctx, "HGETALL", k))
+		if err != nil {
+			return nil, err
+		}
+		for id, ip := range m {
+			ips[id] = append(ips[id], ip)
+		}
+	}
+	return ips, nil
+}
+
+// peerIPKey returns the peer allocation hash in the address family of the IP
+func peerIPKey(ip string) string {
+	if IsIPv6(ip) {
+		return peerIPs6Key
+	}
+	return peerIPsKey
+}
+
+func (r *Redis) ReservePeerIP(ctx context.Context, id, ip string) (string, error) {
+	v, err := redis.String(r.script(ctx, reservePeerIPScript, []interface{}{peerIPKey(ip), peerIPIndexKey}, id, ip))
+	if err != nil {
+		if err == redis.ErrNil {
+			return "", ErrExists
+		}
+		return "", err
+	}
+	return v, nil
+}
+
+func (r *Redis) ReplacePeerIP(ctx context.Context, id string, ips []string) error {
+	var ip4, ip6 string
+	for _, ip := range ips {
+		if IsIPv6(ip) {
+			ip6 = ip
+		} else {
+			ip4 = ip
+		}
+	}
+	if _, err := redis.Int(r.script(ctx, replacePeerIPScript, []interface{}{peerIPsKey, peerIPs6Key, peerIPIndexKey}, id, ip4, ip6)); err != nil {
+		if err == redis.ErrNil {
+			return ErrExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *Redis) DeletePeerIP(ctx context.Context, id string) error {
+	for _, k := range []string{peerIPsKey, peerIPs6Key} {
+		if _, err := r.script(ctx, releasePeerIPScript, []interface{}{k, peerIPIndexKey}, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IndexPeerIPs adds IP index entries for allocations made by previous versions
+func (r *Redis) IndexPeerIPs(ctx context.Context) error {
+	n, err := redis.Int(r.script(ctx, indexPeerIPsScript, []interface{}{peerIPsKey, peerIPIndexKey}))
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		logrus.Infof("indexed %d existing peer ips", n)
+	}
+	return nil
+}
+
+func (r *Redis) GetRoute(ctx context.Context, network string) (*v1.Route, error) {
+	var route v1.Route
+	if err := r.get(ctx, key(routesKey, network), &route); err != nil {
+		return nil, err
+	}
+	return &route, nil
+}
+
+func (r *Redis) GetRoutes(ctx context.Context) ([]*v1.Route, error) {
+	var routes []*v1.Route
+	if err := r.list(ctx, routesKey, func() proto.Message {
+		rt := &v1.Route{}
+		routes = append(routes, rt)
+		return rt
+	}); err != nil {
+		return nil, err
+	}
+	return routes, nil
+}
+
+func (r *Redis) SaveRoute(ctx context.Context, route *v1.Route) error {
+	created := watchEvent(v1.WatchEvent_ROUTE_CREATED, route.Network)
+	created.Route = route
+	updated := watchEvent(v1.WatchEvent_ROUTE_UPDATED, route.Network)
+	updated.Route = route
+	return r.saveWithEvent(ctx, key(routesKey, route.Network), route, 0, created, updated)
+}
+
+func (r *Redis) DeleteRoute(ctx context.Context, network string) error {
+	return r.deleteWithEvent(ctx, key(routesKey, network), watchEvent(v1.WatchEvent_ROUTE_DELETED, network))
+}
+
+func (r *Redis) GetPolicy(ctx context.Context, id string) (*v1.Policy, error) {
+	var policy v1.Policy
+	if err := r.get(ctx, key(policiesKey, id), &policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *Redis) GetPolicies(ctx context.Context) ([]*v1.Policy, error) {
+	var policies []*v1.Policy
+	if err := r.list(ctx, policiesKey, func() proto.Message {
+		p := &v1.Policy{}
+		policies = append(policies, p)
+		return p
+	}); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (r *Redis) SavePolicy(ctx context.Context, policy *v1.Policy) error {
+	created := watchEvent(v1.WatchEvent_POLICY_CREATED, policy.ID)
+	created.Policy = policy
+	updated := watchEvent(v1.WatchEvent_POLICY_UPDATED, policy.ID)
+	updated.Policy = policy
+	return r.saveWithEvent(ctx, key(policiesKey, policy.ID), policy, 0, created, updated)
+}
+
+func (r *Redis) DeletePolicy(ctx context.Context, id string) error {
+	return r.deleteWithEvent(ctx, key(policiesKey, id), watchEvent(v1.WatchEvent_POLICY_DELETED, id))
+}
+
+func (r *Redis) AuthorizePeer(ctx context.Context, id string) error {
+	return r.setWithEvent(ctx, "SADD", authorizedPeersKey, id, watchEvent(v1.WatchEvent_PEER_AUTHORIZED, id))
+}
+
+func (r *Redis) DeauthorizePeer(ctx context.Context, id string) error {
+	return r.setWithEvent(ctx, "SREM", authorizedPeersKey, id, watchEvent(v1.WatchEvent_PEER_DEAUTHORIZED, id))
+}
+
+func (r *Redis) IsAuthorized(ctx context.Context, id string) (bool, error) {
+	return redis.Bool(r.Local(ctx, "SISMEMBER", authorizedPeersKey, id))
+}
+
+func (r *Redis) AuthorizedPeers(ctx context.Context) ([]string, error) {
+	return redis.Strings(r.Local(ctx, "SMEMBERS", authorizedPeersKey))
+}
+
+func (r *Redis) GetPeerTags(ctx context.Context) (map[string][]string, error) {
+	var all []*v1.PeerTags
+	if err := r.list(ctx, peerTagsKey, func() proto.Message {
+		t := &v1.PeerTags{}
+		all = append(all, t)
+		return t
+	}); err != nil {
+		return nil, err
+	}
+	tags := make(map[string][]string, len(all))
+	for _, t := range all {
+		tags[t.ID] = t.Tags
+	}
+	return tags, nil
+}
+
+func (r *Redis) SetPeerTags(ctx context.Context, id string, tags []string) error {
+	ev := watchEvent(v1.WatchEvent_PEER_TAGGED, id)
+	if len(tags) == 0 {
+		return r.deleteWithEvent(ctx, key(peerTagsKey, id), ev)
+	}
+	return r.saveWithEvent(ctx, key(peerTagsKey, id), &v1.PeerTags{
+		ID:   id,
+		Tags: tags,
+	}, 0, ev, ev)
+}
+
+func (r *Redis) GetAdvertisedRoutes(ctx context.Context) (map[string][]string, error) {
+	var all []*v1.PeerRoutes
+	if err := r.list(ctx, peerRoutesKey, func() proto.Message {
+		n := &v1.PeerRoutes{}
+		all = append(all, n)
+		return n
+	}); err != nil {
+		return nil, err
+	}
+	routes := make(map[string][]string, len(all))
+	for _, n := range all {
+		routes[n.ID] = n.Networks
+	}
+	return routes, nil
+}
+
+func (r *Redis) SetAdvertisedRoutes(ctx context.Context, id string, networks []string) error {
+	ev := watchEvent(v1.WatchEvent_PEER_ROUTES_ADVERTISED, id)
+	if len(networks) == 0 {
+		return r.deleteWithEvent(ctx, key(peerRoutesKey, id), ev)
+	}
+	return r.saveWithEvent(ctx, key(peerRoutesKey, id), &v1.PeerRoutes{
+		ID:       id,
+		Networks: networks,
+	}, 0, ev, ev)
+}
+
+func (r *Redis) SaveReconcileReport(ctx context.Context, report *v1.ReconcileReport) error {
+	data, err := proto.Marshal(report)
+	if err != nil {
+		return err
+	}
+	if _, err := r.Master(ctx, "LPUSH", reconcileReportsKey, data); err != nil {
+		return err
+	}
+	_, err = r.Master(ctx, "LTRIM", reconcileReportsKey, 0, maxReconcileReports-1)
+	return err
+}
+
+func (r *Redis) GetReconcileReports(ctx context.Context) ([]*v1.ReconcileReport, error) {
+	values, err := redis.ByteSlices(r.Local(ctx, "LRANGE", reconcileReportsKey, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*v1.ReconcileReport, 0, len(values))
+	for _, data := range values {
+		var report v1.ReconcileReport
+		if err := proto.Unmarshal(data, &report); err != nil {
+			return nil, err
+		}
+		reports = append(reports, &report)
+	}
+	return reports, nil
+}
+
+func (r *Redis) Revision(ctx context.Context) (uint64, error) {
+	rev, err := redis.Uint64(r.Local(ctx, "GET", revisionKey))
+	if err != nil && err != redis.ErrNil {
+		return 0, err
+	}
+	return rev, nil
+}
+
+func (r *Redis) WatchEvents(ctx context.Context, revision uint64) ([]*v1.WatchEvent, error) {
+	current, err := r.Revision(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if revision > current {
+		return nil, ErrCompacted
+	}
+	if revision == current {
+		return nil, nil
+	}
+	values, err := redis.ByteSlices(r.Local(ctx, "ZRANGEBYSCORE", watchEventsKey, fmt.Sprintf("(%d", revision), "+inf"))
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*v1.WatchEvent, 0, len(values))
+	for _, v := range values {
+		parts := bytes.SplitN(v, []byte(":"), 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid watch event %q", v)
+		}
+		var ev v1.WatchEvent
+		if err := proto.Unmarshal(parts[1], &ev); err != nil {
+			return nil, errors.Wrap(err, "error unmarshalling watch event")
+		}
+		if ev.Revision, err = strconv.ParseUint(string(parts[0]), 10, 64); err != nil {
+			return nil, errors.Wrap(err, "invalid watch event revision")
+		}
+		events = append(events, &ev)
+	}
+	if len(events) == 0 || events[0].Revision != revision+1 {
+		return nil, ErrCompacted
+	}
+	return events, nil
+}
+
+func (r *Redis) Publish(ctx context.Context, event string) error {
+	_, err := r.Master(ctx, "PUBLISH", event, "1")
+	return err
+}
+
+func (r *Redis) Subscribe(ctx context.Context, events ...string) (<-chan string, error) {
+	ch := make(chan string)
+	args := make([]interface{}, len(events))
+	for i, e := range events {
+		args[i] = e
+	}
+	go func() {
+		defer close(ch)
+		for {
+			r.mu.RLock()
+			c := r.local.Get()
+			r.mu.RUnlock()
+
+			psc := redis.PubSubConn{Conn: c}
+			if err := psc.Subscribe(args...); err != nil {
+				logrus.WithError(err).Error("error subscribing to events")
+			} else {
+				r.receive(ctx, psc, ch)
+			}
+			c.Close()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// receive sends messages to the channel until the connection fails or the
+// context is canceled
+func (r *Redis) receive(ctx context.Context, psc redis.PubSubConn, ch chan string) {
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	go func() {
+		select {
+		case <-ctx.Done():
+			psc.Unsubscribe()
+		case <-doneCh:
+		}
+	}()
+	for {
+		switch v := psc.Receive().(type) {
+		case redis.Message:
+			select {
+			case ch <- v.Channel:
+			case <-ctx.Done():
+				return
+			}
+		case redis.Subscription:
+			if v.Count == 0 {
+				return
+			}
+		case error:
+			logrus.WithError(v).Debug("event subscription closed")
+			return
+		default:
+			logrus.Debugf("unknown message type %T: %s", v, v)
+		}
+	}
+}
+
+func (r *Redis) get(ctx context.Context, k string, v proto.Message) error {
+	data, err := redis.Bytes(r.Local(ctx, "GET", k))
+	if err != nil {
+		if err == redis.ErrNil {
+			return ErrNotFound
+		}
+		return err
+	}
+	if err := proto.Unmarshal(data, v); err != nil {
+		return errors.Wrapf(err, "error unmarshalling %s", k)
+	}
+	return nil
+}
+
+func (r *Redis) list(ctx context.Context, prefix string, next func() proto.Message) error {
+	keys, err := redis.Strings(r.Local(ctx, "KEYS", key(prefix, "*")))
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		data, err := redis.Bytes(r.Local(ctx, "GET", k))
+		if err != nil {
+			// key expired or removed since listing
+			if err == redis.ErrNil {
+				continue
+			}
+			return err
+		}
+		if err := proto.Unmarshal(data, next()); err != nil {
+			return errors.Wrapf(err, "error unmarshalling %s", k)
+		}
+	}
+	return nil
+}
+
+func (r *Redis) set(ctx context.Context, k string, v proto.Message, ttl time.Duration) error {
+	data, err := proto.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := r.Master(ctx, "SET", k, data); err != nil {
+		return err
+	}
+	if ttl > 0 {
+		if _, err := r.Master(ctx, "EXPIRE", k, int(ttl.Seconds())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveWithEvent saves the value and records the created event if the key is
+// new or the updated event if the value changed.  Updates are not recorded
+// if updated is nil.
+func (r *Redis) saveWithEvent(ctx context.Context, k string, v proto.Message, ttl time.Duration, created, updated *v1.WatchEvent) error {
+	data, err := proto.Marshal(v)
+	if err != nil {
+		return err
+	}
+	createdData, err := proto.Marshal(created)
+	if err != nil {
+		return err
+	}
+	var updatedData []byte
+	if updated != nil {
+		if updatedData, err = proto.Marshal(updated); err != nil {
+			return err
+		}
+	}
+	_, err = r.eventScript(ctx, saveEventScript, k, data, int(ttl.Seconds()), createdData, updatedData)
+	return err
+}
+
+// deleteWithEvent deletes the key and records the event if the key existed
+func (r *Redis) deleteWithEvent(ctx context.Context, k string, ev *v1.WatchEvent) error {
+	data, err := proto.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	_, err = r.eventScript(ctx, deleteEventScript, k, data)
+	return err
+}
+
+// setWithEvent applies the set command and records the event if the set changed
+func (r *Redis) setWithEvent(ctx context.Context, cmd, k, member string, ev *v1.WatchEvent) error {
+	data, err := proto.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	_, err = r.eventScript(ctx, setEventScript, k, cmd, member, data)
+	return err
+}
+
+// eventScript executes a script that records watch events for the key
+func (r *Redis) eventScript(ctx context.Context, s *redis.Script, k string, args ...interface{}) (interface{}, error) {
+	keys := []interface{}{k, revisionKey, watchEventsKey}
+	return r.script(ctx, s, keys, append([]interface{}{EventWatch, maxWatchEvents}, args...)...)
+}
+
+// script executes the fenced script against the master
+func (r *Redis) script(ctx context.Context, s *redis.Script, keys []interface{}, args ...interface{}) (interface{}, error) {
+	r.mu.RLock()
+	p := r.master
+	term := r.term
+	r.mu.RUnlock()
+	conn, err := p.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	keysAndArgs := append(append(keys, termKey), args...)
+	v, err := s.Do(conn, append(keysAndArgs, term)...)
+	return v, fenced(err)
+}
+
+// fencedScript returns a script that is rejected for stale writers
+func fencedScript(keyCount int, src string) *redis.Script {
+	return redis.NewScript(keyCount+1, fenceCheck+src)
+}
+
+// fenced converts fencing errors returned by scripts to ErrFenced
+func fenced(err error) error {
+	if e, ok := err.(redis.Error); ok && strings.HasPrefix(string(e), "FENCED") {
+		logrus.Debug(string(e))
+		return ErrFenced
+	}
+	return err
+}
+
+func do(ctx context.Context, pool *redis.Pool, cmd string, args ...interface{}) (interface{}, error) {
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return conn.Do(cmd, args...)
+}
+
+func key(prefix, id string) string {
+	return fmt.Sprintf("%s:%s", prefix, id)
+}
+
+func notFound(v string, err error) (string, error) {
+	if err == redis.ErrNil {
+		return "", ErrNotFound
+	}
+	return v, err
+}
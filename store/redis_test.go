@@ -0,0 +1,36 @@
+package store
+
+import (
+	"testing"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/gogo/protobuf/proto"
+)
+
+func TestWithoutUnknown(t *testing.T) {
+	data, err := proto.Marshal(&v1.Peer{ID: "peer-a", PublicKey: "public"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the keypair of previous versions was field 2
+	legacy := append(append([]byte{}, data...), 0x12, 0x07, 'p', 'r', 'i', 'v', 'a', 't', 'e')
+
+	clean, changed, err := withoutUnknown(legacy, &v1.Peer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("expected the legacy keypair to be removed")
+	}
+	var p v1.Peer
+	if err := proto.Unmarshal(clean, &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != "peer-a" || p.PublicKey != "public" || len(p.XXX_unrecognized) != 0 {
+		t.Errorf("expected peer without unknown fields; received %+v", p)
+	}
+
+	if _, changed, err := withoutUnknown(data, &v1.Peer{}); err != nil || changed {
+		t.Errorf("expected current peer to be unchanged; received %t %v", changed, err)
+	}
+}
@@ -0,0 +1,229 @@
+package store
+
+import (
+	"context"
+	"net"
+	"sort"
+	"time"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// EventUpdateTunnel notifies nodes to reconfigure their tunnels
+	EventUpdateTunnel = "heimdall:updatetunnel"
+	// EventWatch notifies watchers that events were recorded
+	EventWatch = "heimdall:watch"
+
+	// maxReconcileReports is the number of reconciliation reports kept
+	maxReconcileReports = 100
+	// maxWatchEvents is the number of watch events kept to resume watches
+	maxWatchEvents = 1000
+)
+
+var (
+	// ErrNotFound is returned when the requested item does not exist in the store
+	ErrNotFound = errors.New("not found")
+	// ErrExists is returned when the requested item is already reserved
+	ErrExists = errors.New("already exists")
+	// ErrFenced is returned when a write is made with a stale master term
+	ErrFenced = errors.New("stale master term")
+	// ErrCompacted is returned when the events after a revision are no longer kept
+	ErrCompacted = errors.New("revision has been compacted")
+)
+
+// Store is the cluster state store
+type Store interface {
+	// GetMaster returns the current cluster master
+	GetMaster(ctx context.Context) (*v1.Master, error)
+	// GetTerm returns the current master election term
+	GetTerm(ctx context.Context) (uint64, error)
+	// AcquireMaster acquires the master lease for the term of the master
+	// info.  ErrFenced is returned if the term is not newer than the current
+	// term or the lease is held by another node.
+	AcquireMaster(ctx context.Context, master *v1.Master, ttl time.Duration) error
+	// SetMaster renews the master lease which expires after the ttl.
+	// ErrFenced is returned if the term has changed or the lease is held by
+	// another node.
+	SetMaster(ctx context.Context, master *v1.Master, ttl time.Duration) error
+	// HandoffMaster transfers the master lease held by from to the node to
+	// for the term which must be the next term.  Writes from the current
+	// term are rejected after the handoff.
+	HandoffMaster(ctx context.Context, from, to string, term uint64, ttl time.Duration) error
+	// GetClusterKey returns the preshared cluster key
+	GetClusterKey(ctx context.Context) (string, error)
+	// SetClusterKey updates the preshared cluster key
+	SetClusterKey(ctx context.Context, key string) error
+	// InitNodeSubnetSize atomically sets the IPv4 and IPv6 node subnet sizes
+	// of the cluster unless they are already set and returns the sizes of
+	// the cluster
+	InitNodeSubnetSize(ctx context.Context, v4, v6 uint32) (uint32, uint32, error)
+
+	// GetNode returns the node by id
+	GetNode(ctx context.Context, id string) (*v1.Node, error)
+	// GetNodes returns all nodes
+	GetNodes(ctx context.Context) ([]*v1.Node, error)
+	// SaveNode saves the node which expires after the ttl and records a
+	// joined event for new nodes
+	SaveNode(ctx context.Context, node *v1.Node, ttl time.Duration) error
+	// DeleteNode removes the node and records a left event
+	DeleteNode(ctx context.Context, id string) error
+
+	// DrainNode marks the node as draining
+	DrainNode(ctx context.Context, id string) error
+	// UndrainNode removes the draining mark from the node
+	UndrainNode(ctx context.Context, id string) error
+	// DrainingNodes returns all draining node ids
+	DrainingNodes(ctx context.Context) ([]string, error)
+	// MarkNodeRemoved marks the node as removed until the ttl expires
+	MarkNodeRemoved(ctx context.Context, id string, ttl time.Duration) error
+	// IsNodeRemoved returns true if the node is marked as removed
+	IsNodeRemoved(ctx context.Context, id string) (bool, error)
+	// ClearNodeRemoved removes the removed mark from the node
+	ClearNodeRemoved(ctx context.Context, id string) error
+
+	// GetNodeNetwork returns the subnets allocated to the node with IPv4
+	// first
+	GetNodeNetwork(ctx context.Context, id string) ([]string, error)
+	// GetNodeNetworks returns all node subnets by node id
+	GetNodeNetworks(ctx context.Context) (map[string][]string, error)
+	// ReserveNodeNetwork atomically reserves the subnet for the node.  If the
+	// node already has an allocation in the address family of the subnet the
+	// existing subnet is returned.  ErrExists is returned if the subnet is
+	// allocated to another node.
+	ReserveNodeNetwork(ctx context.Context, id, network string) (string, error)
+	// DeleteNodeNetwork releases all subnets allocated to the node
+	DeleteNodeNetwork(ctx context.Context, id string) error
+
+	// GetPeer returns the peer by id
+	GetPeer(ctx context.Context, id string) (*v1.Peer, error)
+	// GetPeers returns all peers
+	GetPeers(ctx context.Context) ([]*v1.Peer, error)
+	// SavePeer saves the peer and records an added or updated event if
+	// the peer changed
+	SavePeer(ctx context.Context, peer *v1.Peer) error
+	// DeletePeer removes the peer and records a removed event
+	DeletePeer(ctx context.Context, id string) error
+
+	// GetPeerConfigStatus returns the config delivery status of the peer
+	GetPeerConfigStatus(ctx context.Context, id string) (*v1.PeerConfigStatus, error)
+	// GetPeerConfigStatuses returns the config delivery status of all peers
+	GetPeerConfigStatuses(ctx context.Context) ([]*v1.PeerConfigStatus, error)
+	// SavePeerConfigStatus saves the config delivery status of the peer
+	SavePeerConfigStatus(ctx context.Context, status *v1.PeerConfigStatus) error
+	// DeletePeerConfigStatus removes the config delivery status of the peer
+	DeletePeerConfigStatus(ctx context.Context, id string) error
+
+	// GetPeerIP returns the IPs allocated to the peer with IPv4 first
+	GetPeerIP(ctx context.Context, id string) ([]string, error)
+	// GetPeerIPs returns all allocated peer IPs by peer id with IPv4 first
+	GetPeerIPs(ctx context.Context) (map[string][]string, error)
+	// ReservePeerIP atomically reserves the IP for the peer.  If the peer
+	// already has an allocation in the address family of the IP the existing
+	// IP is returned.  ErrExists is returned if the IP is allocated to
+	// another peer.
+	ReservePeerIP(ctx context.Context, id, ip string) (string, error)
+	// ReplacePeerIP atomically replaces all IPs allocated to the peer with
+	// the IPs.  The existing allocations are kept and ErrExists is returned
+	// if any of the IPs is allocated to another peer.
+	ReplacePeerIP(ctx context.Context, id string, ips []string) error
+	// DeletePeerIP releases all IPs allocated to the peer
+	DeletePeerIP(ctx context.Context, id string) error
+
+	// GetRoute returns the route for the network
+	GetRoute(ctx context.Context, network string) (*v1.Route, error)
+	// GetRoutes returns all routes
+	GetRoutes(ctx context.Context) ([]*v1.Route, error)
+	// SaveRoute saves the route and records a created or updated event if
+	// the route changed
+	SaveRoute(ctx context.Context, route *v1.Route) error
+	// DeleteRoute removes the route for the network and records a deleted
+	// event
+	DeleteRoute(ctx context.Context, network string) error
+
+	// GetPolicy returns the access policy by id
+	GetPolicy(ctx context.Context, id string) (*v1.Policy, error)
+	// GetPolicies returns all access policies
+	GetPolicies(ctx context.Context) ([]*v1.Policy, error)
+	// SavePolicy saves the access policy and records a created or updated
+	// event if the policy changed
+	SavePolicy(ctx context.Context, policy *v1.Policy) error
+	// DeletePolicy removes the access policy and records a deleted event
+	DeletePolicy(ctx context.Context, id string) error
+
+	// AuthorizePeer authorizes the peer id and records an authorized event
+	AuthorizePeer(ctx context.Context, id string) error
+	// DeauthorizePeer removes the authorization for the peer id and records
+	// a deauthorized event
+	DeauthorizePeer(ctx context.Context, id string) error
+	// IsAuthorized returns true if the peer id is authorized
+	IsAuthorized(ctx context.Context, id string) (bool, error)
+	// AuthorizedPeers returns all authorized peer ids
+	AuthorizedPeers(ctx context.Context) ([]string, error)
+
+	// GetPeerTags returns the tags of all peers by peer id
+	GetPeerTags(ctx context.Context) (map[string][]string, error)
+	// SetPeerTags replaces the tags of the peer and records a tagged event
+	// if the tags changed.  Empty tags remove the tags of the peer.
+	SetPeerTags(ctx context.Context, id string, tags []string) error
+
+	// GetAdvertisedRoutes returns the networks advertised by each peer by peer id
+	GetAdvertisedRoutes(ctx context.Context) (map[string][]string, error)
+	// SetAdvertisedRoutes replaces the networks advertised by the peer and
+	// records an event if they changed.  Empty networks remove the entry.
+	SetAdvertisedRoutes(ctx context.Context, id string, networks []string) error
+
+	// SaveReconcileReport saves the report of a split brain reconciliation
+	SaveReconcileReport(ctx context.Context, report *v1.ReconcileReport) error
+	// GetReconcileReports returns the most recent reconciliation reports
+	GetReconcileReports(ctx context.Context) ([]*v1.ReconcileReport, error)
+
+	// Revision returns the revision of the latest watch event
+	Revision(ctx context.Context) (uint64, error)
+	// WatchEvents returns the watch events after the revision.  ErrCompacted
+	// is returned if the events after the revision are no longer kept.
+	WatchEvents(ctx context.Context, revision uint64) ([]*v1.WatchEvent, error)
+
+	// Publish sends the event to all subscribers in the cluster
+	Publish(ctx context.Context, event string) error
+	// Subscribe returns a channel that receives the specified events until
+	// the context is canceled
+	Subscribe(ctx context.Context, events ...string) (<-chan string, error)
+}
+
+func watchEvent(t v1.WatchEvent_Type, id string) *v1.WatchEvent {
+	return &v1.WatchEvent{
+		Type:    t,
+		ID:      id,
+		Created: time.Now(),
+	}
+}
+
+// IsIPv6 returns true if the IP or subnet is an IPv6 address
+func IsIPv6(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		ip, _, _ = net.ParseCIDR(addr)
+	}
+	return ip != nil && ip.To4() == nil
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sortAddresses orders the IPs or subnets with IPv4 first
+func sortAddresses(addrs []string) {
+	sort.SliceStable(addrs, func(i, j int) bool {
+		return !IsIPv6(addrs[i]) && IsIPv6(addrs[j])
+	})
+}
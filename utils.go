@@ -36,7 +36,8 @@ func NodeID() string {
 	return s.String()
 }
 
-// GetIP returns the first non-local IP address for the system
+// GetIP returns the first non-local IP address for the system preferring
+// IPv4 over IPv6
 func GetIP() string {
 	ip := "127.0.0.1"
 	ifaces, err := net.Interfaces()
@@ -44,21 +45,31 @@ func GetIP() string {
 		logrus.Warnf("unable to detect network interfaces")
 		return ip
 	}
+	v6 := ""
 	for _, i := range ifaces {
-		a := getInterfaceIP(i)
-		if a != "" {
-			return a
+		for _, a := range getInterfaceIPs(i) {
+			if a.To4() != nil {
+				return a.To4().String()
+			}
+			if v6 == "" {
+				v6 = a.String()
+			}
 		}
 	}
+	if v6 != "" {
+		return v6
+	}
 
 	return ip
 }
 
-func getInterfaceIP(iface net.Interface) string {
+// getInterfaceIPs returns the global unicast addresses of the interface
+func getInterfaceIPs(iface net.Interface) []net.IP {
 	addrs, err := iface.Addrs()
 	if err != nil {
-		return ""
+		return nil
 	}
+	var ips []net.IP
 	for _, addr := range addrs {
 		var ip net.IP
 		switch v := addr.(type) {
@@ -67,17 +78,14 @@ func getInterfaceIP(iface net.Interface) string {
 		case *net.IPAddr:
 			ip = v.IP
 		}
-		// skip loopback
-		if ip.IsLoopback() {
-			return ""
-		}
-		if ip.To4() == nil {
-			return ""
+		// skip loopback and link local
+		if !ip.IsGlobalUnicast() {
+			continue
 		}
-		return ip.To4().String()
+		ips = append(ips, ip)
 	}
 
-	return ""
+	return ips
 }
 
 // HashData returns a sha256 sum of the specified data
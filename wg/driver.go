@@ -0,0 +1,453 @@
+package wg
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.zx2c4.com/wireguard/wgctrl"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+const (
+	persistentKeepalive = time.Second * 25
+)
+
+// Driver applies Wireguard configuration to a local interface
+type Driver interface {
+	// Apply updates the interface to match the specified configuration
+	Apply(ctx context.Context, cfg *Config) error
+	// Handshakes returns the latest handshake time of each peer by public key
+	Handshakes(ctx context.Context, iface string) (map[string]time.Time, error)
+	// Endpoints returns the current endpoint of each peer by public key.  For
+	// peers behind NAT this is the reflexive address seen by the interface.
+	Endpoints(ctx context.Context, iface string) (map[string]string, error)
+	// Close releases resources held by the driver
+	Close() error
+}
+
+// NewDriver returns the in-process netlink driver when available and falls
+// back to the wg-quick driver otherwise
+func NewDriver() Driver {
+	d, err := newNetlinkDriver()
+	if err != nil {
+		logrus.WithError(err).Warn("wireguard netlink driver unavailable; using wg-quick")
+		return &quickDriver{}
+	}
+	return d
+}
+
+// quickDriver applies configuration by restarting the tunnel with wg-quick
+type quickDriver struct{}
+
+func (d *quickDriver) Apply(ctx context.Context, cfg *Config) error {
+	return RestartTunnel(ctx, cfg.Interface)
+}
+
+func (d *quickDriver) Handshakes(ctx context.Context, iface string) (map[string]time.Time, error) {
+	out, err := wg(ctx, nil, "show", iface, "latest-handshakes")
+	if err != nil {
+		return nil, errors.Wrap(err, strings.TrimSpace(string(out)))
+	}
+	return parseHandshakes(string(out))
+}
+
+func (d *quickDriver) Endpoints(ctx context.Context, iface string) (map[string]string, error) {
+	out, err := wg(ctx, nil, "show", iface, "endpoints")
+	if err != nil {
+		return nil, errors.Wrap(err, strings.TrimSpace(string(out)))
+	}
+	return parseEndpoints(string(out)), nil
+}
+
+func (d *quickDriver) Close() error {
+	return nil
+}
+
+// netlinkDriver applies incremental peer changes to the live interface so that
+// existing sessions are not interrupted.  wg-quick is only used to initially
+// bring the interface up or when interface level settings change.
+type netlinkDriver struct {
+	mu      sync.Mutex
+	client  *wgctrl.Client
+	applied map[string]*Config
+}
+
+func newNetlinkDriver() (*netlinkDriver, error) {
+	c, err := wgctrl.New()
+	if err != nil {
+		return nil, err
+	}
+	return &netlinkDriver{
+		client:  c,
+		applied: map[string]*Config{},
+	}, nil
+}
+
+func (d *netlinkDriver) Apply(ctx context.Context, cfg *Config) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	dev, err := d.client.Device(cfg.Interface)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		logrus.Infof("bringing up tunnel %s", cfg.Interface)
+		if out, err := wgquick(ctx, "up", cfg.Interface); err != nil {
+			return errors.Wrap(err, string(out))
+		}
+		d.applied[cfg.Interface] = cfg
+		return nil
+	}
+
+	// compare against the running device after a process restart so that
+	// live tunnels are not interrupted
+	prev, ok := d.applied[cfg.Interface]
+	if !ok {
+		prev = liveConfig(dev, interfaceAddresses(cfg.Interface), cfg)
+	}
+	if requiresRestart(prev, cfg) {
+		if err := RestartTunnel(ctx, cfg.Interface); err != nil {
+			return err
+		}
+		d.applied[cfg.Interface] = cfg
+		return nil
+	}
+
+	peerCfgs, err := peerConfigs(dev.Peers, cfg.Peers)
+	if err != nil {
+		return err
+	}
+	if len(peerCfgs) > 0 {
+		logrus.Debugf("applying %d peer changes to %s", len(peerCfgs), cfg.Interface)
+		if err := d.client.ConfigureDevice(cfg.Interface, wgtypes.Config{
+			Peers: peerCfgs,
+		}); err != nil {
+			logrus.WithError(err).Warnf("error configuring %s; restarting tunnel", cfg.Interface)
+			if err := RestartTunnel(ctx, cfg.Interface); err != nil {
+				return err
+			}
+			d.applied[cfg.Interface] = cfg
+			return nil
+		}
+	}
+
+	added, removed := routeChanges(prev.Peers, cfg.Peers)
+	for _, r := range removed {
+		if out, err := ip(ctx, "route", "del", r, "dev", cfg.Interface); err != nil {
+			logrus.Warnf("error removing route %s: %s", r, strings.TrimSpace(string(out)))
+		}
+	}
+	for _, r := range added {
+		if out, err := ip(ctx, "route", "replace", r, "dev", cfg.Interface); err != nil {
+			return errors.Wrapf(err, "error adding route %s: %s", r, strings.TrimSpace(string(out)))
+		}
+	}
+
+	d.applied[cfg.Interface] = cfg
+	return nil
+}
+
+func (d *netlinkDriver) Handshakes(ctx context.Context, iface string) (map[string]time.Time, error) {
+	dev, err := d.client.Device(iface)
+	if err != nil {
+		return nil, err
+	}
+	handshakes := make(map[string]time.Time, len(dev.Peers))
+	for _, p := range dev.Peers {
+		handshakes[p.PublicKey.String()] = p.LastHandshakeTime
+	}
+	return handshakes, nil
+}
+
+func (d *netlinkDriver) Endpoints(ctx context.Context, iface string) (map[string]string, error) {
+	dev, err := d.client.Device(iface)
+	if err != nil {
+		return nil, err
+	}
+	endpoints := make(map[string]string, len(dev.Peers))
+	for _, p := range dev.Peers {
+		if p.Endpoint != nil {
+			endpoints[p.PublicKey.String()] = p.Endpoint.String()
+		}
+	}
+	return endpoints, nil
+}
+
+func (d *netlinkDriver) Close() error {
+	return d.client.Close()
+}
+
+// requiresRestart returns true if interface level settings have changed that
+// can only be applied by recreating the interface.  Default routes are set up
+// by wg-quick with policy routing so adding or removing them also requires
+// a restart.
+func requiresRestart(prev, cfg *Config) bool {
+	if prev.PrivateKey != cfg.PrivateKey || prev.ListenPort != cfg.ListenPort || prev.Address != cfg.Address || prev.NodeInterface != cfg.NodeInterface {
+		return true
+	}
+	if hasDefaultRoute(prev.Peers) != hasDefaultRoute(cfg.Peers) {
+		return true
+	}
+	return !equalStrings(prev.DNS, cfg.DNS)
+}
+
+// liveConfig returns the config of the running device.  Settings that cannot
+// be read from the device are taken from cfg.  A random listen port is
+// accepted if cfg does not specify one.
+func liveConfig(dev *wgtypes.Device, addrs []string, cfg *Config) *Config {
+	live := &Config{
+		Interface:     cfg.Interface,
+		NodeInterface: cfg.NodeInterface,
+		PrivateKey:    dev.PrivateKey.String(),
+		ListenPort:    dev.ListenPort,
+		DNS:           cfg.DNS,
+	}
+	if cfg.ListenPort == 0 {
+		live.ListenPort = 0
+	}
+	if equalStrings(addrs, splitAddress(cfg.Address)) {
+		live.Address = cfg.Address
+	} else {
+		live.Address = strings.Join(addrs, ", ")
+	}
+	for _, p := range dev.Peers {
+		peer := &v1.Peer{
+			PublicKey: p.PublicKey.String(),
+		}
+		for _, a := range p.AllowedIPs {
+			peer.AllowedIPs = append(peer.AllowedIPs, a.String())
+		}
+		live.Peers = append(live.Peers, peer)
+	}
+	return live
+}
+
+// interfaceAddresses returns the addresses of the interface with prefix
+func interfaceAddresses(name string) []string {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil
+	}
+	values := []string{}
+	for _, a := range addrs {
+		if n, ok := a.(*net.IPNet); ok && !n.IP.IsLinkLocalUnicast() {
+			values = append(values, n.String())
+		}
+	}
+	return values
+}
+
+// splitAddress returns the addresses of the comma separated Wireguard
+// Address setting
+func splitAddress(address string) []string {
+	values := []string{}
+	for _, a := range strings.Split(address, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			values = append(values, a)
+		}
+	}
+	return values
+}
+
+// peerConfigs returns the peer changes needed to move the current device peers
+// to the desired peers
+func peerConfigs(current []wgtypes.Peer, desired []*v1.Peer) ([]wgtypes.PeerConfig, error) {
+	existing := make(map[wgtypes.Key]wgtypes.Peer, len(current))
+	for _, p := range current {
+		existing[p.PublicKey] = p
+	}
+
+	cfgs := []wgtypes.PeerConfig{}
+	seen := map[wgtypes.Key]struct{}{}
+	for _, peer := range desired {
+		key, err := wgtypes.ParseKey(peer.PublicKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid public key for peer %s", peer.ID)
+		}
+		seen[key] = struct{}{}
+
+		allowedIPs := make([]net.IPNet, 0, len(peer.AllowedIPs))
+		for _, a := range peer.AllowedIPs {
+			_, n, err := net.ParseCIDR(a)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid allowed ip for peer %s", peer.ID)
+			}
+			allowedIPs = append(allowedIPs, *n)
+		}
+
+		var endpoint *net.UDPAddr
+		if peer.Endpoint != "" {
+			endpoint, err = net.ResolveUDPAddr("udp", peer.Endpoint)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid endpoint for peer %s", peer.ID)
+			}
+		}
+
+		if p, ok := existing[key]; ok {
+			sameEndpoint := endpoint == nil || (p.Endpoint != nil && p.Endpoint.String() == endpoint.String())
+			if sameEndpoint && equalIPNets(p.AllowedIPs, allowedIPs) {
+				continue
+			}
+		}
+
+		keepalive := persistentKeepalive
+		cfgs = append(cfgs, wgtypes.PeerConfig{
+			PublicKey:                   key,
+			Endpoint:                    endpoint,
+			PersistentKeepaliveInterval: &keepalive,
+			ReplaceAllowedIPs:           true,
+			AllowedIPs:                  allowedIPs,
+		})
+	}
+
+	for _, p := range current {
+		if _, ok := seen[p.PublicKey]; ok {
+			continue
+		}
+		cfgs = append(cfgs, wgtypes.PeerConfig{
+			PublicKey: p.PublicKey,
+			Remove:    true,
+		})
+	}
+
+	return cfgs, nil
+}
+
+// routeChanges returns the allowed networks that were added and removed
+// between the previous and desired peers
+func routeChanges(prev, desired []*v1.Peer) ([]string, []string) {
+	old := allowedNetworks(prev)
+	cur := allowedNetworks(desired)
+
+	added := []string{}
+	for n := range cur {
+		if _, ok := old[n]; !ok {
+			added = append(added, n)
+		}
+	}
+	removed := []string{}
+	for n := range old {
+		if _, ok := cur[n]; !ok {
+			removed = append(removed, n)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// allowedNetworks returns the allowed networks of the peers except default
+// routes which are managed by wg-quick
+func allowedNetworks(peers []*v1.Peer) map[string]struct{} {
+	networks := map[string]struct{}{}
+	for _, p := range peers {
+		for _, a := range p.AllowedIPs {
+			_, n, err := net.ParseCIDR(a)
+			if err != nil {
+				continue
+			}
+			if ones, _ := n.Mask.Size(); ones == 0 {
+				continue
+			}
+			networks[n.String()] = struct{}{}
+		}
+	}
+	return networks
+}
+
+// hasDefaultRoute returns true if any peer is allowed a default route
+func hasDefaultRoute(peers []*v1.Peer) bool {
+	for _, p := range peers {
+		for _, a := range p.AllowedIPs {
+			if _, n, err := net.ParseCIDR(a); err == nil {
+				if ones, _ := n.Mask.Size(); ones == 0 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func equalIPNets(a, b []net.IPNet) bool {
+	x := make([]string, len(a))
+	for i, n := range a {
+		x[i] = n.String()
+	}
+	y := make([]string, len(b))
+	for i, n := range b {
+		y[i] = n.String()
+	}
+	return equalStrings(x, y)
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	x := append([]string{}, a...)
+	y := append([]string{}, b...)
+	sort.Strings(x)
+	sort.Strings(y)
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// parseHandshakes parses the output of wg show latest-handshakes
+func parseHandshakes(out string) (map[string]time.Time, error) {
+	handshakes := map[string]time.Time{}
+	for _, l := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Fields(l)
+		if len(fields) != 2 {
+			continue
+		}
+		sec, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid handshake time for %s", fields[0])
+		}
+		var t time.Time
+		if sec > 0 {
+			t = time.Unix(sec, 0)
+		}
+		handshakes[fields[0]] = t
+	}
+	return handshakes, nil
+}
+
+// parseEndpoints parses the output of wg show endpoints.  Peers without an
+// endpoint are skipped.
+func parseEndpoints(out string) map[string]string {
+	endpoints := map[string]string{}
+	for _, l := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Fields(l)
+		if len(fields) != 2 || fields[1] == "(none)" {
+			continue
+		}
+		endpoints[fields[0]] = fields[1]
+	}
+	return endpoints
+}
+
+func ip(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "ip", args...)
+	return cmd.CombinedOutput()
+}
@@ -0,0 +1,175 @@
+package wg
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "github.com/ehazlett/heimdall/api/v1"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+func TestPeerConfigs(t *testing.T) {
+	unchanged := mustKey(t)
+	updated := mustKey(t)
+	removed := mustKey(t)
+	added := mustKey(t)
+
+	current := []wgtypes.Peer{
+		{PublicKey: unchanged, AllowedIPs: []net.IPNet{mustNet(t, "10.10.0.0/16")}},
+		{PublicKey: updated, AllowedIPs: []net.IPNet{mustNet(t, "10.11.0.0/16")}},
+		{PublicKey: removed, AllowedIPs: []net.IPNet{mustNet(t, "10.12.0.0/16")}},
+	}
+	desired := []*v1.Peer{
+		{ID: "unchanged", PublicKey: unchanged.String(), AllowedIPs: []string{"10.10.0.0/16"}},
+		{ID: "updated", PublicKey: updated.String(), AllowedIPs: []string{"10.11.0.0/16", "10.100.0.0/24"}},
+		{ID: "added", PublicKey: added.String(), AllowedIPs: []string{"10.13.0.0/16"}},
+	}
+
+	cfgs, err := peerConfigs(current, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := map[wgtypes.Key]wgtypes.PeerConfig{}
+	for _, c := range cfgs {
+		results[c.PublicKey] = c
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 peer changes; received %d", len(results))
+	}
+	if _, ok := results[unchanged]; ok {
+		t.Error("expected unchanged peer to be skipped")
+	}
+	if c := results[updated]; !c.ReplaceAllowedIPs || len(c.AllowedIPs) != 2 {
+		t.Errorf("expected updated peer to replace allowed ips; received %+v", c)
+	}
+	if c := results[removed]; !c.Remove {
+		t.Errorf("expected peer to be removed; received %+v", c)
+	}
+	if c, ok := results[added]; !ok || c.Remove {
+		t.Errorf("expected peer to be added; received %+v", c)
+	}
+}
+
+func TestRouteChanges(t *testing.T) {
+	prev := []*v1.Peer{
+		{AllowedIPs: []string{"10.10.0.0/16", "10.100.0.0/24"}},
+	}
+	desired := []*v1.Peer{
+		{AllowedIPs: []string{"10.10.0.0/16", "10.200.0.0/24"}},
+	}
+	added, removed := routeChanges(prev, desired)
+	if len(added) != 1 || added[0] != "10.200.0.0/24" {
+		t.Errorf("unexpected added routes %v", added)
+	}
+	if len(removed) != 1 || removed[0] != "10.100.0.0/24" {
+		t.Errorf("unexpected removed routes %v", removed)
+	}
+}
+
+func TestDefaultRouteRequiresRestart(t *testing.T) {
+	prev := &Config{
+		Peers: []*v1.Peer{{AllowedIPs: []string{"10.10.0.0/16"}}},
+	}
+	cfg := &Config{
+		Peers: []*v1.Peer{{AllowedIPs: []string{"10.10.0.0/16", "0.0.0.0/0", "::/0"}}},
+	}
+	if !requiresRestart(prev, cfg) {
+		t.Error("expected adding a default route to require a restart")
+	}
+	if requiresRestart(cfg, cfg) {
+		t.Error("expected unchanged config to not require a restart")
+	}
+	// default routes are not managed as device routes
+	if added, _ := routeChanges(prev.Peers, cfg.Peers); len(added) != 0 {
+		t.Errorf("expected no route changes; received %v", added)
+	}
+}
+
+func TestLiveConfig(t *testing.T) {
+	privateKey := mustKey(t)
+	peerKey := mustKey(t)
+	dev := &wgtypes.Device{
+		PrivateKey: privateKey,
+		ListenPort: 10100,
+		Peers: []wgtypes.Peer{
+			{PublicKey: peerKey, AllowedIPs: []net.IPNet{mustNet(t, "10.10.1.0/24")}},
+		},
+	}
+	cfg := &Config{
+		Interface:  "darknet",
+		PrivateKey: privateKey.String(),
+		ListenPort: 10100,
+		Address:    "10.10.0.1/24, fd00::1/64",
+		Peers: []*v1.Peer{
+			{PublicKey: peerKey.String(), AllowedIPs: []string{"10.10.1.0/24", "10.10.2.0/24"}},
+		},
+	}
+	addrs := []string{"fd00::1/64", "10.10.0.1/24"}
+
+	live := liveConfig(dev, addrs, cfg)
+	if requiresRestart(live, cfg) {
+		t.Error("expected running device to not require a restart")
+	}
+	if added, _ := routeChanges(live.Peers, cfg.Peers); len(added) != 1 || added[0] != "10.10.2.0/24" {
+		t.Errorf("expected route 10.10.2.0/24 to be added; received %v", added)
+	}
+	if live := liveConfig(dev, addrs[:1], cfg); !requiresRestart(live, cfg) {
+		t.Error("expected changed addresses to require a restart")
+	}
+	dev.ListenPort = 41641
+	if live := liveConfig(dev, addrs, cfg); !requiresRestart(live, cfg) {
+		t.Error("expected changed listen port to require a restart")
+	}
+	cfg.ListenPort = 0
+	if live := liveConfig(dev, addrs, cfg); requiresRestart(live, cfg) {
+		t.Error("expected random listen port to not require a restart")
+	}
+}
+
+func mustKey(t *testing.T) wgtypes.Key {
+	k, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k.PublicKey()
+}
+
+func mustNet(t *testing.T, cidr string) net.IPNet {
+	_, n, err := net.ParseCIDR(cidr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *n
+}
+
+func TestParseHandshakes(t *testing.T) {
+	out := "a2V5YQ==\t1600000000\na2V5Yg==\t0\n"
+	handshakes, err := parseHandshakes(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(handshakes) != 2 {
+		t.Fatalf("expected 2 handshakes; received %d", len(handshakes))
+	}
+	if !handshakes["a2V5YQ=="].Equal(time.Unix(1600000000, 0)) {
+		t.Errorf("unexpected handshake time %s", handshakes["a2V5YQ=="])
+	}
+	if !handshakes["a2V5Yg=="].IsZero() {
+		t.Errorf("expected zero handshake time; received %s", handshakes["a2V5Yg=="])
+	}
+}
+
+func TestParseEndpoints(t *testing.T) {
+	out := "a2V5YQ==\t203.0.113.10:51820\na2V5Yg==\t(none)\na2V5Yw==\t[2001:db8::1]:41641\n"
+	endpoints := parseEndpoints(out)
+	expected := map[string]string{
+		"a2V5YQ==": "203.0.113.10:51820",
+		"a2V5Yw==": "[2001:db8::1]:41641",
+	}
+	if !reflect.DeepEqual(endpoints, expected) {
+		t.Errorf("expected %v; received %v", expected, endpoints)
+	}
+}
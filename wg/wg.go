@@ -3,6 +3,7 @@ package wg
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"io"
 	"io/ioutil"
 	"os"
@@ -22,12 +23,10 @@ const (
 PrivateKey = {{ .PrivateKey }}
 ListenPort = {{ .ListenPort }}
 Address = {{ .Address }}
-PostUp = iptables -A FORWARD -i {{ .Interface }} -j ACCEPT; iptables -t nat -A POSTROUTING -o {{ .NodeInterface }} -j MASQUERADE; ip6tables -A FORWARD -i {{ .Interface }} -j ACCEPT; ip6tables -t nat -A POSTROUTING -o {{ .NodeInterface }} -j MASQUERADE
-PostDown = iptables -D FORWARD -i {{ .Interface }} -j ACCEPT; iptables -t nat -D POSTROUTING -o {{ .NodeInterface }} -j MASQUERADE; ip6tables -D FORWARD -i {{ .Interface }} -j ACCEPT; ip6tables -t nat -D POSTROUTING -o {{ .NodeInterface }} -j MASQUERADE
 {{ range .Peers }}
 # {{ .ID }}
 [Peer]
-PublicKey = {{ .KeyPair.PublicKey }}
+PublicKey = {{ .PublicKey }}
 PersistentKeepalive = 25
 {{ if .AllowedIPs }}AllowedIPs = {{ csvList .AllowedIPs }}{{ end }}{{ if ne .Endpoint "" }}
 Endpoint = {{ .Endpoint }}{{ end }}
@@ -36,16 +35,17 @@ Endpoint = {{ .Endpoint }}{{ end }}
 	wireguardPeerTemplate = `# managed by heimdall
 [Interface]
 PrivateKey = {{ .PrivateKey }}
-Address = {{ .Address }}
+{{ if .ListenPort }}ListenPort = {{ .ListenPort }}
+{{ end }}Address = {{ .Address }}
 DNS = {{ csvList .DNS }}
 {{ range .Peers }}
-{{ if ne .Endpoint "" }}
+{{ if or (ne .Endpoint "") .Direct }}
 # {{ .ID }}
 [Peer]
-PublicKey = {{ .KeyPair.PublicKey }}
+PublicKey = {{ .PublicKey }}
 PersistentKeepalive = 25
-{{ if .AllowedIPs }}AllowedIPs = {{ csvList .AllowedIPs }}{{ end }}
-Endpoint = {{ .Endpoint }}
+{{ if .AllowedIPs }}AllowedIPs = {{ csvList .AllowedIPs }}{{ end }}{{ if ne .Endpoint "" }}
+Endpoint = {{ .Endpoint }}{{ end }}
 {{ end }}{{ end }}
 `
 )
@@ -122,16 +122,67 @@ func GenerateWireguardKeys(ctx context.Context) (string, string, error) {
 		return "", "", err
 	}
 	privateKey := strings.TrimSpace(string(kData))
+	publicKey, err := PublicKey(ctx, privateKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	return privateKey, publicKey, nil
+}
+
+// PublicKey returns the Wireguard public key for the specified private key
+func PublicKey(ctx context.Context, privateKey string) (string, error) {
 	buf := bytes.NewBufferString(privateKey)
 	pubData, err := wg(ctx, buf, "pubkey")
 	if err != nil {
-		return "", "", err
+		return "", errors.Wrap(err, string(pubData))
+	}
+	return strings.TrimSpace(string(pubData)), nil
+}
+
+// LoadOrGenerateKeys loads the private key from the specified path and returns
+// the private/public keypair.  If the key does not exist a new one is generated
+// and saved.  The private key never leaves the local machine.
+func LoadOrGenerateKeys(ctx context.Context, keyPath string) (string, string, error) {
+	data, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return "", "", err
+		}
+		logrus.Debugf("generating new wireguard key %s", keyPath)
+		privateKey, publicKey, err := GenerateWireguardKeys(ctx)
+		if err != nil {
+			return "", "", err
+		}
+		if err := os.MkdirAll(filepath.Dir(keyPath), 0700); err != nil {
+			return "", "", err
+		}
+		if err := ioutil.WriteFile(keyPath, []byte(privateKey+"\n"), 0600); err != nil {
+			return "", "", err
+		}
+		return privateKey, publicKey, nil
 	}
-	publicKey := strings.TrimSpace(string(pubData))
 
+	privateKey := strings.TrimSpace(string(data))
+	if !ValidKey(privateKey) {
+		return "", "", errors.Errorf("invalid wireguard key in %s", keyPath)
+	}
+	publicKey, err := PublicKey(ctx, privateKey)
+	if err != nil {
+		return "", "", err
+	}
 	return privateKey, publicKey, nil
 }
 
+// ValidKey returns true if the specified value is a valid base64 encoded Wireguard key
+func ValidKey(key string) bool {
+	k, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return false
+	}
+	return len(k) == 32
+}
+
 // RestartTunnel restarts the named tunnel
 func RestartTunnel(ctx context.Context, name string) error {
 	logrus.Infof("restarting tunnel %s", name)